@@ -2,7 +2,9 @@ package app
 
 import (
 	"abude-backend/internal/config"
+	"abude-backend/internal/pkg/transactions/recurring"
 	"abude-backend/pkg/validation"
+	"time"
 )
 
 type AppInstance struct {
@@ -35,3 +37,8 @@ func Load(configFile string) *AppInstance {
 
 	return app
 }
+
+// StartJobs runs the background jobs of the application.
+func (app *AppInstance) StartJobs() {
+	recurring.StartRunner(app.Database.DB, time.Hour)
+}
@@ -2,6 +2,7 @@ package app
 
 import (
 	"abude-backend/internal/config"
+	"abude-backend/internal/pkg/accounts/account"
 	"abude-backend/internal/pkg/auth"
 	"fmt"
 
@@ -43,6 +44,7 @@ func (d *DatabaseInstance) Setup(config *config.DatabaseConfig) {
 
 	d.DB.Callback().Create().Before("gorm:create").Register("editor:before_create", auth.AssignEditor)
 	d.DB.Callback().Update().Before("gorm:update").Register("editor:before_update", auth.AssignEditor)
+	d.DB.Callback().Create().After("gorm:create").Register("account:clone_chart", account.CloneChart)
 }
 
 func (d *DatabaseInstance) debugMode() logger.LogLevel {
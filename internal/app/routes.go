@@ -3,15 +3,28 @@ package app
 import (
 	"abude-backend/internal/common"
 	"abude-backend/internal/pkg/accounts"
+	"abude-backend/internal/pkg/advance"
+	"abude-backend/internal/pkg/attachment"
 	"abude-backend/internal/pkg/attendances"
 	"abude-backend/internal/pkg/auth"
 	"abude-backend/internal/pkg/company"
+	"abude-backend/internal/pkg/customer"
 	"abude-backend/internal/pkg/employee"
 	"abude-backend/internal/pkg/handover"
 	"abude-backend/internal/pkg/inventories"
+	"abude-backend/internal/pkg/loyalty"
+	"abude-backend/internal/pkg/offline"
 	"abude-backend/internal/pkg/outlet"
+	"abude-backend/internal/pkg/payable"
+	"abude-backend/internal/pkg/payroll"
+	"abude-backend/internal/pkg/receipt"
+	"abude-backend/internal/pkg/receivable"
+	"abude-backend/internal/pkg/revision"
+	"abude-backend/internal/pkg/sequence"
 	"abude-backend/internal/pkg/supplier"
 	"abude-backend/internal/pkg/transactions"
+	"abude-backend/internal/pkg/transition"
+	"abude-backend/internal/pkg/trash"
 	"abude-backend/internal/pkg/turnover"
 	"abude-backend/internal/pkg/user"
 
@@ -35,6 +48,7 @@ func LoadRoutes(app *AppInstance) {
 		Router:     api,
 		Controller: ctrl,
 		DB:         db,
+		Config:     app.Server.Config,
 		Auth:       authMiddleware,
 	}
 
@@ -44,12 +58,25 @@ func LoadRoutes(app *AppInstance) {
 	company.LoadRoutes(router)
 	outlet.LoadRoutes(router)
 	supplier.LoadRoutes(router)
+	customer.LoadRoutes(router)
+	loyalty.LoadRoutes(router)
 	inventories.LoadRoutes(router)
 	accounts.LoadRoutes(router)
 	transactions.LoadRoutes(router)
+	offline.LoadRoutes(router)
+	payable.LoadRoutes(router)
+	receivable.LoadRoutes(router)
+	receipt.LoadRoutes(router)
+	attachment.LoadRoutes(router)
 	handover.LoadRoutes(router)
 	turnover.LoadRoutes(router)
 	attendances.LoadRoutes(router)
+	payroll.LoadRoutes(router)
+	advance.LoadRoutes(router)
+	sequence.LoadRoutes(router)
+	transition.LoadRoutes(router)
+	revision.LoadRoutes(router)
+	trash.LoadRoutes(router)
 
 	web.All("/api/*", func(ctx *fiber.Ctx) error {
 		return ctx.Status(404).JSON(fiber.Map{
@@ -1,6 +1,8 @@
 package common
 
 import (
+	"abude-backend/internal/config"
+
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
 )
@@ -9,5 +11,6 @@ type Router struct {
 	Router     fiber.Router
 	Controller *BaseController
 	DB         *gorm.DB
+	Config     *config.ServerConfig
 	Auth       func(level int) func(*fiber.Ctx) error
 }
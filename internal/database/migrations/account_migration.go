@@ -2,7 +2,11 @@ package migrations
 
 import (
 	"abude-backend/internal/pkg/accounts/account"
+	"abude-backend/internal/pkg/accounts/bank"
+	"abude-backend/internal/pkg/accounts/budget"
 	"abude-backend/internal/pkg/accounts/category"
+	"abude-backend/internal/pkg/accounts/journal"
+	"abude-backend/internal/pkg/accounts/period"
 
 	"gorm.io/gorm"
 )
@@ -11,6 +15,14 @@ func MigrateAccount(db *gorm.DB) {
 	err := db.AutoMigrate(
 		&account.Account{},
 		&category.Category{},
+		&journal.Entry{},
+		&journal.Line{},
+		&journal.Mapping{},
+		&period.Period{},
+		&bank.BankAccount{},
+		&bank.Transfer{},
+		&bank.StatementLine{},
+		&budget.Budget{},
 	)
 
 	if err != nil {
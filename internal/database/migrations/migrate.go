@@ -1,20 +1,36 @@
 package migrations
 
 import (
+	"abude-backend/internal/pkg/advance"
+	"abude-backend/internal/pkg/attendances/attendance"
 	"abude-backend/internal/pkg/attendances/shift"
 	"abude-backend/internal/pkg/company"
+	"abude-backend/internal/pkg/customer"
 	"abude-backend/internal/pkg/employee"
 	"abude-backend/internal/pkg/handover"
+	"abude-backend/internal/pkg/idempotency"
 	"abude-backend/internal/pkg/inventories/category"
 	"abude-backend/internal/pkg/inventories/product"
+	"abude-backend/internal/pkg/loyalty"
+	"abude-backend/internal/pkg/offline"
 	"abude-backend/internal/pkg/outlet"
+	"abude-backend/internal/pkg/payable"
+	"abude-backend/internal/pkg/payroll"
+	"abude-backend/internal/pkg/receipt"
+	"abude-backend/internal/pkg/receivable"
+	"abude-backend/internal/pkg/revision"
+	"abude-backend/internal/pkg/sequence"
 	"abude-backend/internal/pkg/supplier"
 	"abude-backend/internal/pkg/transactions/expense"
+	"abude-backend/internal/pkg/transactions/order"
 	"abude-backend/internal/pkg/transactions/purchase"
+	"abude-backend/internal/pkg/transactions/recurring"
 	"abude-backend/internal/pkg/transactions/sale"
 	"abude-backend/internal/pkg/transactions/wage"
+	"abude-backend/internal/pkg/transition"
 	"abude-backend/internal/pkg/turnover"
 	"abude-backend/internal/pkg/user"
+	"abude-backend/pkg/file"
 	"errors"
 
 	"gorm.io/gorm"
@@ -31,18 +47,54 @@ func AutoMigrate(db *gorm.DB) {
 		&product.Ingredient{},
 		&product.Product{},
 		&supplier.Supplier{},
+		&customer.Customer{},
+		&loyalty.Program{},
+		&loyalty.Tier{},
+		&loyalty.Member{},
+		&loyalty.Entry{},
 		&sale.Sale{},
 		&sale.SaleItem{},
+		&sale.SalePayment{},
 		&sale.OutletSale{},
+		&sale.SaleReturn{},
+		&sale.SaleReturnItem{},
 		&purchase.Purchase{},
 		&purchase.PurchaseItem{},
 		&purchase.OutletPurchase{},
+		&order.PurchaseOrder{},
+		&order.OrderItem{},
+		&order.GoodsReceipt{},
+		&order.ReceiptItem{},
+		&payable.Invoice{},
+		&payable.Payment{},
+		&payable.Allocation{},
+		&receivable.Receipt{},
+		&receivable.Allocation{},
+		&receipt.Setting{},
+		&file.File{},
 		&expense.Expense{},
+		&expense.ApprovalRule{},
+		&expense.Approval{},
+		&recurring.Template{},
+		&recurring.Occurrence{},
+		&advance.Advance{},
+		&advance.Repayment{},
 		&wage.Wage{},
 		&handover.Handover{},
 		&handover.Proof{},
 		&turnover.Turnover{},
 		&shift.Shift{},
+		&attendance.Attendance{},
+		&payroll.Scheme{},
+		&payroll.Period{},
+		&payroll.Adjustment{},
+		&payroll.Slip{},
+		&idempotency.Key{},
+		&sequence.Counter{},
+		&sequence.Format{},
+		&offline.Document{},
+		&transition.History{},
+		&revision.Revision{},
 	)
 
 	MigrateAccount(db)
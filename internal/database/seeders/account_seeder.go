@@ -343,6 +343,20 @@ func AccountSeeder(db *gorm.DB) {
 			return err
 		}
 
+		// Accounts are grouped under the X00000 account of their category
+		var parents []account.Account
+		if err := tx.Where("company_id IS NULL AND code LIKE ?", "_00000").Find(&parents).Error; err != nil {
+			return err
+		}
+
+		for _, parent := range parents {
+			if err := tx.Model(&account.Account{}).
+				Where("company_id IS NULL AND category_id = ? AND id != ?", parent.CategoryID, parent.ID).
+				Update("parent_id", parent.ID).Error; err != nil {
+				return err
+			}
+		}
+
 		return nil
 	}); err != nil {
 		panic(err)
@@ -0,0 +1,113 @@
+package account
+
+import (
+	"abude-backend/internal/pkg/accounts/category"
+	"abude-backend/internal/pkg/company"
+
+	"gorm.io/gorm"
+)
+
+// Clone copies the default chart, the categories and accounts without a
+// company, into a company. Codes the company already has are kept as they
+// are, so cloning again only adds what is missing.
+func Clone(tx *gorm.DB, companyId uint) error {
+	var categories []category.Category
+	if err := tx.Where("company_id IS NULL").Find(&categories).Error; err != nil {
+		return err
+	}
+
+	clonedCategories := map[uint]uint{}
+	for _, v := range categories {
+		var own category.Category
+		if err := tx.Where("code = ? AND company_id = ?", v.Code, companyId).Limit(1).Find(&own).Error; err != nil {
+			return err
+		}
+
+		if own.ID == 0 {
+			own = category.Category{
+				Name:        v.Name,
+				Description: v.Description,
+				Code:        v.Code,
+				Normal:      v.Normal,
+				CompanyID:   &companyId,
+			}
+
+			if err := tx.Create(&own).Error; err != nil {
+				return err
+			}
+		}
+
+		clonedCategories[v.ID] = own.ID
+	}
+
+	var accounts []Account
+	if err := tx.Where("company_id IS NULL").Order("code ASC").Find(&accounts).Error; err != nil {
+		return err
+	}
+
+	cloned := map[uint]uint{}
+	var children []Account
+	for _, v := range accounts {
+		var own Account
+		if err := tx.Where("code = ? AND company_id = ?", v.Code, companyId).Limit(1).Find(&own).Error; err != nil {
+			return err
+		}
+
+		if own.ID == 0 {
+			own = Account{
+				Name:        v.Name,
+				Description: v.Description,
+				Code:        v.Code,
+				Normal:      v.Normal,
+				CategoryID:  v.CategoryID,
+				CompanyID:   &companyId,
+			}
+
+			if id, ok := clonedCategories[v.CategoryID]; ok {
+				own.CategoryID = id
+			}
+
+			if err := tx.Create(&own).Error; err != nil {
+				return err
+			}
+
+			if v.ParentID != nil {
+				own.ParentID = v.ParentID
+				children = append(children, own)
+			}
+		}
+
+		cloned[v.ID] = own.ID
+	}
+
+	// Parents are linked once every account of the chart exists
+	for _, v := range children {
+		parent, ok := cloned[*v.ParentID]
+		if !ok {
+			continue
+		}
+
+		if err := tx.Model(&Account{}).Where("id = ?", v.ID).Update("parent_id", parent).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CloneChart is a create callback giving every new company its own copy of
+// the default chart.
+func CloneChart(db *gorm.DB) {
+	if db.Statement.Error != nil {
+		return
+	}
+
+	created, ok := db.Statement.Dest.(*company.Company)
+	if !ok || created.ID == 0 {
+		return
+	}
+
+	if err := Clone(db.Session(&gorm.Session{NewDB: true}), created.ID); err != nil {
+		db.AddError(err)
+	}
+}
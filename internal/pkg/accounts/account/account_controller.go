@@ -56,6 +56,55 @@ func (ctrl *AccountController) All(ctx *fiber.Ctx) error {
 	return ctx.Status(fiber.StatusOK).JSON(result)
 }
 
+// @Summary Get Account Tree
+// @Description Lists the chart of a company as root accounts with their children
+// @Tags Accounts
+// @Accept json
+// @Produce json
+// @Param query query TreeQuery true "query"
+// @Success 200 {object} []Account
+// @Security JWT
+// @Router /api/account/tree [get]
+func (ctrl *AccountController) Tree(ctx *fiber.Ctx) error {
+	var query TreeQuery
+	if err := ctrl.Validation.Query(&query, ctx); err != nil {
+		return err
+	}
+
+	accounts, err := ctrl.account.Tree(query)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(accounts)
+}
+
+// @Summary Clone Default Chart of Accounts
+// @Description Copies the default categories and accounts the company does not have yet
+// @Tags Accounts
+// @Accept json
+// @Produce json
+// @Param request body CloneDTO true "Request Body"
+// @Success 200 {object} common.GeneralResponse{result=[]Account}
+// @Security JWT
+// @Router /api/account/clone [post]
+func (ctrl *AccountController) Clone(ctx *fiber.Ctx) error {
+	var data CloneDTO
+	if err := ctrl.Validation.Body(&data, ctx); err != nil {
+		return err
+	}
+
+	accounts, err := ctrl.account.Clone(data)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.GeneralResponse{
+		Message: "Bagan akun berhasil disalin",
+		Result:  accounts,
+	})
+}
+
 // @Summary Create Account
 // @Tags Accounts
 // @Accept json
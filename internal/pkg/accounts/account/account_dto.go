@@ -9,6 +9,7 @@ type AccountDTO struct {
 	Normal      int    `json:"normal" form:"normal" validate:"required,oneof=1 -1"`
 	Category    uint   `json:"category" form:"category" validate:"required,exist=account_categories"`
 	Company     uint   `json:"company" form:"company" validate:"required,exist=companies"`
+	Parent      *uint  `json:"parent" form:"parent" validate:"omitempty,exist=accounts"`
 }
 
 type AccountQuery struct {
@@ -16,4 +17,13 @@ type AccountQuery struct {
 	Keyword  string `query:"keyword"`
 	Category int    `query:"category"`
 	Company  int    `query:"company"`
+	Parent   int    `query:"parent"`
+}
+
+type TreeQuery struct {
+	Company uint `query:"company" validate:"required,exist=companies"`
+}
+
+type CloneDTO struct {
+	Company uint `json:"company" form:"company" validate:"required,exist=companies"`
 }
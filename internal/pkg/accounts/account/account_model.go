@@ -21,13 +21,27 @@ type Account struct {
 
 	Company   *company.Company `json:"company,omitempty" gorm:"constraint:OnDelete:CASCADE;"`
 	CompanyID *uint            `json:"-"`
+
+	// Accounts may be grouped under a parent account of the same category
+	Parent   *Account  `json:"parent,omitempty" gorm:"constraint:OnDelete:RESTRICT;"`
+	ParentID *uint     `json:"-"`
+	Children []Account `json:"children,omitempty" gorm:"-"`
 }
 
+// BeforeSave keeps codes unique within a company, and within the default
+// chart for accounts without a company.
 func (account *Account) BeforeSave(tx *gorm.DB) error {
+	db := tx.Model(&Account{}).
+		Where("code = ? AND id != ?", account.Code, account.ID)
+
+	if account.CompanyID == nil {
+		db.Where("company_id IS NULL")
+	} else {
+		db.Where("company_id = ?", *account.CompanyID)
+	}
+
 	var count int64
-	if err := tx.Model(&Account{}).
-		Where("code = ? AND company_id = ? AND id != ?", account.Code, account.CompanyID, account.ID).
-		Count(&count).Error; err != nil {
+	if err := db.Count(&count).Error; err != nil {
 		return err
 	}
 
@@ -18,7 +18,11 @@ func NewService(db *gorm.DB) *AccountService {
 
 func (s *AccountService) FindOne(id int) (*Account, error) {
 	var account Account
-	if err := s.db.First(&account, id).Error; err != nil {
+	if err := s.db.Preload("Category").Preload("Parent").First(&account, id).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	if err := s.db.Where("parent_id = ?", account.ID).Order("code ASC").Find(&account.Children).Error; err != nil {
 		return nil, exception.DB(err)
 	}
 
@@ -49,7 +53,11 @@ func (s *AccountService) FindAll(query AccountQuery) *pagination.Result[Account]
 	}
 
 	if query.Company != 0 {
-		db.Where("company_id = ? OR company_id IS NULL", query.Company)
+		s.visible(db, uint(query.Company))
+	}
+
+	if query.Parent != 0 {
+		db.Where("parent_id = ?", query.Parent)
 	}
 
 	db.Order("code ASC")
@@ -57,6 +65,111 @@ func (s *AccountService) FindAll(query AccountQuery) *pagination.Result[Account]
 	return result.Paginate(db)
 }
 
+// Tree lists the chart of a company as root accounts with their children.
+func (s *AccountService) Tree(query TreeQuery) ([]Account, error) {
+	var accounts []Account
+	if err := s.visible(s.db.Preload("Category"), query.Company).Order("code ASC").Find(&accounts).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	children := map[uint][]int{}
+	for i, v := range accounts {
+		if v.ParentID != nil {
+			children[*v.ParentID] = append(children[*v.ParentID], i)
+		}
+	}
+
+	var build func(account Account, depth int) Account
+	build = func(account Account, depth int) Account {
+		// Guards against a parent loop in the data
+		if depth > len(accounts) {
+			return account
+		}
+
+		for _, i := range children[account.ID] {
+			account.Children = append(account.Children, build(accounts[i], depth+1))
+		}
+
+		return account
+	}
+
+	ids := map[uint]bool{}
+	for _, v := range accounts {
+		ids[v.ID] = true
+	}
+
+	roots := []Account{}
+	for _, v := range accounts {
+		if v.ParentID == nil || !ids[*v.ParentID] {
+			roots = append(roots, build(v, 0))
+		}
+	}
+
+	return roots, nil
+}
+
+// Clone copies the default chart into a company that was created before
+// companies got their own chart.
+func (s *AccountService) Clone(data CloneDTO) ([]Account, error) {
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		return Clone(tx, data.Company)
+	}); err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return s.Tree(TreeQuery{Company: data.Company})
+}
+
+// visible limits accounts to those of a company and the default accounts it
+// has no copy of.
+func (s *AccountService) visible(db *gorm.DB, company uint) *gorm.DB {
+	return db.Where("company_id = ? OR (company_id IS NULL AND code NOT IN (?))", company, s.db.
+		Model(&Account{}).
+		Select("code").
+		Where("company_id = ?", company),
+	)
+}
+
+// checkParent makes sure the parent is an account of the same company and
+// category, and not the account itself or one of its descendants.
+func (s *AccountService) checkParent(account *Account) error {
+	if account.ParentID == nil {
+		return nil
+	}
+
+	var parent Account
+	if err := s.db.First(&parent, *account.ParentID).Error; err != nil {
+		return exception.DB(err, "Akun induk")
+	}
+
+	if parent.CompanyID == nil || *parent.CompanyID != *account.CompanyID || parent.CategoryID != account.CategoryID {
+		return exception.Validation(map[string]string{
+			"parent": "Akun induk harus berada pada perusahaan dan kategori yang sama",
+		})
+	}
+
+	visited := map[uint]bool{}
+	for current := &parent; ; {
+		if current.ID == account.ID || visited[current.ID] {
+			return exception.Validation(map[string]string{
+				"parent": "Akun induk tidak boleh akun itu sendiri atau sub akunnya",
+			})
+		}
+
+		visited[current.ID] = true
+		if current.ParentID == nil {
+			return nil
+		}
+
+		var next Account
+		if err := s.db.First(&next, *current.ParentID).Error; err != nil {
+			return exception.DB(err)
+		}
+
+		current = &next
+	}
+}
+
 func (s *AccountService) Create(data AccountDTO) (*Account, error) {
 	account := Account{
 		Name:        data.Name,
@@ -65,6 +178,11 @@ func (s *AccountService) Create(data AccountDTO) (*Account, error) {
 		Normal:      data.Normal,
 		CategoryID:  data.Category,
 		CompanyID:   &data.Company,
+		ParentID:    data.Parent,
+	}
+
+	if err := s.checkParent(&account); err != nil {
+		return nil, err
 	}
 
 	if err := s.db.Create(&account).Error; err != nil {
@@ -80,12 +198,21 @@ func (s *AccountService) Update(id int, data AccountDTO) (*Account, error) {
 		return nil, exception.DB(err)
 	}
 
+	if account.CompanyID == nil {
+		return nil, exception.BadRequest("Akun bawaan tidak dapat diubah, salin bagan akun ke perusahaan terlebih dahulu")
+	}
+
 	account.Name = data.Name
 	account.Description = data.Description
 	account.Code = data.Code
 	account.Normal = data.Normal
 	account.CategoryID = data.Category
 	account.CompanyID = &data.Company
+	account.ParentID = data.Parent
+
+	if err := s.checkParent(&account); err != nil {
+		return nil, err
+	}
 
 	if err := s.db.Save(&account).Error; err != nil {
 		return nil, exception.DB(err)
@@ -100,6 +227,19 @@ func (s *AccountService) Delete(id int) (*Account, error) {
 		return nil, exception.DB(err)
 	}
 
+	if account.CompanyID == nil {
+		return nil, exception.BadRequest("Akun bawaan tidak dapat dihapus")
+	}
+
+	var children int64
+	if err := s.db.Model(&Account{}).Where("parent_id = ?", account.ID).Count(&children).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	if children > 0 {
+		return nil, exception.BadRequest("Akun yang memiliki sub akun tidak dapat dihapus")
+	}
+
 	if err := s.db.Delete(&account).Error; err != nil {
 		return nil, exception.DB(err)
 	}
@@ -3,12 +3,24 @@ package accounts
 import (
 	"abude-backend/internal/common"
 	"abude-backend/internal/pkg/accounts/account"
+	"abude-backend/internal/pkg/accounts/bank"
+	"abude-backend/internal/pkg/accounts/budget"
 	"abude-backend/internal/pkg/accounts/category"
+	"abude-backend/internal/pkg/accounts/journal"
+	"abude-backend/internal/pkg/accounts/period"
+	"abude-backend/internal/pkg/accounts/statement"
 )
 
 func LoadRoutes(r *common.Router) {
 	categoryService := category.NewService(r.DB)
 	accountService := account.NewService(r.DB)
+	journalService := journal.NewService(r.DB)
+	statementService := statement.NewService(r.DB)
+	periodService := period.NewService(r.DB)
+	bankService := bank.NewService(r.DB)
+	transferService := bank.NewTransferService(r.DB)
+	bankStatementService := bank.NewStatementService(r.DB)
+	budgetService := budget.NewService(r.DB)
 
 	categoryHandler := category.NewController(r.Controller, categoryService)
 	r.Router.Get("/account-category", r.Auth(1), categoryHandler.All)
@@ -19,8 +31,60 @@ func LoadRoutes(r *common.Router) {
 
 	accountHandler := account.NewController(r.Controller, accountService)
 	r.Router.Get("/account", r.Auth(1), accountHandler.All)
+	r.Router.Get("/account/tree", r.Auth(1), accountHandler.Tree)
+	r.Router.Post("/account/clone", r.Auth(2), accountHandler.Clone)
 	r.Router.Get("/account/:id", r.Auth(1), accountHandler.One)
 	r.Router.Post("/account", r.Auth(2), accountHandler.Create)
 	r.Router.Put("/account/:id", r.Auth(2), accountHandler.Update)
 	r.Router.Delete("/account/:id", r.Auth(2), accountHandler.Delete)
+
+	journalHandler := journal.NewController(r.Controller, journalService)
+	r.Router.Get("/journal", r.Auth(2), journalHandler.All)
+	r.Router.Get("/journal/mapping", r.Auth(2), journalHandler.Mappings)
+	r.Router.Put("/journal/mapping", r.Auth(3), journalHandler.SaveMappings)
+	r.Router.Get("/journal/:id", r.Auth(2), journalHandler.One)
+	r.Router.Post("/journal", r.Auth(2), journalHandler.Create)
+	r.Router.Patch("/journal/:id/reverse", r.Auth(2), journalHandler.Reverse)
+
+	statementHandler := statement.NewController(r.Controller, statementService)
+	r.Router.Get("/statement/trial-balance", r.Auth(3), statementHandler.TrialBalance)
+	r.Router.Get("/statement/income-statement", r.Auth(3), statementHandler.IncomeStatement)
+	r.Router.Get("/statement/balance-sheet", r.Auth(3), statementHandler.BalanceSheet)
+	r.Router.Get("/statement/cash-flow", r.Auth(3), statementHandler.CashFlow)
+	r.Router.Get("/statement/cash-position", r.Auth(3), statementHandler.CashPosition)
+
+	periodHandler := period.NewController(r.Controller, periodService)
+	r.Router.Get("/accounting-period", r.Auth(3), periodHandler.All)
+	r.Router.Post("/accounting-period/close", r.Auth(3), periodHandler.Close)
+	r.Router.Post("/accounting-period/reopen", r.Auth(4), periodHandler.Reopen)
+
+	bankHandler := bank.NewController(r.Controller, bankService)
+	r.Router.Get("/bank-account", r.Auth(2), bankHandler.All)
+	r.Router.Get("/bank-account/:id", r.Auth(2), bankHandler.One)
+	r.Router.Post("/bank-account", r.Auth(3), bankHandler.Create)
+	r.Router.Put("/bank-account/:id", r.Auth(3), bankHandler.Update)
+	r.Router.Delete("/bank-account/:id", r.Auth(3), bankHandler.Delete)
+
+	transferHandler := bank.NewTransferController(r.Controller, transferService)
+	r.Router.Get("/bank-transfer", r.Auth(2), transferHandler.All)
+	r.Router.Get("/bank-transfer/:id", r.Auth(2), transferHandler.One)
+	r.Router.Post("/bank-transfer", r.Auth(2), transferHandler.Create)
+	r.Router.Patch("/bank-transfer/:id/cancel", r.Auth(3), transferHandler.Cancel)
+
+	bankStatementHandler := bank.NewStatementController(r.Controller, bankStatementService)
+	r.Router.Get("/bank-statement", r.Auth(3), bankStatementHandler.All)
+	r.Router.Post("/bank-statement/import", r.Auth(3), bankStatementHandler.Import)
+	r.Router.Post("/bank-statement/auto-match", r.Auth(3), bankStatementHandler.AutoMatch)
+	r.Router.Get("/bank-statement/:id/candidates", r.Auth(3), bankStatementHandler.Candidates)
+	r.Router.Patch("/bank-statement/:id/match", r.Auth(3), bankStatementHandler.Match)
+	r.Router.Patch("/bank-statement/:id/unmatch", r.Auth(3), bankStatementHandler.Unmatch)
+	r.Router.Delete("/bank-statement/:id", r.Auth(3), bankStatementHandler.Delete)
+
+	budgetHandler := budget.NewController(r.Controller, budgetService)
+	r.Router.Get("/budget", r.Auth(2), budgetHandler.All)
+	r.Router.Get("/budget/variance", r.Auth(3), budgetHandler.Variance)
+	r.Router.Get("/budget/:id", r.Auth(2), budgetHandler.One)
+	r.Router.Post("/budget", r.Auth(3), budgetHandler.Create)
+	r.Router.Put("/budget/:id", r.Auth(3), budgetHandler.Update)
+	r.Router.Delete("/budget/:id", r.Auth(3), budgetHandler.Delete)
 }
@@ -0,0 +1,139 @@
+package bank
+
+import (
+	"abude-backend/internal/common"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type BankController struct {
+	*common.BaseController
+	bank *BankService
+}
+
+func NewController(ctrl *common.BaseController, bank *BankService) *BankController {
+	return &BankController{ctrl, bank}
+}
+
+// @Summary Get One Bank Account
+// @Tags Bank Accounts
+// @Accept json
+// @Produce json
+// @Param id path string true "Bank Account ID"
+// @Success 200 {object} BankAccount{}
+// @Security JWT
+// @Router /api/bank-account/{id} [get]
+func (ctrl *BankController) One(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	bank, err := ctrl.bank.WithContext(ctx.Context()).FindOne(id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(bank)
+}
+
+// @Summary Get All Bank Accounts
+// @Tags Bank Accounts
+// @Accept json
+// @Produce json
+// @Param query query BankAccountQuery false "query"
+// @Success 200 {object} common.PaginatedResponse{result=[]BankAccount}
+// @Security JWT
+// @Router /api/bank-account [get]
+func (ctrl *BankController) All(ctx *fiber.Ctx) error {
+	var query BankAccountQuery
+	if err := ctrl.Validation.Query(&query, ctx); err != nil {
+		return err
+	}
+
+	result := ctrl.bank.WithContext(ctx.Context()).FindAll(query)
+
+	return ctx.Status(fiber.StatusOK).JSON(result)
+}
+
+// @Summary Create Bank Account
+// @Description The ledger account must be an asset. The mapping tells how to read CSV statements of the account.
+// @Tags Bank Accounts
+// @Accept json
+// @Produce json
+// @Param request body BankAccountDTO true "Request Body"
+// @Success 201 {object} common.GeneralResponse{result=BankAccount}
+// @Security JWT
+// @Router /api/bank-account [post]
+func (ctrl *BankController) Create(ctx *fiber.Ctx) error {
+	var data BankAccountDTO
+	if err := ctrl.Validation.Body(&data, ctx); err != nil {
+		return err
+	}
+
+	bank, err := ctrl.bank.WithContext(ctx.Context()).Create(data)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(common.GeneralResponse{
+		Message: "Rekening berhasil dibuat",
+		Result:  bank,
+	})
+}
+
+// @Summary Update Bank Account
+// @Tags Bank Accounts
+// @Accept json
+// @Produce json
+// @Param id path string true "Bank Account ID"
+// @Param request body BankAccountDTO true "Request Body"
+// @Success 200 {object} common.GeneralResponse{result=BankAccount}
+// @Security JWT
+// @Router /api/bank-account/{id} [put]
+func (ctrl *BankController) Update(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	var data BankAccountDTO
+	if err := ctrl.Validation.Body(&data, ctx); err != nil {
+		return err
+	}
+
+	bank, err := ctrl.bank.WithContext(ctx.Context()).Update(id, data)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.GeneralResponse{
+		Message: "Rekening berhasil diubah",
+		Result:  bank,
+	})
+}
+
+// @Summary Delete Bank Account
+// @Tags Bank Accounts
+// @Accept json
+// @Produce json
+// @Param id path string true "Bank Account ID"
+// @Success 200 {object} common.GeneralResponse{result=BankAccount}
+// @Security JWT
+// @Router /api/bank-account/{id} [delete]
+func (ctrl *BankController) Delete(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	bank, err := ctrl.bank.WithContext(ctx.Context()).Delete(id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.GeneralResponse{
+		Message: "Rekening berhasil dihapus",
+		Result:  bank,
+	})
+}
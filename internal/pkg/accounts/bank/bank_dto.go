@@ -0,0 +1,38 @@
+package bank
+
+import "abude-backend/pkg/pagination"
+
+type MappingDTO struct {
+	Delimiter         string `json:"delimiter" validate:"omitempty,len=1" example:","`
+	SkipRows          int    `json:"skipRows" validate:"gte=0"`
+	DateColumn        int    `json:"dateColumn" validate:"required,gt=0"`
+	DateFormat        string `json:"dateFormat" validate:"omitempty,max=30" example:"02/01/2006"`
+	DescriptionColumn int    `json:"descriptionColumn" validate:"gte=0"`
+	ReferenceColumn   int    `json:"referenceColumn" validate:"gte=0"`
+	AmountColumn      int    `json:"amountColumn" validate:"required_without_all=DebitColumn CreditColumn,gte=0"`
+	DebitColumn       int    `json:"debitColumn" validate:"required_with=CreditColumn,gte=0"`
+	CreditColumn      int    `json:"creditColumn" validate:"required_with=DebitColumn,gte=0"`
+	DecimalSeparator  string `json:"decimalSeparator" validate:"omitempty,oneof=. ," example:"."`
+}
+
+type BankAccountDTO struct {
+	Name           string      `json:"name" form:"name" validate:"required,max=100"`
+	Type           string      `json:"type" form:"type" validate:"required,oneof=cash bank qris" enums:"cash,bank,qris"`
+	BankName       string      `json:"bankName" form:"bankName" validate:"omitempty,max=100"`
+	Number         string      `json:"number" form:"number" validate:"omitempty,max=50"`
+	Holder         string      `json:"holder" form:"holder" validate:"omitempty,max=100"`
+	OpeningBalance float64     `json:"openingBalance" form:"openingBalance" validate:"omitempty"`
+	Active         *bool       `json:"active" form:"active" validate:"omitempty"`
+	Mapping        *MappingDTO `json:"mapping" form:"mapping" validate:"omitempty"`
+	Account        uint        `json:"account" form:"account" validate:"required,exist=accounts"`
+	Company        uint        `json:"company" form:"company" validate:"required,exist=companies"`
+	Outlet         *uint       `json:"outlet" form:"outlet" validate:"omitempty,exist=outlets"`
+}
+
+type BankAccountQuery struct {
+	pagination.Pagination
+	Company uint     `query:"company"`
+	Outlet  uint     `query:"outlet"`
+	Type    []string `query:"type" enums:"cash,bank,qris"`
+	Active  *bool    `query:"active"`
+}
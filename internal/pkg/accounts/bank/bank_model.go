@@ -0,0 +1,58 @@
+package bank
+
+import (
+	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/accounts/account"
+	"abude-backend/internal/pkg/company"
+	"abude-backend/internal/pkg/outlet"
+)
+
+const (
+	TypeCash = "cash" // Cash drawer of an outlet or the office
+	TypeBank = "bank"
+	TypeQris = "qris" // QRIS merchant settlement balance
+)
+
+// Mapping tells how to read the CSV statement of a bank. Columns are counted
+// from 1 and 0 leaves a column unused. Statements either have a signed amount
+// column or separate debit (money out) and credit (money in) columns.
+type Mapping struct {
+	Delimiter         string `json:"delimiter" gorm:"type:varchar(1);default:','"`
+	SkipRows          int    `json:"skipRows"` // Header rows before the first line
+	DateColumn        int    `json:"dateColumn"`
+	DateFormat        string `json:"dateFormat" gorm:"type:varchar(30);default:'02/01/2006'"`
+	DescriptionColumn int    `json:"descriptionColumn"`
+	ReferenceColumn   int    `json:"referenceColumn"`
+	AmountColumn      int    `json:"amountColumn"`
+	DebitColumn       int    `json:"debitColumn"`
+	CreditColumn      int    `json:"creditColumn"`
+	DecimalSeparator  string `json:"decimalSeparator" gorm:"type:varchar(1);default:'.'"`
+}
+
+// BankAccount is a place the money of a company is kept, booked on a ledger
+// account of the asset category.
+type BankAccount struct {
+	common.BaseModel
+	Name           string  `json:"name" gorm:"type:varchar(100)"`
+	Type           string  `json:"type" gorm:"type:enum('cash','bank','qris')" enums:"cash,bank,qris"`
+	BankName       string  `json:"bankName" gorm:"type:varchar(100)"`
+	Number         string  `json:"number" gorm:"type:varchar(50)"`
+	Holder         string  `json:"holder" gorm:"type:varchar(100)"`
+	OpeningBalance float64 `json:"openingBalance"`
+	Active         bool    `json:"active" gorm:"default:true"`
+
+	Mapping Mapping `json:"mapping" gorm:"embedded;embeddedPrefix:csv_"`
+
+	Account   *account.Account `json:"account,omitempty" gorm:"constraint:OnDelete:RESTRICT;"`
+	AccountID uint             `json:"-"`
+
+	Company   *company.Company `json:"company,omitempty" gorm:"constraint:OnDelete:CASCADE;"`
+	CompanyID uint             `json:"-"`
+
+	Outlet   *outlet.Outlet `json:"outlet,omitempty" gorm:"constraint:OnDelete:SET NULL;"`
+	OutletID *uint          `json:"-"`
+}
+
+func (BankAccount) TableName() string {
+	return "bank_accounts"
+}
@@ -0,0 +1,203 @@
+package bank
+
+import (
+	"abude-backend/internal/pkg/accounts/account"
+	"abude-backend/internal/pkg/accounts/category"
+	"abude-backend/internal/pkg/outlet"
+	"abude-backend/pkg/exception"
+	"abude-backend/pkg/pagination"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type BankService struct {
+	db *gorm.DB
+}
+
+func NewService(db *gorm.DB) *BankService {
+	return &BankService{db}
+}
+
+func (s *BankService) FindOne(id int) (*BankAccount, error) {
+	var bank BankAccount
+	if err := s.db.Preload("Account").Preload("Company").Preload("Outlet").First(&bank, id).Error; err != nil {
+		return nil, exception.DB(err, "Rekening")
+	}
+
+	return &bank, nil
+}
+
+func (s *BankService) FindAll(query BankAccountQuery) *pagination.Result[BankAccount] {
+	result := pagination.New[BankAccount](query.Pagination)
+
+	db := s.db.Model(&BankAccount{}).Preload("Account").Preload("Outlet")
+	if query.Company != 0 {
+		db.Where("company_id = ?", query.Company)
+	}
+
+	if query.Outlet != 0 {
+		db.Where("outlet_id = ?", query.Outlet)
+	}
+
+	if len(query.Type) > 0 {
+		db.Where("type IN (?)", query.Type)
+	}
+
+	if query.Active != nil {
+		db.Where("active = ?", *query.Active)
+	}
+
+	db.Order("name ASC")
+
+	return result.Paginate(db)
+}
+
+func (s *BankService) Create(data BankAccountDTO) (*BankAccount, error) {
+	bank := BankAccount{Active: true}
+	fill(&bank, data)
+
+	if err := s.check(&bank); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Create(&bank).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &bank, nil
+}
+
+func (s *BankService) Update(id int, data BankAccountDTO) (*BankAccount, error) {
+	var bank BankAccount
+	if err := s.db.First(&bank, id).Error; err != nil {
+		return nil, exception.DB(err, "Rekening")
+	}
+
+	if bank.CompanyID != data.Company {
+		return nil, exception.Validation(map[string]string{
+			"company": "Perusahaan rekening tidak dapat diubah",
+		})
+	}
+
+	fill(&bank, data)
+
+	if err := s.check(&bank); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Save(&bank).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &bank, nil
+}
+
+func (s *BankService) Delete(id int) (*BankAccount, error) {
+	var bank BankAccount
+	if err := s.db.First(&bank, id).Error; err != nil {
+		return nil, exception.DB(err, "Rekening")
+	}
+
+	var transfers int64
+	if err := s.db.Model(&Transfer{}).Where("from_id = ? OR to_id = ?", bank.ID, bank.ID).Count(&transfers).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	if transfers > 0 {
+		return nil, exception.BadRequest("Rekening yang memiliki transfer tidak dapat dihapus, nonaktifkan rekening ini")
+	}
+
+	if err := s.db.Delete(&bank).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &bank, nil
+}
+
+func fill(bank *BankAccount, data BankAccountDTO) {
+	bank.Name = data.Name
+	bank.Type = data.Type
+	bank.BankName = data.BankName
+	bank.Number = data.Number
+	bank.Holder = data.Holder
+	bank.OpeningBalance = data.OpeningBalance
+	bank.AccountID = data.Account
+	bank.CompanyID = data.Company
+	bank.OutletID = data.Outlet
+
+	if data.Active != nil {
+		bank.Active = *data.Active
+	}
+
+	if data.Mapping != nil {
+		bank.Mapping = Mapping{
+			Delimiter:         data.Mapping.Delimiter,
+			SkipRows:          data.Mapping.SkipRows,
+			DateColumn:        data.Mapping.DateColumn,
+			DateFormat:        data.Mapping.DateFormat,
+			DescriptionColumn: data.Mapping.DescriptionColumn,
+			ReferenceColumn:   data.Mapping.ReferenceColumn,
+			AmountColumn:      data.Mapping.AmountColumn,
+			DebitColumn:       data.Mapping.DebitColumn,
+			CreditColumn:      data.Mapping.CreditColumn,
+			DecimalSeparator:  data.Mapping.DecimalSeparator,
+		}
+	}
+
+	if bank.Mapping.Delimiter == "" {
+		bank.Mapping.Delimiter = ","
+	}
+
+	if bank.Mapping.DateFormat == "" {
+		bank.Mapping.DateFormat = "02/01/2006"
+	}
+
+	if bank.Mapping.DecimalSeparator == "" {
+		bank.Mapping.DecimalSeparator = "."
+	}
+}
+
+// check makes sure the ledger account is an asset the company can use and the
+// outlet belongs to the company.
+func (s *BankService) check(bank *BankAccount) error {
+	var ledger account.Account
+	if err := s.db.Preload("Category").First(&ledger, bank.AccountID).Error; err != nil {
+		return exception.DB(err, "Akun")
+	}
+
+	if ledger.CompanyID != nil && *ledger.CompanyID != bank.CompanyID {
+		return exception.Validation(map[string]string{
+			"account": "Akun bukan milik perusahaan ini",
+		})
+	}
+
+	if ledger.Category.Code != category.CodeAsset {
+		return exception.Validation(map[string]string{
+			"account": "Rekening harus dicatat pada akun aset",
+		})
+	}
+
+	if bank.OutletID != nil {
+		var record outlet.Outlet
+		if err := s.db.First(&record, *bank.OutletID).Error; err != nil {
+			return exception.DB(err, "Outlet")
+		}
+
+		if record.CompanyID != bank.CompanyID {
+			return exception.Validation(map[string]string{
+				"outlet": "Outlet bukan milik perusahaan ini",
+			})
+		}
+	}
+
+	return nil
+}
+
+func (s *BankService) Using(tx *gorm.DB) *BankService {
+	return &BankService{tx}
+}
+
+func (s *BankService) WithContext(ctx context.Context) *BankService {
+	return &BankService{s.db.WithContext(ctx)}
+}
@@ -0,0 +1,202 @@
+package bank
+
+import (
+	"abude-backend/internal/common"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type StatementController struct {
+	*common.BaseController
+	statement *StatementService
+}
+
+func NewStatementController(ctrl *common.BaseController, statement *StatementService) *StatementController {
+	return &StatementController{ctrl, statement}
+}
+
+// @Summary Get Bank Statement Lines
+// @Description Matched lines include the document they are matched to
+// @Tags Bank Reconciliation
+// @Accept json
+// @Produce json
+// @Param query query StatementQuery true "query"
+// @Success 200 {object} common.PaginatedResponse{result=[]StatementLine}
+// @Security JWT
+// @Router /api/bank-statement [get]
+func (ctrl *StatementController) All(ctx *fiber.Ctx) error {
+	var query StatementQuery
+	if err := ctrl.Validation.Query(&query, ctx); err != nil {
+		return err
+	}
+
+	result, err := ctrl.statement.WithContext(ctx.Context()).FindAll(query)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(result)
+}
+
+// @Summary Import Bank Statement
+// @Description Reads a CSV file with the column mapping of the bank account. Lines imported before are skipped.
+// @Tags Bank Reconciliation
+// @Accept mpfd
+// @Produce json
+// @Param account formData int true "Bank Account ID"
+// @Param file formData file true "CSV File"
+// @Success 201 {object} common.GeneralResponse{result=ImportResult}
+// @Security JWT
+// @Router /api/bank-statement/import [post]
+func (ctrl *StatementController) Import(ctx *fiber.Ctx) error {
+	var data ImportDTO
+	if err := ctrl.Validation.FormData(&data, ctx); err != nil {
+		return err
+	}
+
+	result, err := ctrl.statement.WithContext(ctx.Context()).Import(data)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(common.GeneralResponse{
+		Message: "Mutasi rekening berhasil diimpor",
+		Result:  result,
+	})
+}
+
+// @Summary Auto Match Bank Statement
+// @Description Matches unmatched lines to payments, receipts, handover deposits, expenses and transfers of the same amount within the given days
+// @Tags Bank Reconciliation
+// @Accept json
+// @Produce json
+// @Param request body AutoMatchDTO true "Request Body"
+// @Success 200 {object} common.GeneralResponse{result=MatchResult}
+// @Security JWT
+// @Router /api/bank-statement/auto-match [post]
+func (ctrl *StatementController) AutoMatch(ctx *fiber.Ctx) error {
+	var data AutoMatchDTO
+	if err := ctrl.Validation.Body(&data, ctx); err != nil {
+		return err
+	}
+
+	result, err := ctrl.statement.WithContext(ctx.Context()).AutoMatch(data)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.GeneralResponse{
+		Message: "Pencocokan otomatis selesai",
+		Result:  result,
+	})
+}
+
+// @Summary Get Match Candidates
+// @Description Unmatched documents in the same direction as the line, the closest amount first
+// @Tags Bank Reconciliation
+// @Accept json
+// @Produce json
+// @Param id path string true "Statement Line ID"
+// @Param query query CandidateQuery false "query"
+// @Success 200 {object} []Candidate
+// @Security JWT
+// @Router /api/bank-statement/{id}/candidates [get]
+func (ctrl *StatementController) Candidates(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	var query CandidateQuery
+	if err := ctrl.Validation.Query(&query, ctx); err != nil {
+		return err
+	}
+
+	result, err := ctrl.statement.WithContext(ctx.Context()).Candidates(id, query)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(result)
+}
+
+// @Summary Match Bank Statement Line
+// @Tags Bank Reconciliation
+// @Accept json
+// @Produce json
+// @Param id path string true "Statement Line ID"
+// @Param request body MatchDTO true "Request Body"
+// @Success 200 {object} common.GeneralResponse{result=StatementLine}
+// @Security JWT
+// @Router /api/bank-statement/{id}/match [patch]
+func (ctrl *StatementController) Match(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	var data MatchDTO
+	if err := ctrl.Validation.Body(&data, ctx); err != nil {
+		return err
+	}
+
+	line, err := ctrl.statement.WithContext(ctx.Context()).Match(id, data)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.GeneralResponse{
+		Message: "Baris mutasi berhasil dicocokkan",
+		Result:  line,
+	})
+}
+
+// @Summary Unmatch Bank Statement Line
+// @Tags Bank Reconciliation
+// @Accept json
+// @Produce json
+// @Param id path string true "Statement Line ID"
+// @Success 200 {object} common.GeneralResponse{result=StatementLine}
+// @Security JWT
+// @Router /api/bank-statement/{id}/unmatch [patch]
+func (ctrl *StatementController) Unmatch(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	line, err := ctrl.statement.WithContext(ctx.Context()).Unmatch(id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.GeneralResponse{
+		Message: "Pencocokan baris mutasi berhasil dibatalkan",
+		Result:  line,
+	})
+}
+
+// @Summary Delete Bank Statement Line
+// @Tags Bank Reconciliation
+// @Accept json
+// @Produce json
+// @Param id path string true "Statement Line ID"
+// @Success 200 {object} common.GeneralResponse{result=StatementLine}
+// @Security JWT
+// @Router /api/bank-statement/{id} [delete]
+func (ctrl *StatementController) Delete(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	line, err := ctrl.statement.WithContext(ctx.Context()).Delete(id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.GeneralResponse{
+		Message: "Baris mutasi berhasil dihapus",
+		Result:  line,
+	})
+}
@@ -0,0 +1,35 @@
+package bank
+
+import (
+	"abude-backend/pkg/pagination"
+	"mime/multipart"
+	"time"
+)
+
+// ImportDTO uploads a CSV statement, read with the mapping of the account.
+type ImportDTO struct {
+	Account uint                  `json:"account" form:"account" validate:"required,exist=bank_accounts"`
+	File    *multipart.FileHeader `json:"file" form:"file" validate:"required" swaggerignore:"true"`
+}
+
+type StatementQuery struct {
+	pagination.Pagination
+	Account   uint      `query:"account" validate:"required"`
+	Status    []string  `query:"status" enums:"unmatched,matched"`
+	StartDate time.Time `query:"startDate" format:"date-time"`
+	EndDate   time.Time `query:"endDate" format:"date-time"`
+}
+
+type AutoMatchDTO struct {
+	Account uint `json:"account" form:"account" validate:"required,exist=bank_accounts"`
+	Days    int  `json:"days" form:"days" validate:"omitempty,gte=0,lte=31"` // Allowed date difference, defaults to 3
+}
+
+type CandidateQuery struct {
+	Days int `query:"days" validate:"omitempty,gte=0,lte=31"` // Defaults to 7
+}
+
+type MatchDTO struct {
+	Type string `json:"type" form:"type" validate:"required,oneof=payment receipt handover expense transfer" enums:"payment,receipt,handover,expense,transfer"`
+	ID   uint   `json:"id" form:"id" validate:"required"`
+}
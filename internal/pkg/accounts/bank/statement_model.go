@@ -0,0 +1,70 @@
+package bank
+
+import (
+	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/user"
+	"time"
+)
+
+const (
+	LineUnmatched = "unmatched"
+	LineMatched   = "matched"
+)
+
+// Documents a statement line can be matched to.
+const (
+	MatchPayment  = "payment"  // Supplier payment
+	MatchReceipt  = "receipt"  // Customer receipt
+	MatchHandover = "handover" // Cash deposited after a handover
+	MatchExpense  = "expense"
+	MatchTransfer = "transfer"
+)
+
+// StatementLine is a line of an imported bank statement. Money in is
+// positive, money out negative. Hash identifies the line within its account
+// so importing the same statement twice adds nothing.
+type StatementLine struct {
+	common.BaseModel
+	user.WithEditor
+	Date        time.Time  `json:"date"`
+	Description string     `json:"description" gorm:"type:varchar(255)"`
+	Reference   string     `json:"reference" gorm:"type:varchar(100)"`
+	Amount      float64    `json:"amount"`
+	Hash        string     `json:"-" gorm:"type:varchar(40);uniqueIndex:idx_bank_statement_line"`
+	Status      string     `json:"status" gorm:"type:enum('unmatched','matched');default:unmatched" enums:"unmatched,matched"`
+	MatchType   string     `json:"matchType" gorm:"type:varchar(20);index:idx_bank_statement_match"`
+	MatchID     *uint      `json:"matchId" gorm:"index:idx_bank_statement_match"`
+	MatchedAt   *time.Time `json:"matchedAt"`
+
+	Match *Candidate `json:"match,omitempty" gorm:"-"`
+
+	BankAccount   *BankAccount `json:"-" gorm:"constraint:OnDelete:CASCADE;"`
+	BankAccountID uint         `json:"-" gorm:"uniqueIndex:idx_bank_statement_line"`
+}
+
+func (StatementLine) TableName() string {
+	return "bank_statement_lines"
+}
+
+// Candidate is a document that may explain a statement line, signed the same
+// way as the line.
+type Candidate struct {
+	Type        string    `json:"type" enums:"payment,receipt,handover,expense,transfer"`
+	ID          uint      `json:"id"`
+	Code        string    `json:"code"`
+	Description string    `json:"description"`
+	Date        time.Time `json:"date"`
+	Amount      float64   `json:"amount"`
+}
+
+type ImportResult struct {
+	Imported int             `json:"imported"`
+	Skipped  int             `json:"skipped"` // Lines imported before
+	Lines    []StatementLine `json:"lines"`
+}
+
+type MatchResult struct {
+	Matched   int             `json:"matched"`
+	Unmatched int             `json:"unmatched"`
+	Lines     []StatementLine `json:"lines"` // Lines matched in this run
+}
@@ -0,0 +1,610 @@
+package bank
+
+import (
+	"abude-backend/pkg/exception"
+	"abude-backend/pkg/pagination"
+	"context"
+	"crypto/sha1"
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Documents tried when matching, in this order.
+var matchTypes = []string{MatchTransfer, MatchHandover, MatchReceipt, MatchPayment, MatchExpense}
+
+type StatementService struct {
+	db *gorm.DB
+}
+
+func NewStatementService(db *gorm.DB) *StatementService {
+	return &StatementService{db}
+}
+
+func (s *StatementService) FindAll(query StatementQuery) (*pagination.Result[StatementLine], error) {
+	var bank BankAccount
+	if err := s.db.First(&bank, query.Account).Error; err != nil {
+		return nil, exception.DB(err, "Rekening")
+	}
+
+	result := pagination.New[StatementLine](query.Pagination)
+
+	db := s.db.Model(&StatementLine{}).Where("bank_account_id = ?", bank.ID)
+	if len(query.Status) > 0 {
+		db.Where("status IN (?)", query.Status)
+	}
+
+	if !query.StartDate.IsZero() {
+		db.Where("date >= ?", query.StartDate)
+	}
+
+	if !query.EndDate.IsZero() {
+		db.Where("date <= ?", query.EndDate)
+	}
+
+	db.Order("date DESC, id DESC")
+
+	page := result.Paginate(db)
+	if err := s.describe(&bank, page.Result); err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return page, nil
+}
+
+// Import reads a CSV statement with the mapping of the account. Lines that
+// were imported before are skipped.
+func (s *StatementService) Import(data ImportDTO) (*ImportResult, error) {
+	var bank BankAccount
+	if err := s.db.First(&bank, data.Account).Error; err != nil {
+		return nil, exception.DB(err, "Rekening")
+	}
+
+	mapping := bank.Mapping
+	if mapping.DateColumn == 0 || (mapping.AmountColumn == 0 && (mapping.DebitColumn == 0 || mapping.CreditColumn == 0)) {
+		return nil, exception.BadRequest("Format kolom mutasi rekening ini belum diatur")
+	}
+
+	file, err := data.File.Open()
+	if err != nil {
+		return nil, exception.BadRequest("File tidak dapat dibaca")
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.Comma = []rune(mapping.Delimiter)[0]
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, exception.BadRequest("File CSV tidak valid")
+	}
+
+	var lines []StatementLine
+	occurrences := map[string]int{}
+	for i, row := range rows {
+		if i < mapping.SkipRows || blank(row) {
+			continue
+		}
+
+		line, err := mapping.parse(row)
+		if err != nil {
+			return nil, exception.BadRequest(fmt.Sprintf("Baris %d: %s", i+1, err.Error()))
+		}
+
+		// Statements without an amount on a row, such as balance lines
+		if line.Amount == 0 {
+			continue
+		}
+
+		// Identical rows in a statement are told apart by their order
+		raw := strings.Join(row, "\x1f")
+		occurrences[raw]++
+
+		sum := sha1.Sum([]byte(fmt.Sprintf("%s\x1e%d", raw, occurrences[raw])))
+		line.Hash = hex.EncodeToString(sum[:])
+		line.Status = LineUnmatched
+		line.BankAccountID = bank.ID
+
+		lines = append(lines, line)
+	}
+
+	result := ImportResult{Lines: []StatementLine{}}
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		for _, line := range lines {
+			insert := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&line)
+			if insert.Error != nil {
+				return insert.Error
+			}
+
+			if insert.RowsAffected == 0 {
+				result.Skipped++
+				continue
+			}
+
+			result.Imported++
+			result.Lines = append(result.Lines, line)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &result, nil
+}
+
+// AutoMatch pairs the unmatched lines of an account with documents of the
+// same amount dated within the given days, the closest date first.
+func (s *StatementService) AutoMatch(data AutoMatchDTO) (*MatchResult, error) {
+	days := data.Days
+	if days == 0 {
+		days = 3
+	}
+
+	var bank BankAccount
+	if err := s.db.First(&bank, data.Account).Error; err != nil {
+		return nil, exception.DB(err, "Rekening")
+	}
+
+	var lines []StatementLine
+	if err := s.db.Where("bank_account_id = ? AND status = ?", bank.ID, LineUnmatched).
+		Order("date ASC, id ASC").
+		Find(&lines).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	result := MatchResult{Lines: []StatementLine{}}
+	if len(lines) == 0 {
+		return &result, nil
+	}
+
+	start := day(lines[0].Date).AddDate(0, 0, -days)
+	end := day(lines[len(lines)-1].Date).AddDate(0, 0, days+1)
+
+	candidates, err := s.candidates(&bank, start, end)
+	if err != nil {
+		return nil, exception.DB(err)
+	}
+
+	used := make([]bool, len(candidates))
+	now := time.Now()
+
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		for _, line := range lines {
+			best, distance := -1, 0
+			for i, candidate := range candidates {
+				if used[i] || round(candidate.Amount) != round(line.Amount) {
+					continue
+				}
+
+				diff := between(line.Date, candidate.Date)
+				if diff > days {
+					continue
+				}
+
+				if best == -1 || diff < distance {
+					best, distance = i, diff
+				}
+			}
+
+			if best == -1 {
+				result.Unmatched++
+				continue
+			}
+
+			used[best] = true
+			match := candidates[best]
+
+			line.Status = LineMatched
+			line.MatchType = match.Type
+			line.MatchID = &match.ID
+			line.MatchedAt = &now
+			line.Match = &match
+
+			if err := tx.Save(&line).Error; err != nil {
+				return err
+			}
+
+			result.Matched++
+			result.Lines = append(result.Lines, line)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &result, nil
+}
+
+// Candidates lists the unmatched documents in the same direction as the line
+// dated within the given days, the closest amount first.
+func (s *StatementService) Candidates(id int, query CandidateQuery) ([]Candidate, error) {
+	days := query.Days
+	if days == 0 {
+		days = 7
+	}
+
+	line, bank, err := s.line(id)
+	if err != nil {
+		return nil, err
+	}
+
+	start := day(line.Date).AddDate(0, 0, -days)
+	end := day(line.Date).AddDate(0, 0, days+1)
+
+	found, err := s.candidates(bank, start, end)
+	if err != nil {
+		return nil, exception.DB(err)
+	}
+
+	candidates := []Candidate{}
+	for _, v := range found {
+		if (v.Amount > 0) == (line.Amount > 0) {
+			candidates = append(candidates, v)
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := math.Abs(candidates[i].Amount-line.Amount), math.Abs(candidates[j].Amount-line.Amount)
+		if a != b {
+			return a < b
+		}
+
+		return between(line.Date, candidates[i].Date) < between(line.Date, candidates[j].Date)
+	})
+
+	return candidates, nil
+}
+
+// Match pairs a line with a document by hand. The amounts may differ, such as
+// when the bank deducted a fee.
+func (s *StatementService) Match(id int, data MatchDTO) (*StatementLine, error) {
+	line, bank, err := s.line(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(line, line.ID).Error; err != nil {
+			return err
+		}
+
+		if line.Status == LineMatched {
+			return exception.BadRequest("Baris mutasi sudah dicocokkan")
+		}
+
+		var found []Candidate
+		if err := s.Using(tx).source(bank, data.Type).Where("id = ?", data.ID).Limit(1).Scan(&found).Error; err != nil {
+			return err
+		}
+
+		if len(found) == 0 {
+			return exception.BadRequest("Dokumen tidak ditemukan atau sudah dicocokkan")
+		}
+
+		if (found[0].Amount > 0) != (line.Amount > 0) {
+			return exception.BadRequest("Arah dana dokumen tidak sesuai dengan baris mutasi")
+		}
+
+		now := time.Now()
+		match := found[0]
+		match.Type = data.Type
+
+		line.Status = LineMatched
+		line.MatchType = match.Type
+		line.MatchID = &match.ID
+		line.MatchedAt = &now
+		line.Match = &match
+
+		return tx.Save(line).Error
+	}); err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return line, nil
+}
+
+func (s *StatementService) Unmatch(id int) (*StatementLine, error) {
+	line, _, err := s.line(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if line.Status != LineMatched {
+		return nil, exception.BadRequest("Baris mutasi belum dicocokkan")
+	}
+
+	line.Status = LineUnmatched
+	line.MatchType = ""
+	line.MatchID = nil
+	line.MatchedAt = nil
+
+	if err := s.db.Save(line).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return line, nil
+}
+
+// Delete removes a line imported by mistake.
+func (s *StatementService) Delete(id int) (*StatementLine, error) {
+	line, _, err := s.line(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if line.Status == LineMatched {
+		return nil, exception.BadRequest("Baris mutasi yang sudah dicocokkan tidak dapat dihapus")
+	}
+
+	if err := s.db.Delete(line).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return line, nil
+}
+
+func (s *StatementService) line(id int) (*StatementLine, *BankAccount, error) {
+	var line StatementLine
+	if err := s.db.Preload("BankAccount").First(&line, id).Error; err != nil {
+		return nil, nil, exception.DB(err, "Baris mutasi")
+	}
+
+	bank := line.BankAccount
+	line.BankAccount = nil
+
+	return &line, bank, nil
+}
+
+// candidates lists the unmatched documents of every type dated in a range.
+func (s *StatementService) candidates(bank *BankAccount, start time.Time, end time.Time) ([]Candidate, error) {
+	candidates := []Candidate{}
+	for _, kind := range matchTypes {
+		var found []Candidate
+		if err := s.source(bank, kind).
+			Where("date >= ? AND date < ?", start, end).
+			Order("date ASC, id ASC").
+			Scan(&found).Error; err != nil {
+			return nil, err
+		}
+
+		for _, v := range found {
+			v.Type = kind
+			candidates = append(candidates, v)
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Date.Before(candidates[j].Date)
+	})
+
+	return candidates, nil
+}
+
+// source is the documents of a type not matched to a line yet.
+func (s *StatementService) source(bank *BankAccount, kind string) *gorm.DB {
+	matched := s.db.Model(&StatementLine{}).Select("match_id").Where("match_type = ? AND match_id IS NOT NULL", kind)
+
+	// A transfer shows on the statements of both of its accounts
+	if kind == MatchTransfer {
+		matched.Where("bank_account_id = ?", bank.ID)
+	}
+
+	return s.documents(bank, kind).Where("id NOT IN (?)", matched)
+}
+
+// documents selects the documents of a type that move money on the account,
+// signed like statement lines.
+func (s *StatementService) documents(bank *BankAccount, kind string) *gorm.DB {
+	methods := []string{"transfer", "qris"}
+	if bank.Type == TypeCash {
+		methods = []string{"cash"}
+	}
+
+	var db *gorm.DB
+	switch kind {
+	case MatchTransfer:
+		db = s.db.Table("bank_transfers").
+			Select("id, code, note AS description, date, CASE WHEN to_id = ? THEN amount ELSE -amount END AS amount", bank.ID).
+			Where("status = ? AND (from_id = ? OR to_id = ?)", StatusDone, bank.ID, bank.ID)
+	case MatchHandover:
+		db = s.db.Table("handovers").
+			Select("handovers.id, '' AS code, handovers.note AS description, handovers.date, handovers.cash_received AS amount").
+			Joins("INNER JOIN outlets ON outlets.id = handovers.outlet_id").
+			Where("outlets.company_id = ? AND handovers.cash_received > 0", bank.CompanyID)
+
+		if bank.OutletID != nil {
+			db.Where("handovers.outlet_id = ?", *bank.OutletID)
+		}
+	case MatchReceipt:
+		db = s.db.Table("customer_receipts").
+			Select("id, code, note AS description, date, amount").
+			Where("company_id = ? AND status = 'paid' AND method IN (?)", bank.CompanyID, methods)
+	case MatchPayment:
+		db = s.db.Table("supplier_payments").
+			Select("id, code, note AS description, date, -amount AS amount").
+			Where("company_id = ? AND status = 'paid' AND method IN (?)", bank.CompanyID, methods)
+	case MatchExpense:
+		db = s.db.Table("expenses").
+			Select("id, code, notes AS description, date, -amount AS amount").
+			Where("company_id = ? AND type = 'debit' AND status IN ('accepted', 'approved') AND deleted_at IS NULL", bank.CompanyID)
+	}
+
+	if bank.OutletID != nil && (kind == MatchReceipt || kind == MatchPayment || kind == MatchExpense) {
+		db.Where("outlet_id = ?", *bank.OutletID)
+	}
+
+	return s.db.Table("(?) AS documents", db)
+}
+
+// describe fills the matched document of the lines.
+func (s *StatementService) describe(bank *BankAccount, lines []StatementLine) error {
+	ids := map[string][]uint{}
+	for _, v := range lines {
+		if v.MatchID != nil {
+			ids[v.MatchType] = append(ids[v.MatchType], *v.MatchID)
+		}
+	}
+
+	for kind, list := range ids {
+		var found []Candidate
+		if err := s.documents(bank, kind).Where("id IN (?)", list).Scan(&found).Error; err != nil {
+			return err
+		}
+
+		for i := range found {
+			found[i].Type = kind
+			for j := range lines {
+				if lines[j].MatchType == kind && lines[j].MatchID != nil && *lines[j].MatchID == found[i].ID {
+					lines[j].Match = &found[i]
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// parse reads a statement line from a CSV row.
+func (m Mapping) parse(row []string) (StatementLine, error) {
+	line := StatementLine{
+		Description: truncate(field(row, m.DescriptionColumn), 255),
+		Reference:   truncate(field(row, m.ReferenceColumn), 100),
+	}
+
+	value := field(row, m.DateColumn)
+	date, err := time.ParseInLocation(m.DateFormat, value, time.Local)
+	if err != nil {
+		return line, fmt.Errorf("tanggal '%s' tidak sesuai format %s", value, m.DateFormat)
+	}
+
+	line.Date = date
+
+	if m.AmountColumn != 0 {
+		line.Amount, err = amount(field(row, m.AmountColumn), m.DecimalSeparator)
+		if err != nil {
+			return line, err
+		}
+
+		return line, nil
+	}
+
+	debit, err := amount(field(row, m.DebitColumn), m.DecimalSeparator)
+	if err != nil {
+		return line, err
+	}
+
+	credit, err := amount(field(row, m.CreditColumn), m.DecimalSeparator)
+	if err != nil {
+		return line, err
+	}
+
+	line.Amount = round(math.Abs(credit) - math.Abs(debit))
+
+	return line, nil
+}
+
+// amount parses a number written with currency symbols and thousand
+// separators. Negative amounts are written with a minus, in parentheses or
+// marked DB/DR; a CR mark is ignored.
+func amount(text string, decimal string) (float64, error) {
+	value := strings.ToUpper(strings.TrimSpace(text))
+	if value == "" {
+		return 0, nil
+	}
+
+	negative := false
+	if strings.HasPrefix(value, "(") && strings.HasSuffix(value, ")") {
+		negative = true
+	}
+
+	if strings.HasSuffix(value, "DB") || strings.HasSuffix(value, "DR") {
+		negative = true
+		value = value[:len(value)-2]
+	}
+
+	var number strings.Builder
+	for _, r := range value {
+		switch {
+		case unicode.IsDigit(r):
+			number.WriteRune(r)
+		case r == '-':
+			negative = true
+		case string(r) == decimal:
+			number.WriteRune('.')
+		}
+	}
+
+	result, err := strconv.ParseFloat(number.String(), 64)
+	if err != nil {
+		return 0, fmt.Errorf("jumlah '%s' tidak valid", strings.TrimSpace(text))
+	}
+
+	if negative {
+		result = -result
+	}
+
+	return round(result), nil
+}
+
+func field(row []string, column int) string {
+	if column < 1 || column > len(row) {
+		return ""
+	}
+
+	return strings.TrimSpace(row[column-1])
+}
+
+func blank(row []string) bool {
+	for _, v := range row {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+func truncate(value string, size int) string {
+	if len([]rune(value)) > size {
+		return string([]rune(value)[:size])
+	}
+
+	return value
+}
+
+// day drops the time of a date.
+func day(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+}
+
+// between counts the days between two dates, ignoring their order.
+func between(a time.Time, b time.Time) int {
+	diff := day(a).Sub(day(b)).Hours() / 24
+
+	return int(math.Abs(math.Round(diff)))
+}
+
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+func (s *StatementService) Using(tx *gorm.DB) *StatementService {
+	return &StatementService{tx}
+}
+
+func (s *StatementService) WithContext(ctx context.Context) *StatementService {
+	return &StatementService{s.db.WithContext(ctx)}
+}
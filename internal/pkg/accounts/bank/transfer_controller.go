@@ -0,0 +1,105 @@
+package bank
+
+import (
+	"abude-backend/internal/common"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type TransferController struct {
+	*common.BaseController
+	transfer *TransferService
+}
+
+func NewTransferController(ctrl *common.BaseController, transfer *TransferService) *TransferController {
+	return &TransferController{ctrl, transfer}
+}
+
+// @Summary Get One Bank Transfer
+// @Tags Bank Accounts
+// @Accept json
+// @Produce json
+// @Param id path string true "Transfer ID"
+// @Success 200 {object} Transfer{}
+// @Security JWT
+// @Router /api/bank-transfer/{id} [get]
+func (ctrl *TransferController) One(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	transfer, err := ctrl.transfer.WithContext(ctx.Context()).FindOne(id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(transfer)
+}
+
+// @Summary Get All Bank Transfers
+// @Tags Bank Accounts
+// @Accept json
+// @Produce json
+// @Param query query TransferQuery false "query"
+// @Success 200 {object} common.PaginatedResponse{result=[]Transfer}
+// @Security JWT
+// @Router /api/bank-transfer [get]
+func (ctrl *TransferController) All(ctx *fiber.Ctx) error {
+	var query TransferQuery
+	if err := ctrl.Validation.Query(&query, ctx); err != nil {
+		return err
+	}
+
+	result := ctrl.transfer.WithContext(ctx.Context()).FindAll(query)
+
+	return ctx.Status(fiber.StatusOK).JSON(result)
+}
+
+// @Summary Create Bank Transfer
+// @Tags Bank Accounts
+// @Accept json
+// @Produce json
+// @Param request body TransferDTO true "Request Body"
+// @Success 201 {object} common.GeneralResponse{result=Transfer}
+// @Security JWT
+// @Router /api/bank-transfer [post]
+func (ctrl *TransferController) Create(ctx *fiber.Ctx) error {
+	var data TransferDTO
+	if err := ctrl.Validation.Body(&data, ctx); err != nil {
+		return err
+	}
+
+	transfer, err := ctrl.transfer.WithContext(ctx.Context()).Create(data)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(common.GeneralResponse{
+		Message: "Transfer berhasil dibuat",
+		Result:  transfer,
+	})
+}
+
+// @Summary Cancel Bank Transfer
+// @Tags Bank Accounts
+// @Accept json
+// @Produce json
+// @Param id path string true "Transfer ID"
+// @Success 200 {object} common.BasicResponse{}
+// @Security JWT
+// @Router /api/bank-transfer/{id}/cancel [patch]
+func (ctrl *TransferController) Cancel(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := ctrl.transfer.WithContext(ctx.Context()).Cancel(id); err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.BasicResponse{
+		Message: "Transfer berhasil dibatalkan",
+	})
+}
@@ -0,0 +1,23 @@
+package bank
+
+import (
+	"abude-backend/pkg/pagination"
+	"time"
+)
+
+type TransferDTO struct {
+	Note   string    `json:"note" form:"note" validate:"omitempty,max=150"`
+	Amount float64   `json:"amount" form:"amount" validate:"required,gt=0"`
+	Date   time.Time `json:"date" form:"date" validate:"omitempty" format:"date-time"`
+	From   uint      `json:"from" form:"from" validate:"required,exist=bank_accounts"`
+	To     uint      `json:"to" form:"to" validate:"required,exist=bank_accounts,nefield=From"`
+}
+
+type TransferQuery struct {
+	pagination.Pagination
+	Company   uint      `query:"company"`
+	Account   uint      `query:"account"` // Either side of the transfer
+	Status    []string  `query:"status" enums:"done,canceled"`
+	StartDate time.Time `query:"startDate" format:"date-time"`
+	EndDate   time.Time `query:"endDate" format:"date-time"`
+}
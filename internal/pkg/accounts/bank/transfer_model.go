@@ -0,0 +1,73 @@
+package bank
+
+import (
+	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/accounts/journal"
+	"abude-backend/internal/pkg/company"
+	"abude-backend/internal/pkg/sequence"
+	"abude-backend/internal/pkg/user"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	StatusDone     = "done"
+	StatusCanceled = "canceled"
+)
+
+// Transfer moves money between two accounts of a company, such as a cash
+// drawer deposited to the bank or a QRIS settlement.
+type Transfer struct {
+	common.BaseModel
+	user.WithEditor
+	Code   string    `json:"code" gorm:"type:varchar(50)"`
+	Note   string    `json:"note" gorm:"type:varchar(150)"`
+	Amount float64   `json:"amount"`
+	Status string    `json:"status" gorm:"type:enum('done','canceled')" enums:"done,canceled"`
+	Date   time.Time `json:"date"`
+
+	From   *BankAccount `json:"from,omitempty" gorm:"constraint:OnDelete:RESTRICT;"`
+	FromID uint         `json:"-"`
+
+	To   *BankAccount `json:"to,omitempty" gorm:"constraint:OnDelete:RESTRICT;"`
+	ToID uint         `json:"-"`
+
+	Company   *company.Company `json:"company,omitempty" gorm:"constraint:OnDelete:CASCADE;"`
+	CompanyID uint             `json:"-"`
+}
+
+func (Transfer) TableName() string {
+	return "bank_transfers"
+}
+
+func (transfer *Transfer) BeforeCreate(tx *gorm.DB) error {
+	if transfer.Code != "" {
+		return nil
+	}
+
+	code, err := sequence.Next(tx, sequence.TypeTransfer, transfer.CompanyID, 0, time.Now())
+	if err != nil {
+		return err
+	}
+
+	transfer.Code = code
+
+	return nil
+}
+
+// posting moves the amount between the ledger accounts of both sides.
+func (transfer *Transfer) posting(from *BankAccount, to *BankAccount) journal.Posting {
+	return journal.Posting{
+		Company:     transfer.CompanyID,
+		User:        transfer.EditorID,
+		Date:        transfer.Date,
+		Source:      journal.SourceTransfer,
+		SourceID:    transfer.ID,
+		Description: "Transfer " + transfer.Code + " dari " + from.Name + " ke " + to.Name,
+		Lines: []journal.PostingLine{
+			{Account: to.AccountID, Debit: transfer.Amount},
+			{Account: from.AccountID, Credit: transfer.Amount},
+		},
+	}
+}
@@ -0,0 +1,160 @@
+package bank
+
+import (
+	"abude-backend/internal/pkg/accounts/journal"
+	"abude-backend/internal/pkg/accounts/period"
+	"abude-backend/pkg/exception"
+	"abude-backend/pkg/pagination"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type TransferService struct {
+	db *gorm.DB
+}
+
+func NewTransferService(db *gorm.DB) *TransferService {
+	return &TransferService{db}
+}
+
+func (s *TransferService) FindOne(id int) (*Transfer, error) {
+	var transfer Transfer
+	if err := s.db.Preload("From").Preload("To").Preload("Company").First(&transfer, id).Error; err != nil {
+		return nil, exception.DB(err, "Transfer")
+	}
+
+	return &transfer, nil
+}
+
+func (s *TransferService) FindAll(query TransferQuery) *pagination.Result[Transfer] {
+	result := pagination.New[Transfer](query.Pagination)
+
+	db := s.db.Model(&Transfer{}).Preload("From").Preload("To")
+	if query.Company != 0 {
+		db.Where("company_id = ?", query.Company)
+	}
+
+	if query.Account != 0 {
+		db.Where("from_id = ? OR to_id = ?", query.Account, query.Account)
+	}
+
+	if len(query.Status) > 0 {
+		db.Where("status IN (?)", query.Status)
+	}
+
+	if !query.StartDate.IsZero() {
+		db.Where("date >= ?", query.StartDate)
+	}
+
+	if !query.EndDate.IsZero() {
+		db.Where("date <= ?", query.EndDate)
+	}
+
+	db.Order("date DESC")
+
+	return result.Paginate(db)
+}
+
+func (s *TransferService) Create(data TransferDTO) (*Transfer, error) {
+	var from, to BankAccount
+	if err := s.db.First(&from, data.From).Error; err != nil {
+		return nil, exception.DB(err, "Rekening asal")
+	}
+
+	if err := s.db.First(&to, data.To).Error; err != nil {
+		return nil, exception.DB(err, "Rekening tujuan")
+	}
+
+	if from.CompanyID != to.CompanyID {
+		return nil, exception.Validation(map[string]string{
+			"to": "Rekening tujuan harus berada pada perusahaan yang sama",
+		})
+	}
+
+	if !from.Active || !to.Active {
+		return nil, exception.BadRequest("Rekening tidak aktif")
+	}
+
+	transfer := Transfer{
+		Note:      data.Note,
+		Amount:    data.Amount,
+		Status:    StatusDone,
+		Date:      time.Now(),
+		FromID:    from.ID,
+		ToID:      to.ID,
+		CompanyID: from.CompanyID,
+	}
+
+	if !data.Date.IsZero() {
+		transfer.Date = data.Date
+	}
+
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := period.Check(tx, transfer.CompanyID, transfer.Date); err != nil {
+			return err
+		}
+
+		if err := tx.Create(&transfer).Error; err != nil {
+			return err
+		}
+
+		return journal.Post(tx, transfer.posting(&from, &to))
+	}); err != nil {
+		return nil, exception.DB(err)
+	}
+
+	transfer.From = &from
+	transfer.To = &to
+
+	return &transfer, nil
+}
+
+// Cancel voids a transfer. A transfer matched to a bank statement line has to
+// be unmatched first.
+func (s *TransferService) Cancel(id int) error {
+	var transfer Transfer
+	if err := s.db.First(&transfer, id).Error; err != nil {
+		return exception.DB(err, "Transfer")
+	}
+
+	if transfer.Status == StatusCanceled {
+		return exception.BadRequest("Status tidak berubah")
+	}
+
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := period.Check(tx, transfer.CompanyID, transfer.Date); err != nil {
+			return err
+		}
+
+		var matched int64
+		if err := tx.Model(&StatementLine{}).
+			Where("match_type = ? AND match_id = ?", MatchTransfer, transfer.ID).
+			Count(&matched).Error; err != nil {
+			return err
+		}
+
+		if matched > 0 {
+			return exception.BadRequest("Transfer sudah direkonsiliasi, batalkan pencocokan terlebih dahulu")
+		}
+
+		if err := tx.Model(&transfer).Update("status", StatusCanceled).Error; err != nil {
+			return err
+		}
+
+		return journal.Reverse(tx, journal.SourceTransfer, transfer.ID, time.Now())
+	}); err != nil {
+		return exception.DB(err)
+	}
+
+	return nil
+}
+
+func (s *TransferService) Using(tx *gorm.DB) *TransferService {
+	return &TransferService{tx}
+}
+
+func (s *TransferService) WithContext(ctx context.Context) *TransferService {
+	return &TransferService{s.db.WithContext(ctx)}
+}
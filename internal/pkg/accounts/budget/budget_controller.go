@@ -0,0 +1,162 @@
+package budget
+
+import (
+	"abude-backend/internal/common"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type BudgetController struct {
+	*common.BaseController
+	budget *BudgetService
+}
+
+func NewController(ctrl *common.BaseController, budget *BudgetService) *BudgetController {
+	return &BudgetController{ctrl, budget}
+}
+
+// @Summary Get One Budget
+// @Tags Budgets
+// @Accept json
+// @Produce json
+// @Param id path string true "Budget ID"
+// @Success 200 {object} Budget{}
+// @Security JWT
+// @Router /api/budget/{id} [get]
+func (ctrl *BudgetController) One(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	budget, err := ctrl.budget.WithContext(ctx.Context()).FindOne(id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(budget)
+}
+
+// @Summary Get All Budgets
+// @Tags Budgets
+// @Accept json
+// @Produce json
+// @Param query query BudgetQuery false "query"
+// @Success 200 {object} common.PaginatedResponse{result=[]Budget}
+// @Security JWT
+// @Router /api/budget [get]
+func (ctrl *BudgetController) All(ctx *fiber.Ctx) error {
+	var query BudgetQuery
+	if err := ctrl.Validation.Query(&query, ctx); err != nil {
+		return err
+	}
+
+	result := ctrl.budget.WithContext(ctx.Context()).FindAll(query)
+
+	return ctx.Status(fiber.StatusOK).JSON(result)
+}
+
+// @Summary Create Budget
+// @Description Without an outlet the budget applies to the whole company
+// @Tags Budgets
+// @Accept json
+// @Produce json
+// @Param request body BudgetDTO true "Request Body"
+// @Success 201 {object} common.GeneralResponse{result=Budget}
+// @Security JWT
+// @Router /api/budget [post]
+func (ctrl *BudgetController) Create(ctx *fiber.Ctx) error {
+	var data BudgetDTO
+	if err := ctrl.Validation.Body(&data, ctx); err != nil {
+		return err
+	}
+
+	budget, err := ctrl.budget.WithContext(ctx.Context()).Create(data)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(common.GeneralResponse{
+		Message: "Anggaran berhasil dibuat",
+		Result:  budget,
+	})
+}
+
+// @Summary Update Budget
+// @Tags Budgets
+// @Accept json
+// @Produce json
+// @Param id path string true "Budget ID"
+// @Param request body BudgetDTO true "Request Body"
+// @Success 200 {object} common.GeneralResponse{result=Budget}
+// @Security JWT
+// @Router /api/budget/{id} [put]
+func (ctrl *BudgetController) Update(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	var data BudgetDTO
+	if err := ctrl.Validation.Body(&data, ctx); err != nil {
+		return err
+	}
+
+	budget, err := ctrl.budget.WithContext(ctx.Context()).Update(id, data)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.GeneralResponse{
+		Message: "Anggaran berhasil diubah",
+		Result:  budget,
+	})
+}
+
+// @Summary Delete Budget
+// @Tags Budgets
+// @Accept json
+// @Produce json
+// @Param id path string true "Budget ID"
+// @Success 200 {object} common.GeneralResponse{result=Budget}
+// @Security JWT
+// @Router /api/budget/{id} [delete]
+func (ctrl *BudgetController) Delete(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	budget, err := ctrl.budget.WithContext(ctx.Context()).Delete(id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.GeneralResponse{
+		Message: "Anggaran berhasil dihapus",
+		Result:  budget,
+	})
+}
+
+// @Summary Get Budget Variance
+// @Description Budgets compared with the expenses, purchases and wages posted to their accounts
+// @Tags Budgets
+// @Accept json
+// @Produce json
+// @Param query query VarianceQuery true "query"
+// @Success 200 {object} Variance
+// @Security JWT
+// @Router /api/budget/variance [get]
+func (ctrl *BudgetController) Variance(ctx *fiber.Ctx) error {
+	var query VarianceQuery
+	if err := ctrl.Validation.Query(&query, ctx); err != nil {
+		return err
+	}
+
+	report, err := ctrl.budget.WithContext(ctx.Context()).Variance(query)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(report)
+}
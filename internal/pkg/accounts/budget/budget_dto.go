@@ -0,0 +1,28 @@
+package budget
+
+import "abude-backend/pkg/pagination"
+
+type BudgetDTO struct {
+	Month   string  `json:"month" form:"month" validate:"required,datetime=2006-01" example:"2024-01"`
+	Amount  float64 `json:"amount" form:"amount" validate:"gte=0"`
+	Note    string  `json:"note" form:"note" validate:"omitempty,max=150"`
+	Account uint    `json:"account" form:"account" validate:"required,exist=accounts"`
+	Company uint    `json:"company" form:"company" validate:"required,exist=companies"`
+	Outlet  *uint   `json:"outlet" form:"outlet" validate:"omitempty,exist=outlets"` // Empty for the whole company
+}
+
+type BudgetQuery struct {
+	pagination.Pagination
+	Company uint   `query:"company"`
+	Outlet  uint   `query:"outlet"`
+	Account uint   `query:"account"`
+	Month   string `query:"month" validate:"omitempty,datetime=2006-01" example:"2024-01"`
+}
+
+type VarianceQuery struct {
+	Company    uint   `query:"company" validate:"required,exist=companies"`
+	Outlet     uint   `query:"outlet" validate:"omitempty,exist=outlets"`
+	Account    uint   `query:"account"`
+	StartMonth string `query:"startMonth" validate:"required,datetime=2006-01" example:"2024-01"`
+	EndMonth   string `query:"endMonth" validate:"omitempty,datetime=2006-01" example:"2024-03"` // Defaults to the start month
+}
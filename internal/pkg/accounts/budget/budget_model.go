@@ -0,0 +1,57 @@
+package budget
+
+import (
+	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/accounts/account"
+	"abude-backend/internal/pkg/company"
+	"abude-backend/internal/pkg/outlet"
+	"abude-backend/internal/pkg/user"
+)
+
+// Layout of the month of a budget.
+const monthFormat = "2006-01"
+
+// Budget is the spending limit of an account in a month, for one outlet or
+// for the whole company when no outlet is set.
+type Budget struct {
+	common.BaseModel
+	user.WithEditor
+	Month  string  `json:"month" gorm:"type:varchar(7);index:idx_budget"`
+	Amount float64 `json:"amount"`
+	Note   string  `json:"note" gorm:"type:varchar(150)"`
+
+	Account   *account.Account `json:"account,omitempty" gorm:"constraint:OnDelete:CASCADE;"`
+	AccountID uint             `json:"-" gorm:"index:idx_budget"`
+
+	Company   *company.Company `json:"company,omitempty" gorm:"constraint:OnDelete:CASCADE;"`
+	CompanyID uint             `json:"-" gorm:"index:idx_budget"`
+
+	Outlet   *outlet.Outlet `json:"outlet,omitempty" gorm:"constraint:OnDelete:CASCADE;"`
+	OutletID *uint          `json:"-"`
+}
+
+func (Budget) TableName() string {
+	return "budgets"
+}
+
+// VarianceLine compares a budget with what was actually spent on its account.
+// A positive variance is the amount left, a negative one the overspending.
+type VarianceLine struct {
+	Month    string          `json:"month"`
+	Account  account.Account `json:"account"`
+	Outlet   *outlet.Outlet  `json:"outlet,omitempty"`
+	Budget   float64         `json:"budget"`
+	Actual   float64         `json:"actual"`
+	Variance float64         `json:"variance"`
+	Percent  float64         `json:"percent"` // Actual as a percentage of the budget
+	Over     bool            `json:"over"`
+}
+
+type Variance struct {
+	StartMonth string         `json:"startMonth"`
+	EndMonth   string         `json:"endMonth"`
+	Lines      []VarianceLine `json:"lines"`
+	Budget     float64        `json:"budget"`
+	Actual     float64        `json:"actual"`
+	Variance   float64        `json:"variance"`
+}
@@ -0,0 +1,335 @@
+package budget
+
+import (
+	"abude-backend/internal/pkg/accounts/account"
+	"abude-backend/internal/pkg/accounts/journal"
+	"abude-backend/internal/pkg/outlet"
+	"abude-backend/pkg/exception"
+	"abude-backend/pkg/pagination"
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Documents whose postings count as spending against a budget.
+var spending = []string{journal.SourceExpense, journal.SourcePurchase, journal.SourceWage}
+
+type BudgetService struct {
+	db *gorm.DB
+}
+
+func NewService(db *gorm.DB) *BudgetService {
+	return &BudgetService{db}
+}
+
+func (s *BudgetService) FindOne(id int) (*Budget, error) {
+	var budget Budget
+	if err := s.db.Preload("Account").Preload("Company").Preload("Outlet").First(&budget, id).Error; err != nil {
+		return nil, exception.DB(err, "Anggaran")
+	}
+
+	return &budget, nil
+}
+
+func (s *BudgetService) FindAll(query BudgetQuery) *pagination.Result[Budget] {
+	result := pagination.New[Budget](query.Pagination)
+
+	db := s.db.Model(&Budget{}).Preload("Account").Preload("Outlet")
+	if query.Company != 0 {
+		db.Where("company_id = ?", query.Company)
+	}
+
+	if query.Outlet != 0 {
+		db.Where("outlet_id = ?", query.Outlet)
+	}
+
+	if query.Account != 0 {
+		db.Where("account_id = ?", query.Account)
+	}
+
+	if query.Month != "" {
+		db.Where("month = ?", query.Month)
+	}
+
+	db.Order("month DESC, account_id ASC")
+
+	return result.Paginate(db)
+}
+
+func (s *BudgetService) Create(data BudgetDTO) (*Budget, error) {
+	budget := Budget{}
+	fill(&budget, data)
+
+	if err := s.check(&budget); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Create(&budget).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &budget, nil
+}
+
+func (s *BudgetService) Update(id int, data BudgetDTO) (*Budget, error) {
+	var budget Budget
+	if err := s.db.First(&budget, id).Error; err != nil {
+		return nil, exception.DB(err, "Anggaran")
+	}
+
+	fill(&budget, data)
+
+	if err := s.check(&budget); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Save(&budget).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &budget, nil
+}
+
+func (s *BudgetService) Delete(id int) (*Budget, error) {
+	var budget Budget
+	if err := s.db.First(&budget, id).Error; err != nil {
+		return nil, exception.DB(err, "Anggaran")
+	}
+
+	if err := s.db.Delete(&budget).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &budget, nil
+}
+
+// Variance compares the budgets of a range of months with the expenses,
+// purchases and wages posted to their accounts. Company wide budgets are
+// compared with the spending of every outlet.
+func (s *BudgetService) Variance(query VarianceQuery) (*Variance, error) {
+	end := query.EndMonth
+	if end == "" {
+		end = query.StartMonth
+	}
+
+	if end < query.StartMonth {
+		return nil, exception.Validation(map[string]string{
+			"endMonth": "Bulan akhir harus setelah bulan awal",
+		})
+	}
+
+	report := Variance{
+		StartMonth: query.StartMonth,
+		EndMonth:   end,
+		Lines:      []VarianceLine{},
+	}
+
+	var budgets []Budget
+	db := s.db.Preload("Account").Preload("Outlet").
+		Where("company_id = ? AND month BETWEEN ? AND ?", query.Company, query.StartMonth, end).
+		Order("month ASC, account_id ASC, outlet_id ASC")
+
+	if query.Outlet != 0 {
+		db.Where("outlet_id = ?", query.Outlet)
+	}
+
+	if query.Account != 0 {
+		db.Where("account_id = ?", query.Account)
+	}
+
+	if err := db.Find(&budgets).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	if len(budgets) == 0 {
+		return &report, nil
+	}
+
+	accounts := make([]uint, len(budgets))
+	for i, v := range budgets {
+		accounts[i] = v.AccountID
+	}
+
+	rows, err := actuals(s.db, query.Company, accounts, query.StartMonth, end)
+	if err != nil {
+		return nil, exception.DB(err)
+	}
+
+	for _, v := range budgets {
+		line := VarianceLine{
+			Month:   v.Month,
+			Account: *v.Account,
+			Outlet:  v.Outlet,
+			Budget:  v.Amount,
+			Actual:  spent(rows, &v),
+		}
+
+		line.Variance = round(line.Budget - line.Actual)
+		line.Over = line.Variance < 0
+		if line.Budget != 0 {
+			line.Percent = round(line.Actual / line.Budget * 100)
+		}
+
+		report.Lines = append(report.Lines, line)
+		report.Budget = round(report.Budget + line.Budget)
+		report.Actual = round(report.Actual + line.Actual)
+	}
+
+	report.Variance = round(report.Budget - report.Actual)
+
+	return &report, nil
+}
+
+// Warnings lists the budgets of an account that spending the amount on the
+// date would exceed, both of the outlet and of the whole company.
+func Warnings(tx *gorm.DB, company uint, outletId uint, accountId uint, date time.Time, amount float64) ([]string, error) {
+	month := date.Format(monthFormat)
+
+	var budgets []Budget
+	if err := tx.Preload("Account").
+		Where("company_id = ? AND account_id = ? AND month = ? AND (outlet_id IS NULL OR outlet_id = ?)", company, accountId, month, outletId).
+		Order("outlet_id IS NULL").
+		Find(&budgets).Error; err != nil {
+		return nil, err
+	}
+
+	if len(budgets) == 0 {
+		return nil, nil
+	}
+
+	rows, err := actuals(tx, company, []uint{accountId}, month, month)
+	if err != nil {
+		return nil, err
+	}
+
+	var warnings []string
+	for _, v := range budgets {
+		total := round(spent(rows, &v) + amount)
+		if total <= v.Amount {
+			continue
+		}
+
+		scope := "perusahaan"
+		if v.OutletID != nil {
+			scope = "outlet"
+		}
+
+		warnings = append(warnings, fmt.Sprintf("Anggaran %s %s bulan %s terlampaui, realisasi %.2f dari anggaran %.2f", v.Account.Name, scope, month, total, v.Amount))
+	}
+
+	return warnings, nil
+}
+
+type actual struct {
+	Month     string
+	AccountID uint
+	OutletID  *uint
+	Amount    float64
+}
+
+// actuals sums the spending posted to the accounts per month and outlet.
+func actuals(tx *gorm.DB, company uint, accounts []uint, start string, end string) ([]actual, error) {
+	var rows []actual
+	err := tx.Table("journal_lines").
+		Select("DATE_FORMAT(journal_entries.date, '%Y-%m') AS month, journal_lines.account_id, journal_entries.outlet_id, "+
+			"SUM(journal_lines.debit - journal_lines.credit) AS amount").
+		Joins("INNER JOIN journal_entries ON journal_entries.id = journal_lines.entry_id").
+		Where("journal_entries.company_id = ? AND journal_entries.source IN (?) AND journal_lines.account_id IN (?)", company, spending, accounts).
+		Where("DATE_FORMAT(journal_entries.date, '%Y-%m') BETWEEN ? AND ?", start, end).
+		Group("month, journal_lines.account_id, journal_entries.outlet_id").
+		Scan(&rows).Error
+
+	return rows, err
+}
+
+// spent is the spending that counts against a budget.
+func spent(rows []actual, budget *Budget) float64 {
+	var total float64
+	for _, v := range rows {
+		if v.Month != budget.Month || v.AccountID != budget.AccountID {
+			continue
+		}
+
+		if budget.OutletID != nil && (v.OutletID == nil || *v.OutletID != *budget.OutletID) {
+			continue
+		}
+
+		total += v.Amount
+	}
+
+	return round(total)
+}
+
+func fill(budget *Budget, data BudgetDTO) {
+	budget.Month = data.Month
+	budget.Amount = data.Amount
+	budget.Note = data.Note
+	budget.AccountID = data.Account
+	budget.CompanyID = data.Company
+	budget.OutletID = data.Outlet
+}
+
+// check makes sure the account and outlet belong to the company and the
+// account has no other budget for the same outlet and month.
+func (s *BudgetService) check(budget *Budget) error {
+	var acc account.Account
+	if err := s.db.First(&acc, budget.AccountID).Error; err != nil {
+		return exception.DB(err, "Akun")
+	}
+
+	if acc.CompanyID != nil && *acc.CompanyID != budget.CompanyID {
+		return exception.Validation(map[string]string{
+			"account": "Akun bukan milik perusahaan ini",
+		})
+	}
+
+	if budget.OutletID != nil {
+		var record outlet.Outlet
+		if err := s.db.First(&record, *budget.OutletID).Error; err != nil {
+			return exception.DB(err, "Outlet")
+		}
+
+		if record.CompanyID != budget.CompanyID {
+			return exception.Validation(map[string]string{
+				"outlet": "Outlet bukan milik perusahaan ini",
+			})
+		}
+	}
+
+	db := s.db.Model(&Budget{}).
+		Where("id != ? AND company_id = ? AND account_id = ? AND month = ?", budget.ID, budget.CompanyID, budget.AccountID, budget.Month)
+
+	if budget.OutletID != nil {
+		db.Where("outlet_id = ?", *budget.OutletID)
+	} else {
+		db.Where("outlet_id IS NULL")
+	}
+
+	var count int64
+	if err := db.Count(&count).Error; err != nil {
+		return exception.DB(err)
+	}
+
+	if count > 0 {
+		return exception.Validation(map[string]string{
+			"month": "Anggaran akun ini untuk bulan tersebut sudah ada",
+		})
+	}
+
+	return nil
+}
+
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+func (s *BudgetService) Using(tx *gorm.DB) *BudgetService {
+	return &BudgetService{tx}
+}
+
+func (s *BudgetService) WithContext(ctx context.Context) *BudgetService {
+	return &BudgetService{s.db.WithContext(ctx)}
+}
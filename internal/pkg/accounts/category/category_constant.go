@@ -0,0 +1,11 @@
+package category
+
+// Codes of the categories of the default chart. Account codes start with the
+// code of their category.
+const (
+	CodeAsset     = "1"
+	CodeLiability = "2"
+	CodeRevenue   = "3"
+	CodeExpense   = "4"
+	CodeEquity    = "5"
+)
@@ -23,11 +23,20 @@ func (Category) TableName() string {
 	return "account_categories"
 }
 
+// BeforeSave keeps codes unique within a company, and within the default
+// chart for categories without a company.
 func (category *Category) BeforeSave(tx *gorm.DB) error {
+	db := tx.Model(&Category{}).
+		Where("code = ? AND id != ?", category.Code, category.ID)
+
+	if category.CompanyID == nil {
+		db.Where("company_id IS NULL")
+	} else {
+		db.Where("company_id = ?", *category.CompanyID)
+	}
+
 	var count int64
-	if err := tx.Model(&Category{}).
-		Where("code = ? AND company_id = ? AND id != ?", category.Code, category.CompanyID, category.ID).
-		Count(&count).Error; err != nil {
+	if err := db.Count(&count).Error; err != nil {
 		return err
 	}
 
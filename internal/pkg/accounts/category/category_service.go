@@ -66,6 +66,10 @@ func (s *CategoryService) Update(id int, data CategoryDTO) (*Category, error) {
 		return nil, exception.DB(err)
 	}
 
+	if category.CompanyID == nil {
+		return nil, exception.BadRequest("Kategori bawaan tidak dapat diubah, salin bagan akun ke perusahaan terlebih dahulu")
+	}
+
 	category.Name = data.Name
 	category.Description = data.Description
 	category.Code = data.Code
@@ -85,6 +89,10 @@ func (s *CategoryService) Delete(id int) (*Category, error) {
 		return nil, exception.DB(err)
 	}
 
+	if category.CompanyID == nil {
+		return nil, exception.BadRequest("Kategori bawaan tidak dapat dihapus")
+	}
+
 	if err := s.db.Delete(&category).Error; err != nil {
 		return nil, exception.DB(err)
 	}
@@ -0,0 +1,156 @@
+package journal
+
+import (
+	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/auth"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type JournalController struct {
+	*common.BaseController
+	journal *JournalService
+}
+
+func NewController(ctrl *common.BaseController, journal *JournalService) *JournalController {
+	return &JournalController{ctrl, journal}
+}
+
+// @Summary Get One Journal Entry
+// @Tags Journals
+// @Accept json
+// @Produce json
+// @Param id path string true "Journal Entry ID"
+// @Success 200 {object} Entry{}
+// @Security JWT
+// @Router /api/journal/{id} [get]
+func (ctrl *JournalController) One(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	entry, err := ctrl.journal.WithContext(ctx.Context()).FindOne(id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(entry)
+}
+
+// @Summary Get All Journal Entries
+// @Tags Journals
+// @Accept json
+// @Produce json
+// @Param query query EntryQuery false "query"
+// @Success 200 {object} common.PaginatedResponse{result=[]Entry}
+// @Security JWT
+// @Router /api/journal [get]
+func (ctrl *JournalController) All(ctx *fiber.Ctx) error {
+	var query EntryQuery
+	if err := ctrl.Validation.Query(&query, ctx); err != nil {
+		return err
+	}
+
+	result := ctrl.journal.WithContext(ctx.Context()).FindAll(query)
+
+	return ctx.Status(fiber.StatusOK).JSON(result)
+}
+
+// @Summary Create Manual Journal Entry
+// @Tags Journals
+// @Accept json
+// @Produce json
+// @Param request body EntryDTO true "Request Body"
+// @Success 201 {object} common.GeneralResponse{result=Entry}
+// @Security JWT
+// @Router /api/journal [post]
+func (ctrl *JournalController) Create(ctx *fiber.Ctx) error {
+	var data EntryDTO
+	if err := ctrl.Validation.Body(&data, ctx); err != nil {
+		return err
+	}
+
+	creds := auth.GetCreds(ctx.Context())
+	data.User = creds.ID
+
+	entry, err := ctrl.journal.WithContext(ctx.Context()).Create(data)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(common.GeneralResponse{
+		Message: "Jurnal berhasil dibuat",
+		Result:  entry,
+	})
+}
+
+// @Summary Reverse Manual Journal Entry
+// @Tags Journals
+// @Accept json
+// @Produce json
+// @Param id path string true "Journal Entry ID"
+// @Success 200 {object} common.BasicResponse{}
+// @Security JWT
+// @Router /api/journal/{id}/reverse [patch]
+func (ctrl *JournalController) Reverse(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := ctrl.journal.WithContext(ctx.Context()).Reverse(id); err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.BasicResponse{
+		Message: "Jurnal berhasil dibalik",
+	})
+}
+
+// @Summary Get Journal Account Mappings
+// @Tags Journals
+// @Accept json
+// @Produce json
+// @Param query query MappingQuery true "query"
+// @Success 200 {object} []Setting
+// @Security JWT
+// @Router /api/journal/mapping [get]
+func (ctrl *JournalController) Mappings(ctx *fiber.Ctx) error {
+	var query MappingQuery
+	if err := ctrl.Validation.Query(&query, ctx); err != nil {
+		return err
+	}
+
+	result, err := ctrl.journal.WithContext(ctx.Context()).FindMappings(query)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(result)
+}
+
+// @Summary Save Journal Account Mappings
+// @Tags Journals
+// @Accept json
+// @Produce json
+// @Param request body MappingDTO true "Request Body"
+// @Success 200 {object} common.GeneralResponse{result=[]Setting}
+// @Security JWT
+// @Router /api/journal/mapping [put]
+func (ctrl *JournalController) SaveMappings(ctx *fiber.Ctx) error {
+	var data MappingDTO
+	if err := ctrl.Validation.Body(&data, ctx); err != nil {
+		return err
+	}
+
+	result, err := ctrl.journal.WithContext(ctx.Context()).SaveMappings(data)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.GeneralResponse{
+		Message: "Pemetaan akun berhasil disimpan",
+		Result:  result,
+	})
+}
@@ -0,0 +1,50 @@
+package journal
+
+import (
+	"abude-backend/pkg/pagination"
+	"time"
+)
+
+type LineDTO struct {
+	Account uint    `json:"account" validate:"required,exist=accounts"`
+	Debit   float64 `json:"debit" validate:"min=0"`
+	Credit  float64 `json:"credit" validate:"min=0"`
+	Memo    string  `json:"memo" validate:"omitempty,max=255"`
+}
+
+// EntryDTO is a manual journal entry. Its lines must balance.
+type EntryDTO struct {
+	Date        time.Time `json:"date" validate:"omitempty"`
+	Description string    `json:"description" validate:"required,max=255"`
+	Company     uint      `json:"company" validate:"required,exist=companies"`
+	Outlet      *uint     `json:"outlet" validate:"omitempty,exist=outlets"`
+	Lines       []LineDTO `json:"lines" validate:"required,min=2,dive"`
+
+	User uint `json:"-"`
+}
+
+type EntryQuery struct {
+	pagination.Pagination
+	Company   int      `query:"company"`
+	Outlet    int      `query:"outlet"`
+	Account   int      `query:"account"`
+	Source    []string `query:"source"`
+	StartDate string   `query:"startDate" format:"date"`
+	EndDate   string   `query:"endDate" format:"date"`
+}
+
+type MappingDTO struct {
+	Company  uint            `json:"company" validate:"required,exist=companies"`
+	Accounts map[string]uint `json:"accounts" validate:"required"` // Account ID per key, zero removes the mapping
+}
+
+type MappingQuery struct {
+	Company uint `query:"company" validate:"required"`
+}
+
+// Setting is the account a key posts to for a company.
+type Setting struct {
+	Key     string `json:"key"`
+	Mapped  bool   `json:"mapped"` // False when the default chart is used
+	Account *uint  `json:"account"`
+}
@@ -0,0 +1,114 @@
+package journal
+
+import (
+	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/accounts/account"
+	"abude-backend/internal/pkg/company"
+	"abude-backend/internal/pkg/outlet"
+	"abude-backend/internal/pkg/user"
+	"time"
+)
+
+// Documents that post journal entries.
+const (
+	SourceManual    = "manual"
+	SourceSale      = "sale"
+	SourceReturn    = "return" // Refund of a sale return
+	SourcePurchase  = "purchase"
+	SourceExpense   = "expense"
+	SourceWage      = "wage"
+	SourceAdvance   = "advance"
+	SourceRepayment = "repayment" // Cash or transfer repayment of an advance
+	SourceRecap     = "recap"
+	SourceReceipt   = "receipt"
+	SourcePayment   = "payment"
+	SourceTransfer  = "transfer" // Money moved between cash and bank accounts
+)
+
+// Mapping keys naming the accounts that automatic postings use.
+const (
+	KeyCash            = "cash"
+	KeyReceivable      = "receivable"
+	KeyInventory       = "inventory"
+	KeyEmployeeAdvance = "employee_advance"
+	KeyPayable         = "payable"
+	KeyTax             = "tax"
+	KeyRevenue         = "revenue"
+	KeyCOGS            = "cogs"
+	KeyWage            = "wage"
+)
+
+// Keys without a company mapping fall back to these accounts of the default
+// chart.
+var defaults = map[string]string{
+	KeyCash:            "100001",
+	KeyReceivable:      "100002",
+	KeyInventory:       "100003",
+	KeyEmployeeAdvance: "100006",
+	KeyPayable:         "200002",
+	KeyTax:             "200004",
+	KeyRevenue:         "300001",
+	KeyCOGS:            "400002",
+	KeyWage:            account.CodeExpenseWage,
+}
+
+// Entry is a balanced journal entry. Entries are never edited; a posting is
+// undone by a reversal entry of the same source with the lines swapped.
+type Entry struct {
+	common.BaseModel
+	Date        time.Time `json:"date"`
+	Description string    `json:"description" gorm:"type:varchar(255)"`
+	Source      string    `json:"source" gorm:"type:varchar(20);index:idx_journal_source"`
+	SourceID    *uint     `json:"sourceId" gorm:"index:idx_journal_source"`
+	Reversal    bool      `json:"reversal"`
+	Debit       float64   `json:"debit"`
+	Credit      float64   `json:"credit"`
+
+	Lines []Line `json:"lines,omitempty" gorm:"constraint:OnDelete:CASCADE;"`
+
+	Company   *company.Company `json:"company,omitempty" gorm:"constraint:OnDelete:CASCADE;"`
+	CompanyID uint             `json:"-"`
+
+	Outlet   *outlet.Outlet `json:"outlet,omitempty" gorm:"constraint:OnDelete:SET NULL;"`
+	OutletID *uint          `json:"-"`
+
+	User   *user.User `json:"user,omitempty" gorm:"constraint:OnDelete:SET NULL;"`
+	UserID *uint      `json:"-"`
+}
+
+func (Entry) TableName() string {
+	return "journal_entries"
+}
+
+type Line struct {
+	common.BaseModel
+	Debit  float64 `json:"debit"`
+	Credit float64 `json:"credit"`
+	Memo   string  `json:"memo" gorm:"type:varchar(255)"`
+
+	Account   *account.Account `json:"account,omitempty" gorm:"constraint:OnDelete:RESTRICT;"`
+	AccountID uint             `json:"-"`
+
+	Entry   *Entry `json:"-" gorm:"constraint:OnDelete:CASCADE;"`
+	EntryID uint   `json:"-"`
+}
+
+func (Line) TableName() string {
+	return "journal_lines"
+}
+
+// Mapping points a posting key to an account of a company.
+type Mapping struct {
+	common.BaseModel
+	Key string `json:"key" gorm:"type:varchar(30);uniqueIndex:idx_journal_mapping"`
+
+	Account   *account.Account `json:"account,omitempty" gorm:"constraint:OnDelete:CASCADE;"`
+	AccountID uint             `json:"-"`
+
+	Company   *company.Company `json:"-" gorm:"constraint:OnDelete:CASCADE;"`
+	CompanyID uint             `json:"-" gorm:"uniqueIndex:idx_journal_mapping"`
+}
+
+func (Mapping) TableName() string {
+	return "journal_mappings"
+}
@@ -0,0 +1,243 @@
+package journal
+
+import (
+	"abude-backend/internal/pkg/accounts/account"
+	"abude-backend/pkg/exception"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Posting is a journal entry to be posted from a document. Its lines name
+// their account either by mapping key or by account ID.
+type Posting struct {
+	Company     uint
+	Outlet      *uint
+	User        *uint
+	Date        time.Time
+	Source      string
+	SourceID    uint
+	Description string
+	Lines       []PostingLine
+}
+
+type PostingLine struct {
+	Key     string
+	Account uint
+	Debit   float64
+	Credit  float64
+	Memo    string
+}
+
+// Post records a posting as a journal entry. A document that already has an
+// entry in effect is left as is, so posting twice does nothing. Lines without
+// an amount are dropped and a posting without lines records nothing.
+func Post(tx *gorm.DB, posting Posting) error {
+	_, err := post(tx, posting)
+
+	return err
+}
+
+func post(tx *gorm.DB, posting Posting) (*Entry, error) {
+	if posting.Source != SourceManual {
+		current, err := active(tx, posting.Source, posting.SourceID)
+		if err != nil {
+			return nil, err
+		}
+
+		if current != nil {
+			return current, nil
+		}
+	}
+
+	entry := Entry{
+		Date:        posting.Date,
+		Description: posting.Description,
+		Source:      posting.Source,
+		CompanyID:   posting.Company,
+		OutletID:    posting.Outlet,
+	}
+
+	if posting.User != nil && *posting.User != 0 {
+		entry.UserID = posting.User
+	}
+
+	if posting.SourceID != 0 {
+		entry.SourceID = &posting.SourceID
+	}
+
+	for _, v := range posting.Lines {
+		debit, credit := round(v.Debit), round(v.Credit)
+		if debit == 0 && credit == 0 {
+			continue
+		}
+
+		// Negative amounts belong on the other side
+		if debit < 0 || credit < 0 {
+			debit, credit = math.Max(debit, 0)-math.Min(credit, 0), math.Max(credit, 0)-math.Min(debit, 0)
+		}
+
+		accountId := v.Account
+		if accountId == 0 {
+			id, err := Resolve(tx, posting.Company, v.Key)
+			if err != nil {
+				return nil, err
+			}
+
+			accountId = id
+		}
+
+		entry.Debit += debit
+		entry.Credit += credit
+		entry.Lines = append(entry.Lines, Line{
+			Debit:     debit,
+			Credit:    credit,
+			Memo:      v.Memo,
+			AccountID: accountId,
+		})
+	}
+
+	if len(entry.Lines) == 0 {
+		return nil, nil
+	}
+
+	if round(entry.Debit) != round(entry.Credit) {
+		return nil, exception.BadRequest(fmt.Sprintf("Jurnal tidak seimbang, debit %.2f dan kredit %.2f", entry.Debit, entry.Credit))
+	}
+
+	if err := tx.Create(&entry).Error; err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+// Reverse undoes the entry in effect of a document by posting its lines
+// swapped. Documents without an entry in effect are ignored.
+func Reverse(tx *gorm.DB, source string, id uint, date time.Time) error {
+	current, err := active(tx, source, id)
+	if err != nil || current == nil {
+		return err
+	}
+
+	return reverse(tx, current, date)
+}
+
+// Redate moves the entry in effect of a document to another date by reversing
+// it and posting its lines again on that date, as when the document is
+// edited. Documents without an entry in effect are ignored.
+func Redate(tx *gorm.DB, source string, id uint, date time.Time) error {
+	current, err := active(tx, source, id)
+	if err != nil || current == nil || current.Date.Equal(date) {
+		return err
+	}
+
+	if err := reverse(tx, current, time.Now()); err != nil {
+		return err
+	}
+
+	entry := Entry{
+		Date:        date,
+		Description: current.Description,
+		Source:      current.Source,
+		SourceID:    current.SourceID,
+		Debit:       current.Debit,
+		Credit:      current.Credit,
+		CompanyID:   current.CompanyID,
+		OutletID:    current.OutletID,
+		UserID:      current.UserID,
+	}
+
+	for _, v := range current.Lines {
+		entry.Lines = append(entry.Lines, Line{
+			Debit:     v.Debit,
+			Credit:    v.Credit,
+			Memo:      v.Memo,
+			AccountID: v.AccountID,
+		})
+	}
+
+	return tx.Create(&entry).Error
+}
+
+func reverse(tx *gorm.DB, entry *Entry, date time.Time) error {
+	reversal := Entry{
+		Date:        date,
+		Description: "Pembalikan " + entry.Description,
+		Source:      entry.Source,
+		SourceID:    entry.SourceID,
+		Reversal:    true,
+		Debit:       entry.Credit,
+		Credit:      entry.Debit,
+		CompanyID:   entry.CompanyID,
+		OutletID:    entry.OutletID,
+	}
+
+	if entry.Source == SourceManual {
+		reversal.SourceID = &entry.ID
+	}
+
+	for _, v := range entry.Lines {
+		reversal.Lines = append(reversal.Lines, Line{
+			Debit:     v.Credit,
+			Credit:    v.Debit,
+			Memo:      v.Memo,
+			AccountID: v.AccountID,
+		})
+	}
+
+	return tx.Create(&reversal).Error
+}
+
+// active returns the entry of a document that has not been reversed.
+func active(tx *gorm.DB, source string, id uint) (*Entry, error) {
+	var entries []Entry
+	if err := tx.Preload("Lines").Where("source = ? AND source_id = ?", source, id).Order("id").Find(&entries).Error; err != nil {
+		return nil, err
+	}
+
+	var current *Entry
+	for i := range entries {
+		if entries[i].Reversal {
+			current = nil
+		} else {
+			current = &entries[i]
+		}
+	}
+
+	return current, nil
+}
+
+// Resolve finds the account of a key, from the company mapping or else from
+// the default chart, preferring accounts of the company itself.
+func Resolve(tx *gorm.DB, company uint, key string) (uint, error) {
+	var mapping Mapping
+	err := tx.Where(&Mapping{CompanyID: company, Key: key}).First(&mapping).Error
+	if err == nil {
+		return mapping.AccountID, nil
+	}
+
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, err
+	}
+
+	var acc account.Account
+	if err := tx.Where("code = ? AND (company_id = ? OR company_id IS NULL)", defaults[key], company).
+		Order("company_id IS NULL").
+		First(&acc).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, exception.BadRequest(fmt.Sprintf("Akun untuk pemetaan '%s' belum diatur", key))
+		}
+
+		return 0, err
+	}
+
+	return acc.ID, nil
+}
+
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
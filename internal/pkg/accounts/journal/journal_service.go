@@ -0,0 +1,209 @@
+package journal
+
+import (
+	"abude-backend/internal/pkg/accounts/period"
+	"abude-backend/pkg/exception"
+	"abude-backend/pkg/pagination"
+	"context"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type JournalService struct {
+	db *gorm.DB
+}
+
+func NewService(db *gorm.DB) *JournalService {
+	return &JournalService{db}
+}
+
+func (s *JournalService) FindOne(id int) (*Entry, error) {
+	var entry Entry
+	if err := s.db.Preload("Lines").Preload("Lines.Account").Preload("Company").Preload("Outlet").Preload("User").First(&entry, id).Error; err != nil {
+		return nil, exception.DB(err, "Jurnal")
+	}
+
+	return &entry, nil
+}
+
+func (s *JournalService) FindAll(query EntryQuery) *pagination.Result[Entry] {
+	result := pagination.New[Entry](query.Pagination)
+
+	db := s.db.Model(&Entry{}).Preload("Lines").Preload("Lines.Account")
+
+	if query.Company != 0 {
+		db.Where("company_id = ?", query.Company)
+	}
+
+	if query.Outlet != 0 {
+		db.Where("outlet_id = ?", query.Outlet)
+	}
+
+	if query.Account != 0 {
+		db.Where("id IN (?)", s.db.Model(&Line{}).Select("entry_id").Where("account_id = ?", query.Account))
+	}
+
+	if len(query.Source) > 0 {
+		db.Where("source IN (?)", query.Source)
+	}
+
+	if query.StartDate != "" {
+		db.Where("DATE(date) >= ?", query.StartDate)
+	}
+
+	if query.EndDate != "" {
+		db.Where("DATE(date) <= ?", query.EndDate)
+	}
+
+	db.Order("date DESC, id DESC")
+
+	return result.Paginate(db)
+}
+
+// Create posts a manual journal entry.
+func (s *JournalService) Create(data EntryDTO) (*Entry, error) {
+	posting := Posting{
+		Company:     data.Company,
+		Outlet:      data.Outlet,
+		User:        &data.User,
+		Date:        time.Now(),
+		Source:      SourceManual,
+		Description: data.Description,
+	}
+
+	if !data.Date.IsZero() {
+		posting.Date = data.Date
+	}
+
+	for _, v := range data.Lines {
+		if (v.Debit > 0) == (v.Credit > 0) {
+			return nil, exception.BadRequest("Setiap baris jurnal harus berisi debit atau kredit")
+		}
+
+		posting.Lines = append(posting.Lines, PostingLine{
+			Account: v.Account,
+			Debit:   v.Debit,
+			Credit:  v.Credit,
+			Memo:    v.Memo,
+		})
+	}
+
+	if err := period.Check(s.db, posting.Company, posting.Date); err != nil {
+		return nil, exception.DB(err)
+	}
+
+	entry, err := post(s.db, posting)
+	if err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return s.FindOne(int(entry.ID))
+}
+
+// Reverse undoes a manual journal entry. Entries posted from documents are
+// reversed by canceling the document instead.
+func (s *JournalService) Reverse(id int) error {
+	return exception.DB(s.db.Transaction(func(tx *gorm.DB) error {
+		var entry Entry
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Lines").First(&entry, id).Error; err != nil {
+			return exception.DB(err, "Jurnal")
+		}
+
+		if entry.Source != SourceManual || entry.Reversal {
+			return exception.BadRequest("Hanya jurnal manual yang dapat dibalik")
+		}
+
+		var count int64
+		if err := tx.Model(&Entry{}).Where("source = ? AND source_id = ? AND reversal = ?", SourceManual, entry.ID, true).Count(&count).Error; err != nil {
+			return err
+		}
+
+		if count > 0 {
+			return exception.BadRequest("Jurnal sudah dibalik")
+		}
+
+		return reverse(tx, &entry, time.Now())
+	}))
+}
+
+// FindMappings lists the account every key posts to for a company.
+func (s *JournalService) FindMappings(query MappingQuery) ([]Setting, error) {
+	keys := make([]string, 0, len(defaults))
+	for key := range defaults {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	settings := make([]Setting, len(keys))
+	for i, key := range keys {
+		settings[i].Key = key
+
+		var mapping Mapping
+		if err := s.db.Where(&Mapping{CompanyID: query.Company, Key: key}).Limit(1).Find(&mapping).Error; err != nil {
+			return nil, exception.DB(err)
+		}
+
+		if mapping.ID != 0 {
+			settings[i].Mapped = true
+			settings[i].Account = &mapping.AccountID
+			continue
+		}
+
+		if id, err := Resolve(s.db, query.Company, key); err == nil {
+			settings[i].Account = &id
+		}
+	}
+
+	return settings, nil
+}
+
+// SaveMappings sets the accounts keys post to for a company.
+func (s *JournalService) SaveMappings(data MappingDTO) ([]Setting, error) {
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		for key, accountId := range data.Accounts {
+			if _, ok := defaults[key]; !ok {
+				return exception.Validation(map[string]string{
+					"accounts": "Kunci '" + key + "' tidak dikenal",
+				})
+			}
+
+			if err := tx.Where(&Mapping{CompanyID: data.Company, Key: key}).Delete(&Mapping{}).Error; err != nil {
+				return err
+			}
+
+			if accountId == 0 {
+				continue
+			}
+
+			var count int64
+			if err := tx.Table("accounts").Where("id = ? AND (company_id = ? OR company_id IS NULL)", accountId, data.Company).Count(&count).Error; err != nil {
+				return err
+			}
+
+			if count == 0 {
+				return exception.NotFound("Akun")
+			}
+
+			if err := tx.Create(&Mapping{Key: key, AccountID: accountId, CompanyID: data.Company}).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return s.FindMappings(MappingQuery{Company: data.Company})
+}
+
+func (s *JournalService) Using(tx *gorm.DB) *JournalService {
+	return &JournalService{tx}
+}
+
+func (s *JournalService) WithContext(ctx context.Context) *JournalService {
+	return &JournalService{s.db.WithContext(ctx)}
+}
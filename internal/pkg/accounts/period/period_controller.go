@@ -0,0 +1,88 @@
+package period
+
+import (
+	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/auth"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PeriodController struct {
+	*common.BaseController
+	period *PeriodService
+}
+
+func NewController(ctrl *common.BaseController, period *PeriodService) *PeriodController {
+	return &PeriodController{ctrl, period}
+}
+
+// @Summary Get All Accounting Periods
+// @Tags Accounting Periods
+// @Accept json
+// @Produce json
+// @Param query query PeriodQuery true "query"
+// @Success 200 {object} common.PaginatedResponse{result=[]Period}
+// @Security JWT
+// @Router /api/accounting-period [get]
+func (ctrl *PeriodController) All(ctx *fiber.Ctx) error {
+	var query PeriodQuery
+	if err := ctrl.Validation.Query(&query, ctx); err != nil {
+		return err
+	}
+
+	result := ctrl.period.WithContext(ctx.Context()).FindAll(query)
+
+	return ctx.Status(fiber.StatusOK).JSON(result)
+}
+
+// @Summary Close Accounting Period
+// @Description Locks the documents of a company dated in the month
+// @Tags Accounting Periods
+// @Accept json
+// @Produce json
+// @Param request body PeriodDTO true "Request Body"
+// @Success 200 {object} common.GeneralResponse{result=Period}
+// @Security JWT
+// @Router /api/accounting-period/close [post]
+func (ctrl *PeriodController) Close(ctx *fiber.Ctx) error {
+	var data PeriodDTO
+	if err := ctrl.Validation.Body(&data, ctx); err != nil {
+		return err
+	}
+
+	period, err := ctrl.period.WithContext(ctx.Context()).Close(data, auth.GetCreds(ctx.Context()))
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.GeneralResponse{
+		Message: "Periode berhasil ditutup",
+		Result:  period,
+	})
+}
+
+// @Summary Reopen Accounting Period
+// @Description Superadmin only, the reason is kept in the status history of the period
+// @Tags Accounting Periods
+// @Accept json
+// @Produce json
+// @Param request body ReopenDTO true "Request Body"
+// @Success 200 {object} common.GeneralResponse{result=Period}
+// @Security JWT
+// @Router /api/accounting-period/reopen [post]
+func (ctrl *PeriodController) Reopen(ctx *fiber.Ctx) error {
+	var data ReopenDTO
+	if err := ctrl.Validation.Body(&data, ctx); err != nil {
+		return err
+	}
+
+	period, err := ctrl.period.WithContext(ctx.Context()).Reopen(data, auth.GetCreds(ctx.Context()))
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.GeneralResponse{
+		Message: "Periode berhasil dibuka kembali",
+		Result:  period,
+	})
+}
@@ -0,0 +1,22 @@
+package period
+
+import "abude-backend/pkg/pagination"
+
+type PeriodDTO struct {
+	Company uint   `json:"company" validate:"required,exist=companies"`
+	Month   string `json:"month" validate:"required,datetime=2006-01" example:"2024-01"`
+	Reason  string `json:"reason" validate:"omitempty,max=255"`
+}
+
+// ReopenDTO reopens a closed period. The reason is kept in the status history.
+type ReopenDTO struct {
+	Company uint   `json:"company" validate:"required,exist=companies"`
+	Month   string `json:"month" validate:"required,datetime=2006-01" example:"2024-01"`
+	Reason  string `json:"reason" validate:"required,max=255"`
+}
+
+type PeriodQuery struct {
+	pagination.Pagination
+	Company uint     `query:"company" validate:"required"`
+	Status  []string `query:"status" enums:"open,closed"`
+}
@@ -0,0 +1,36 @@
+package period
+
+import (
+	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/company"
+	"abude-backend/internal/pkg/user"
+	"time"
+)
+
+const (
+	StatusOpen   = "open"
+	StatusClosed = "closed"
+)
+
+// Layout of the month of a period.
+const monthFormat = "2006-01"
+
+// Period is a month of the books of a company. Documents dated in a closed
+// period can no longer be created, changed, canceled or deleted. Months
+// without a period are open.
+type Period struct {
+	common.BaseModel
+	Month    string     `json:"month" gorm:"type:varchar(7);uniqueIndex:idx_accounting_period"`
+	Status   string     `json:"status" gorm:"type:enum('open','closed')" enums:"open,closed"`
+	ClosedAt *time.Time `json:"closedAt"`
+
+	Closer   *user.User `json:"closer,omitempty" gorm:"constraint:OnDelete:SET NULL;"`
+	CloserID *uint      `json:"-"`
+
+	Company   *company.Company `json:"company,omitempty" gorm:"constraint:OnDelete:CASCADE;"`
+	CompanyID uint             `json:"-" gorm:"uniqueIndex:idx_accounting_period"`
+}
+
+func (Period) TableName() string {
+	return "accounting_periods"
+}
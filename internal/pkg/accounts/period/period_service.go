@@ -0,0 +1,151 @@
+package period
+
+import (
+	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/transition"
+	"abude-backend/pkg/exception"
+	"abude-backend/pkg/pagination"
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PeriodService struct {
+	db *gorm.DB
+}
+
+func NewService(db *gorm.DB) *PeriodService {
+	return &PeriodService{db}
+}
+
+func (s *PeriodService) FindAll(query PeriodQuery) *pagination.Result[Period] {
+	result := pagination.New[Period](query.Pagination)
+
+	db := s.db.Model(&Period{}).Preload("Closer").Where("company_id = ?", query.Company)
+
+	if len(query.Status) > 0 {
+		db.Where("status IN (?)", query.Status)
+	}
+
+	db.Order("month DESC")
+
+	return result.Paginate(db)
+}
+
+// Close locks a month that has ended.
+func (s *PeriodService) Close(data PeriodDTO, creds *common.Creds) (*Period, error) {
+	if data.Month >= time.Now().Format(monthFormat) {
+		return nil, exception.Validation(map[string]string{
+			"month": "Periode yang belum berakhir tidak dapat ditutup",
+		})
+	}
+
+	return s.set(data.Company, data.Month, StatusClosed, creds, data.Reason)
+}
+
+// Reopen unlocks a closed month. Only superadmins may reopen, and the reason
+// is kept in the status history of the period.
+func (s *PeriodService) Reopen(data ReopenDTO, creds *common.Creds) (*Period, error) {
+	return s.set(data.Company, data.Month, StatusOpen, creds, data.Reason)
+}
+
+func (s *PeriodService) set(company uint, month string, status string, creds *common.Creds, reason string) (*Period, error) {
+	var period Period
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("company_id = ? AND month = ?", company, month).
+			Limit(1).
+			Find(&period).Error; err != nil {
+			return err
+		}
+
+		if period.ID == 0 {
+			period = Period{
+				Month:     month,
+				Status:    StatusOpen,
+				CompanyID: company,
+			}
+
+			if err := tx.Create(&period).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := transition.Apply(tx, transition.Change{
+			Type:   transition.TypePeriod,
+			ID:     period.ID,
+			From:   period.Status,
+			To:     status,
+			Creds:  creds,
+			Reason: reason,
+		}); err != nil {
+			return err
+		}
+
+		period.Status = status
+		period.ClosedAt = nil
+		period.CloserID = nil
+
+		if status == StatusClosed {
+			now := time.Now()
+			period.ClosedAt = &now
+			period.CloserID = &creds.ID
+		}
+
+		return tx.Save(&period).Error
+	}); err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &period, nil
+}
+
+// Check fails when any of the dates falls in a closed period of the company.
+// Zero dates are ignored.
+func Check(tx *gorm.DB, company uint, dates ...time.Time) error {
+	months := make([]string, 0, len(dates))
+	for _, date := range dates {
+		if !date.IsZero() {
+			months = append(months, date.Format(monthFormat))
+		}
+	}
+
+	if len(months) == 0 {
+		return nil
+	}
+
+	var closed Period
+	if err := tx.Where("company_id = ? AND month IN (?) AND status = ?", company, months, StatusClosed).
+		Order("month ASC").
+		Limit(1).
+		Find(&closed).Error; err != nil {
+		return err
+	}
+
+	if closed.ID != 0 {
+		return exception.BadRequest(fmt.Sprintf("Periode %s sudah ditutup, data pada periode tersebut tidak dapat diubah", closed.Month))
+	}
+
+	return nil
+}
+
+// CheckOutlet is Check for the company of an outlet.
+func CheckOutlet(tx *gorm.DB, outlet uint, dates ...time.Time) error {
+	var company uint
+	if err := tx.Table("outlets").Select("company_id").Where("id = ?", outlet).Scan(&company).Error; err != nil {
+		return err
+	}
+
+	return Check(tx, company, dates...)
+}
+
+func (s *PeriodService) Using(tx *gorm.DB) *PeriodService {
+	return &PeriodService{tx}
+}
+
+func (s *PeriodService) WithContext(ctx context.Context) *PeriodService {
+	return &PeriodService{s.db.WithContext(ctx)}
+}
@@ -0,0 +1,391 @@
+package statement
+
+import (
+	"abude-backend/internal/pkg/accounts/bank"
+	"abude-backend/internal/pkg/accounts/category"
+	"abude-backend/internal/pkg/accounts/journal"
+	"abude-backend/pkg/exception"
+	"sort"
+	"time"
+)
+
+// Lines of the operating activities in the order they are shown. Expenses
+// paid as wages are reported as wages.
+var operating = []struct {
+	kind string
+	name string
+}{
+	{journal.SourceSale, "Penerimaan penjualan"},
+	{journal.SourceReturn, "Pengembalian dana retur penjualan"},
+	{journal.SourceReceipt, "Penerimaan piutang pelanggan"},
+	{journal.SourceRepayment, "Pelunasan kasbon karyawan"},
+	{journal.SourcePurchase, "Pembayaran pembelian"},
+	{journal.SourcePayment, "Pembayaran utang pemasok"},
+	{journal.SourceExpense, "Pembayaran beban"},
+	{journal.SourceWage, "Pembayaran gaji"},
+	{journal.SourceAdvance, "Pemberian kasbon karyawan"},
+	{journal.SourceTransfer, "Transfer antar rekening"},
+}
+
+// Mapped accounts whose movements against cash stay operating in manual
+// entries, even though they are assets or liabilities.
+var workingCapital = []string{
+	journal.KeyReceivable,
+	journal.KeyInventory,
+	journal.KeyEmployeeAdvance,
+	journal.KeyPayable,
+	journal.KeyTax,
+}
+
+// CashFlow reports the cash received and paid in a period by activity.
+// Documents post as operating activities; manual entries are classified by
+// the accounts on the other side of the cash.
+func (s *StatementService) CashFlow(query CashFlowQuery) (*CashFlow, error) {
+	start, _ := time.Parse(dateFormat, query.StartDate)
+	end, _ := time.Parse(dateFormat, query.EndDate)
+	if end.Before(start) {
+		return nil, exception.Validation(map[string]string{
+			"endDate": "Tanggal akhir harus setelah tanggal awal",
+		})
+	}
+
+	cash, err := s.cashAccounts(query.Company)
+	if err != nil {
+		return nil, err
+	}
+
+	opening, err := s.openingCash(query.Company, query.Outlet, cash, query.StartDate)
+	if err != nil {
+		return nil, err
+	}
+
+	movements, err := s.movements(query.Company, query.Outlet, cash, query.StartDate, query.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	manual, err := s.manualMovements(query.Company, query.Outlet, cash, query.StartDate, query.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	report := CashFlow{
+		StartDate: query.StartDate,
+		EndDate:   query.EndDate,
+		Opening:   opening,
+		Operating: Activity{Activity: ActivityOperating, Name: "Arus Kas dari Aktivitas Operasi", Lines: []CashFlowLine{}},
+		Investing: Activity{Activity: ActivityInvesting, Name: "Arus Kas dari Aktivitas Investasi", Lines: []CashFlowLine{}},
+		Financing: Activity{Activity: ActivityFinancing, Name: "Arus Kas dari Aktivitas Pendanaan", Lines: []CashFlowLine{}},
+	}
+
+	totals := map[string]*CashFlowLine{}
+	for _, v := range movements {
+		if v.Kind == journal.SourceManual {
+			continue
+		}
+
+		line, ok := totals[v.Kind]
+		if !ok {
+			line = &CashFlowLine{Source: v.Kind, Name: v.Kind}
+			totals[v.Kind] = line
+		}
+
+		line.Inflow += v.Inflow
+		line.Outflow += v.Outflow
+	}
+
+	for _, v := range operating {
+		if line, ok := totals[v.kind]; ok {
+			line.Name = v.name
+			report.Operating.add(*line)
+			delete(totals, v.kind)
+		}
+	}
+
+	// Sources without a line of their own
+	var others []string
+	for kind := range totals {
+		others = append(others, kind)
+	}
+
+	sort.Strings(others)
+	for _, kind := range others {
+		report.Operating.add(*totals[kind])
+	}
+
+	for _, activity := range []*Activity{&report.Operating, &report.Investing, &report.Financing} {
+		if v, ok := manual[activity.Activity]; ok {
+			activity.add(CashFlowLine{
+				Source:  journal.SourceManual,
+				Name:    "Jurnal umum",
+				Inflow:  v.Inflow,
+				Outflow: v.Outflow,
+			})
+		}
+	}
+
+	report.NetChange = round(report.Operating.Net + report.Investing.Net + report.Financing.Net)
+	report.Closing = round(report.Opening + report.NetChange)
+
+	return &report, nil
+}
+
+// CashPosition reports the cash at the end of every day of a range.
+func (s *StatementService) CashPosition(query CashPositionQuery) (*CashPosition, error) {
+	end := time.Now()
+	if query.EndDate != "" {
+		end, _ = time.Parse(dateFormat, query.EndDate)
+	}
+
+	start := end.AddDate(0, 0, -30)
+	if query.StartDate != "" {
+		start, _ = time.Parse(dateFormat, query.StartDate)
+	}
+
+	if end.Before(start) {
+		return nil, exception.Validation(map[string]string{
+			"endDate": "Tanggal akhir harus setelah tanggal awal",
+		})
+	}
+
+	if end.Sub(start) > 366*24*time.Hour {
+		return nil, exception.Validation(map[string]string{
+			"startDate": "Rentang tanggal paling lama satu tahun",
+		})
+	}
+
+	report := CashPosition{
+		StartDate: start.Format(dateFormat),
+		EndDate:   end.Format(dateFormat),
+		Days:      []CashPositionDay{},
+	}
+
+	cash, err := s.cashAccounts(query.Company)
+	if err != nil {
+		return nil, err
+	}
+
+	report.Opening, err = s.openingCash(query.Company, query.Outlet, cash, report.StartDate)
+	if err != nil {
+		return nil, err
+	}
+
+	movements, err := s.movements(query.Company, query.Outlet, cash, report.StartDate, report.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	days := map[string]*CashPositionDay{}
+	for _, v := range movements {
+		day, ok := days[v.Day]
+		if !ok {
+			day = &CashPositionDay{Date: v.Day}
+			days[v.Day] = day
+		}
+
+		day.Inflow = round(day.Inflow + v.Inflow)
+		day.Outflow = round(day.Outflow + v.Outflow)
+	}
+
+	balance := report.Opening
+	for date := start; !date.After(end); date = date.AddDate(0, 0, 1) {
+		day := CashPositionDay{Date: date.Format(dateFormat)}
+		if v, ok := days[day.Date]; ok {
+			day = *v
+		}
+
+		balance = round(balance + day.Inflow - day.Outflow)
+		day.Balance = balance
+		report.Days = append(report.Days, day)
+	}
+
+	report.Closing = balance
+
+	return &report, nil
+}
+
+func (a *Activity) add(line CashFlowLine) {
+	line.Inflow = round(line.Inflow)
+	line.Outflow = round(line.Outflow)
+	line.Net = round(line.Inflow - line.Outflow)
+
+	a.Lines = append(a.Lines, line)
+	a.Inflow = round(a.Inflow + line.Inflow)
+	a.Outflow = round(a.Outflow + line.Outflow)
+	a.Net = round(a.Inflow - a.Outflow)
+}
+
+// cashAccounts lists the mapped cash account of a company and the ledger
+// accounts of its bank and cash accounts.
+func (s *StatementService) cashAccounts(company uint) ([]uint, error) {
+	id, err := journal.Resolve(s.db, company, journal.KeyCash)
+	if err != nil {
+		return nil, exception.DB(err)
+	}
+
+	var accounts []uint
+	if err := s.db.Model(&bank.BankAccount{}).
+		Where("company_id = ?", company).
+		Distinct().
+		Pluck("account_id", &accounts).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return append(accounts, id), nil
+}
+
+// openingCash is the cash before a date: the opening balances of the bank
+// and cash accounts plus the cash posted before it. Outlets only count their
+// own bank and cash accounts.
+func (s *StatementService) openingCash(company uint, outlet uint, cash []uint, before string) (float64, error) {
+	var posted float64
+	db := s.db.Table("journal_lines").
+		Select("COALESCE(SUM(journal_lines.debit - journal_lines.credit), 0)").
+		Joins("INNER JOIN journal_entries ON journal_entries.id = journal_lines.entry_id").
+		Where("journal_entries.company_id = ? AND journal_lines.account_id IN (?)", company, cash).
+		Where("DATE(journal_entries.date) < ?", before)
+
+	if outlet != 0 {
+		db.Where("journal_entries.outlet_id = ?", outlet)
+	}
+
+	if err := db.Scan(&posted).Error; err != nil {
+		return 0, exception.DB(err)
+	}
+
+	var balances float64
+	banks := s.db.Model(&bank.BankAccount{}).
+		Select("COALESCE(SUM(opening_balance), 0)").
+		Where("company_id = ?", company)
+
+	if outlet != 0 {
+		banks.Where("outlet_id = ?", outlet)
+	}
+
+	if err := banks.Scan(&balances).Error; err != nil {
+		return 0, exception.DB(err)
+	}
+
+	return round(posted + balances), nil
+}
+
+// movements sums the cash posted per day and kind of document between two
+// dates.
+func (s *StatementService) movements(company uint, outlet uint, cash []uint, start string, end string) ([]movement, error) {
+	var movements []movement
+
+	wages := s.db.Table("wages").Select("expense_id").Where("expense_id IS NOT NULL")
+
+	db := s.db.Table("journal_lines").
+		Select("DATE_FORMAT(journal_entries.date, '%Y-%m-%d') AS day, "+
+			"CASE WHEN journal_entries.source = ? AND journal_entries.source_id IN (?) THEN ? ELSE journal_entries.source END AS kind, "+
+			"SUM(CASE WHEN journal_entries.reversal THEN -journal_lines.credit ELSE journal_lines.debit END) AS inflow, "+
+			"SUM(CASE WHEN journal_entries.reversal THEN -journal_lines.debit ELSE journal_lines.credit END) AS outflow",
+			journal.SourceExpense, wages, journal.SourceWage).
+		Joins("INNER JOIN journal_entries ON journal_entries.id = journal_lines.entry_id").
+		Where("journal_entries.company_id = ? AND journal_lines.account_id IN (?)", company, cash).
+		Where("DATE(journal_entries.date) BETWEEN ? AND ?", start, end).
+		Group("day, kind").
+		Order("day ASC, kind ASC")
+
+	if outlet != 0 {
+		db.Where("journal_entries.outlet_id = ?", outlet)
+	}
+
+	if err := db.Scan(&movements).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return movements, nil
+}
+
+// manualMovements sums the cash of manual entries by activity. Entries
+// against equity or liabilities are financing, against other assets
+// investing, and the rest operating.
+func (s *StatementService) manualMovements(company uint, outlet uint, cash []uint, start string, end string) (map[string]*movement, error) {
+	var entries []struct {
+		EntryID uint
+		Inflow  float64
+		Outflow float64
+	}
+
+	db := s.db.Table("journal_lines").
+		Select("journal_lines.entry_id, "+
+			"SUM(CASE WHEN journal_entries.reversal THEN -journal_lines.credit ELSE journal_lines.debit END) AS inflow, "+
+			"SUM(CASE WHEN journal_entries.reversal THEN -journal_lines.debit ELSE journal_lines.credit END) AS outflow").
+		Joins("INNER JOIN journal_entries ON journal_entries.id = journal_lines.entry_id").
+		Where("journal_entries.company_id = ? AND journal_entries.source = ? AND journal_lines.account_id IN (?)", company, journal.SourceManual, cash).
+		Where("DATE(journal_entries.date) BETWEEN ? AND ?", start, end).
+		Group("journal_lines.entry_id")
+
+	if outlet != 0 {
+		db.Where("journal_entries.outlet_id = ?", outlet)
+	}
+
+	if err := db.Scan(&entries).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	result := map[string]*movement{}
+	if len(entries) == 0 {
+		return result, nil
+	}
+
+	ids := make([]uint, len(entries))
+	for i, v := range entries {
+		ids[i] = v.EntryID
+	}
+
+	var counters []struct {
+		EntryID      uint
+		AccountID    uint
+		CategoryCode string
+	}
+
+	if err := s.db.Table("journal_lines").
+		Select("journal_lines.entry_id, journal_lines.account_id, account_categories.code AS category_code").
+		Joins("INNER JOIN accounts ON accounts.id = journal_lines.account_id").
+		Joins("INNER JOIN account_categories ON account_categories.id = accounts.category_id").
+		Where("journal_lines.entry_id IN (?) AND journal_lines.account_id NOT IN (?)", ids, cash).
+		Scan(&counters).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	working := map[uint]bool{}
+	for _, key := range workingCapital {
+		// Keys without an account cannot be on the other side of an entry
+		if id, err := journal.Resolve(s.db, company, key); err == nil {
+			working[id] = true
+		}
+	}
+
+	activities := map[uint]string{}
+	for _, v := range counters {
+		if working[v.AccountID] || activities[v.EntryID] == ActivityFinancing {
+			continue
+		}
+
+		switch v.CategoryCode {
+		case category.CodeEquity, category.CodeLiability:
+			activities[v.EntryID] = ActivityFinancing
+		case category.CodeAsset:
+			activities[v.EntryID] = ActivityInvesting
+		}
+	}
+
+	for _, v := range entries {
+		activity := activities[v.EntryID]
+		if activity == "" {
+			activity = ActivityOperating
+		}
+
+		if _, ok := result[activity]; !ok {
+			result[activity] = &movement{Kind: journal.SourceManual}
+		}
+
+		result[activity].Inflow += v.Inflow
+		result[activity].Outflow += v.Outflow
+	}
+
+	return result, nil
+}
@@ -0,0 +1,157 @@
+package statement
+
+import (
+	"abude-backend/internal/common"
+	"abude-backend/pkg/exception"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type StatementController struct {
+	*common.BaseController
+	statement *StatementService
+}
+
+func NewController(ctrl *common.BaseController, statement *StatementService) *StatementController {
+	return &StatementController{ctrl, statement}
+}
+
+// @Summary Get Trial Balance
+// @Tags Financial Statements
+// @Accept json
+// @Produce json
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param query query TrialBalanceQuery true "query"
+// @Success 200 {object} TrialBalance
+// @Security JWT
+// @Router /api/statement/trial-balance [get]
+func (ctrl *StatementController) TrialBalance(ctx *fiber.Ctx) error {
+	var query TrialBalanceQuery
+	if err := ctrl.Validation.Query(&query, ctx); err != nil {
+		return err
+	}
+
+	report, err := ctrl.statement.WithContext(ctx.Context()).TrialBalance(query)
+	if err != nil {
+		return err
+	}
+
+	return send(ctx, query.Format, report)
+}
+
+// @Summary Get Income Statement
+// @Description Profit and loss of a period compared with the period of the same length before it
+// @Tags Financial Statements
+// @Accept json
+// @Produce json
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param query query IncomeStatementQuery true "query"
+// @Success 200 {object} IncomeStatement
+// @Security JWT
+// @Router /api/statement/income-statement [get]
+func (ctrl *StatementController) IncomeStatement(ctx *fiber.Ctx) error {
+	var query IncomeStatementQuery
+	if err := ctrl.Validation.Query(&query, ctx); err != nil {
+		return err
+	}
+
+	report, err := ctrl.statement.WithContext(ctx.Context()).IncomeStatement(query)
+	if err != nil {
+		return err
+	}
+
+	return send(ctx, query.Format, report)
+}
+
+// @Summary Get Balance Sheet
+// @Tags Financial Statements
+// @Accept json
+// @Produce json
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param query query BalanceSheetQuery true "query"
+// @Success 200 {object} BalanceSheet
+// @Security JWT
+// @Router /api/statement/balance-sheet [get]
+func (ctrl *StatementController) BalanceSheet(ctx *fiber.Ctx) error {
+	var query BalanceSheetQuery
+	if err := ctrl.Validation.Query(&query, ctx); err != nil {
+		return err
+	}
+
+	report, err := ctrl.statement.WithContext(ctx.Context()).BalanceSheet(query)
+	if err != nil {
+		return err
+	}
+
+	return send(ctx, query.Format, report)
+}
+
+// @Summary Get Cash Flow Statement
+// @Description Direct method cash flow by operating, investing and financing activities. Transfers have no outlet and only show for the whole company.
+// @Tags Financial Statements
+// @Accept json
+// @Produce json
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param query query CashFlowQuery true "query"
+// @Success 200 {object} CashFlow
+// @Security JWT
+// @Router /api/statement/cash-flow [get]
+func (ctrl *StatementController) CashFlow(ctx *fiber.Ctx) error {
+	var query CashFlowQuery
+	if err := ctrl.Validation.Query(&query, ctx); err != nil {
+		return err
+	}
+
+	report, err := ctrl.statement.WithContext(ctx.Context()).CashFlow(query)
+	if err != nil {
+		return err
+	}
+
+	return send(ctx, query.Format, report)
+}
+
+// @Summary Get Daily Cash Position
+// @Description Cash received, paid and held at the end of every day of the range
+// @Tags Financial Statements
+// @Accept json
+// @Produce json
+// @Param query query CashPositionQuery true "query"
+// @Success 200 {object} CashPosition
+// @Security JWT
+// @Router /api/statement/cash-position [get]
+func (ctrl *StatementController) CashPosition(ctx *fiber.Ctx) error {
+	var query CashPositionQuery
+	if err := ctrl.Validation.Query(&query, ctx); err != nil {
+		return err
+	}
+
+	report, err := ctrl.statement.WithContext(ctx.Context()).CashPosition(query)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(report)
+}
+
+type document interface {
+	XLSX() ([]byte, error)
+	Filename() string
+}
+
+// send responds with the report as JSON, or as a spreadsheet download.
+func send(ctx *fiber.Ctx, format string, report document) error {
+	if format != "xlsx" {
+		return ctx.Status(fiber.StatusOK).JSON(report)
+	}
+
+	content, err := report.XLSX()
+	if err != nil {
+		return exception.InternalError("Laporan tidak dapat dibuat")
+	}
+
+	ctx.Set(fiber.HeaderContentType, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, report.Filename()))
+
+	return ctx.Status(fiber.StatusOK).Send(content)
+}
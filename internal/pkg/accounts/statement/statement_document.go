@@ -0,0 +1,120 @@
+package statement
+
+import (
+	"abude-backend/pkg/xlsx"
+)
+
+// XLSX renders the trial balance as a spreadsheet.
+func (r *TrialBalance) XLSX() ([]byte, error) {
+	book := xlsx.New()
+	sheet := book.AddSheet("Neraca Saldo")
+	sheet.AddHeader("Neraca Saldo")
+	sheet.AddRow("Per tanggal", r.Date)
+	sheet.AddRow()
+	sheet.AddHeader("Kode", "Akun", "Kategori", "Debit", "Kredit")
+
+	for _, v := range r.Lines {
+		sheet.AddRow(v.Code, v.Name, v.Category, v.Debit, v.Credit)
+	}
+
+	sheet.AddHeader("", "Total", "", r.Debit, r.Credit)
+
+	return book.Bytes()
+}
+
+func (r *TrialBalance) Filename() string {
+	return "neraca-saldo-" + r.Date + ".xlsx"
+}
+
+// XLSX renders the income statement as a spreadsheet.
+func (r *IncomeStatement) XLSX() ([]byte, error) {
+	book := xlsx.New()
+	sheet := book.AddSheet("Laba Rugi")
+	sheet.AddHeader("Laba Rugi")
+	sheet.AddRow("Periode", r.StartDate+" s/d "+r.EndDate)
+	sheet.AddRow("Periode sebelumnya", r.PreviousStartDate+" s/d "+r.PreviousEndDate)
+	sheet.AddRow()
+	sheet.AddHeader("Kode", "Akun", "Periode ini", "Periode sebelumnya")
+
+	for _, section := range []Section{r.Revenue, r.Expense} {
+		sheet.AddHeader(section.Code, section.Name)
+		for _, v := range section.Lines {
+			sheet.AddRow(v.Code, v.Name, v.Amount, previous(v.Previous))
+		}
+		sheet.AddHeader("", "Total "+section.Name, section.Total, previous(section.Previous))
+		sheet.AddRow()
+	}
+
+	sheet.AddHeader("", "Laba Bersih", r.NetIncome, r.PreviousNetIncome)
+
+	return book.Bytes()
+}
+
+func (r *IncomeStatement) Filename() string {
+	return "laba-rugi-" + r.StartDate + "-" + r.EndDate + ".xlsx"
+}
+
+// XLSX renders the balance sheet as a spreadsheet.
+func (r *BalanceSheet) XLSX() ([]byte, error) {
+	book := xlsx.New()
+	sheet := book.AddSheet("Neraca")
+	sheet.AddHeader("Neraca")
+	sheet.AddRow("Per tanggal", r.Date)
+	sheet.AddRow()
+	sheet.AddHeader("Kode", "Akun", "Saldo")
+
+	for _, section := range []Section{r.Asset, r.Liability, r.Equity} {
+		sheet.AddHeader(section.Code, section.Name)
+		for _, v := range section.Lines {
+			sheet.AddRow(v.Code, v.Name, v.Amount)
+		}
+		sheet.AddHeader("", "Total "+section.Name, section.Total)
+		sheet.AddRow()
+	}
+
+	sheet.AddHeader("", "Total Liabilitas dan Ekuitas", r.LiabilityAndEquity)
+
+	return book.Bytes()
+}
+
+func (r *BalanceSheet) Filename() string {
+	return "neraca-" + r.Date + ".xlsx"
+}
+
+// XLSX renders the cash flow statement as a spreadsheet.
+func (r *CashFlow) XLSX() ([]byte, error) {
+	book := xlsx.New()
+	sheet := book.AddSheet("Arus Kas")
+	sheet.AddHeader("Arus Kas")
+	sheet.AddRow("Periode", r.StartDate+" s/d "+r.EndDate)
+	sheet.AddRow()
+	sheet.AddHeader("", "Penerimaan", "Pengeluaran", "Bersih")
+	sheet.AddRow("Kas awal periode", nil, nil, r.Opening)
+	sheet.AddRow()
+
+	for _, activity := range []Activity{r.Operating, r.Investing, r.Financing} {
+		sheet.AddHeader(activity.Name)
+		for _, v := range activity.Lines {
+			sheet.AddRow(v.Name, v.Inflow, v.Outflow, v.Net)
+		}
+		sheet.AddHeader("Total "+activity.Name, activity.Inflow, activity.Outflow, activity.Net)
+		sheet.AddRow()
+	}
+
+	sheet.AddHeader("Kenaikan (penurunan) kas", nil, nil, r.NetChange)
+	sheet.AddHeader("Kas akhir periode", nil, nil, r.Closing)
+
+	return book.Bytes()
+}
+
+func (r *CashFlow) Filename() string {
+	return "arus-kas-" + r.StartDate + "-" + r.EndDate + ".xlsx"
+}
+
+func previous(value *float64) interface{} {
+	if value == nil {
+		return nil
+	}
+
+	return *value
+}
@@ -0,0 +1,38 @@
+package statement
+
+type TrialBalanceQuery struct {
+	Company uint   `query:"company" validate:"required,exist=companies"`
+	Outlet  uint   `query:"outlet" validate:"omitempty,exist=outlets"`
+	Date    string `query:"date" validate:"omitempty,datetime=2006-01-02" format:"date"`   // Defaults to today
+	Format  string `query:"format" validate:"omitempty,oneof=json xlsx" enums:"json,xlsx"` // Defaults to json
+}
+
+type IncomeStatementQuery struct {
+	Company   uint   `query:"company" validate:"required,exist=companies"`
+	Outlet    uint   `query:"outlet" validate:"omitempty,exist=outlets"`
+	StartDate string `query:"startDate" validate:"required,datetime=2006-01-02" format:"date"`
+	EndDate   string `query:"endDate" validate:"required,datetime=2006-01-02" format:"date"`
+	Format    string `query:"format" validate:"omitempty,oneof=json xlsx" enums:"json,xlsx"` // Defaults to json
+}
+
+type BalanceSheetQuery struct {
+	Company uint   `query:"company" validate:"required,exist=companies"`
+	Outlet  uint   `query:"outlet" validate:"omitempty,exist=outlets"`
+	Date    string `query:"date" validate:"omitempty,datetime=2006-01-02" format:"date"`   // Defaults to today
+	Format  string `query:"format" validate:"omitempty,oneof=json xlsx" enums:"json,xlsx"` // Defaults to json
+}
+
+type CashFlowQuery struct {
+	Company   uint   `query:"company" validate:"required,exist=companies"`
+	Outlet    uint   `query:"outlet" validate:"omitempty,exist=outlets"`
+	StartDate string `query:"startDate" validate:"required,datetime=2006-01-02" format:"date"`
+	EndDate   string `query:"endDate" validate:"required,datetime=2006-01-02" format:"date"`
+	Format    string `query:"format" validate:"omitempty,oneof=json xlsx" enums:"json,xlsx"` // Defaults to json
+}
+
+type CashPositionQuery struct {
+	Company   uint   `query:"company" validate:"required,exist=companies"`
+	Outlet    uint   `query:"outlet" validate:"omitempty,exist=outlets"`
+	StartDate string `query:"startDate" validate:"omitempty,datetime=2006-01-02" format:"date"` // Defaults to 30 days before the end date
+	EndDate   string `query:"endDate" validate:"omitempty,datetime=2006-01-02" format:"date"`   // Defaults to today
+}
@@ -0,0 +1,149 @@
+package statement
+
+// TrialBalanceLine is the balance of an account on the side it stands.
+type TrialBalanceLine struct {
+	Code     string  `json:"code"`
+	Name     string  `json:"name"`
+	Category string  `json:"category"`
+	Debit    float64 `json:"debit"`
+	Credit   float64 `json:"credit"`
+}
+
+type TrialBalance struct {
+	Date   string             `json:"date"`
+	Lines  []TrialBalanceLine `json:"lines"`
+	Debit  float64            `json:"debit"`
+	Credit float64            `json:"credit"`
+}
+
+// Line is the amount of an account signed by its normal balance, so contra
+// accounts reduce the total of their section.
+type Line struct {
+	Code     string   `json:"code"`
+	Name     string   `json:"name"`
+	Amount   float64  `json:"amount"`
+	Previous *float64 `json:"previous,omitempty"` // Amount in the previous period
+}
+
+// Section groups the accounts of a category.
+type Section struct {
+	Code     string   `json:"code"`
+	Name     string   `json:"name"`
+	Lines    []Line   `json:"lines"`
+	Total    float64  `json:"total"`
+	Previous *float64 `json:"previous,omitempty"`
+}
+
+type IncomeStatement struct {
+	StartDate         string  `json:"startDate"`
+	EndDate           string  `json:"endDate"`
+	PreviousStartDate string  `json:"previousStartDate"`
+	PreviousEndDate   string  `json:"previousEndDate"`
+	Revenue           Section `json:"revenue"`
+	Expense           Section `json:"expense"`
+	NetIncome         float64 `json:"netIncome"`
+	PreviousNetIncome float64 `json:"previousNetIncome"`
+}
+
+// BalanceSheet lists assets against liabilities and equity. The profit not
+// closed into equity yet is shown as current earnings under equity.
+type BalanceSheet struct {
+	Date               string  `json:"date"`
+	Asset              Section `json:"asset"`
+	Liability          Section `json:"liability"`
+	Equity             Section `json:"equity"`
+	CurrentEarnings    float64 `json:"currentEarnings"`
+	LiabilityAndEquity float64 `json:"liabilityAndEquity"`
+	Balanced           bool    `json:"balanced"`
+}
+
+// balance is the movement of an account over a date range.
+type balance struct {
+	AccountID      uint
+	Code           string
+	Name           string
+	Normal         int
+	CategoryCode   string
+	CategoryName   string
+	CategoryNormal int
+	Debit          float64
+	Credit         float64
+}
+
+// Amount returns the balance signed by the normal side of the account, or of
+// its category when the account has none.
+func (b *balance) Amount() float64 {
+	normal := b.Normal
+	if normal == 0 {
+		normal = b.CategoryNormal
+	}
+
+	if normal == 0 {
+		normal = 1
+	}
+
+	return round((b.Debit - b.Credit) * float64(normal))
+}
+
+// Cash flow activities.
+const (
+	ActivityOperating = "operating"
+	ActivityInvesting = "investing"
+	ActivityFinancing = "financing"
+)
+
+// CashFlowLine is the cash received and paid for one kind of document.
+type CashFlowLine struct {
+	Source  string  `json:"source"`
+	Name    string  `json:"name"`
+	Inflow  float64 `json:"inflow"`
+	Outflow float64 `json:"outflow"`
+	Net     float64 `json:"net"`
+}
+
+type Activity struct {
+	Activity string         `json:"activity" enums:"operating,investing,financing"`
+	Name     string         `json:"name"`
+	Lines    []CashFlowLine `json:"lines"`
+	Inflow   float64        `json:"inflow"`
+	Outflow  float64        `json:"outflow"`
+	Net      float64        `json:"net"`
+}
+
+// CashFlow is a direct method cash flow statement. Cash is the mapped cash
+// account and the ledger accounts of the bank and cash accounts.
+type CashFlow struct {
+	StartDate string   `json:"startDate"`
+	EndDate   string   `json:"endDate"`
+	Opening   float64  `json:"opening"`
+	Operating Activity `json:"operating"`
+	Investing Activity `json:"investing"`
+	Financing Activity `json:"financing"`
+	NetChange float64  `json:"netChange"`
+	Closing   float64  `json:"closing"`
+}
+
+type CashPositionDay struct {
+	Date    string  `json:"date"`
+	Inflow  float64 `json:"inflow"`
+	Outflow float64 `json:"outflow"`
+	Balance float64 `json:"balance"` // Cash at the end of the day
+}
+
+// CashPosition is the cash at the end of every day of a range.
+type CashPosition struct {
+	StartDate string            `json:"startDate"`
+	EndDate   string            `json:"endDate"`
+	Opening   float64           `json:"opening"`
+	Days      []CashPositionDay `json:"days"`
+	Closing   float64           `json:"closing"`
+}
+
+// movement is the cash moved by the entries of a kind of document on a day,
+// with reversals taken off the side they undo.
+type movement struct {
+	Day     string
+	Kind    string
+	Inflow  float64
+	Outflow float64
+}
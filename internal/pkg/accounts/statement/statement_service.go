@@ -0,0 +1,247 @@
+package statement
+
+import (
+	"abude-backend/internal/pkg/accounts/category"
+	"abude-backend/pkg/exception"
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const dateFormat = "2006-01-02"
+
+type StatementService struct {
+	db *gorm.DB
+}
+
+func NewService(db *gorm.DB) *StatementService {
+	return &StatementService{db}
+}
+
+// TrialBalance lists the balance of every account with movements up to the
+// date, on its debit or credit side.
+func (s *StatementService) TrialBalance(query TrialBalanceQuery) (*TrialBalance, error) {
+	date := query.Date
+	if date == "" {
+		date = time.Now().Format(dateFormat)
+	}
+
+	balances, err := s.balances(query.Company, query.Outlet, "", date)
+	if err != nil {
+		return nil, err
+	}
+
+	report := TrialBalance{
+		Date:  date,
+		Lines: []TrialBalanceLine{},
+	}
+
+	for _, v := range balances {
+		net := round(v.Debit - v.Credit)
+		if net == 0 {
+			continue
+		}
+
+		line := TrialBalanceLine{
+			Code:     v.Code,
+			Name:     v.Name,
+			Category: v.CategoryName,
+		}
+
+		if net > 0 {
+			line.Debit = net
+		} else {
+			line.Credit = -net
+		}
+
+		report.Lines = append(report.Lines, line)
+		report.Debit = round(report.Debit + line.Debit)
+		report.Credit = round(report.Credit + line.Credit)
+	}
+
+	return &report, nil
+}
+
+// IncomeStatement compares revenue and expenses of a period with the period
+// of the same length right before it.
+func (s *StatementService) IncomeStatement(query IncomeStatementQuery) (*IncomeStatement, error) {
+	start, _ := time.Parse(dateFormat, query.StartDate)
+	end, _ := time.Parse(dateFormat, query.EndDate)
+	if end.Before(start) {
+		return nil, exception.Validation(map[string]string{
+			"endDate": "Tanggal akhir harus setelah tanggal awal",
+		})
+	}
+
+	previousEnd := start.AddDate(0, 0, -1)
+	previousStart := previousEnd.Add(-end.Sub(start))
+
+	report := IncomeStatement{
+		StartDate:         query.StartDate,
+		EndDate:           query.EndDate,
+		PreviousStartDate: previousStart.Format(dateFormat),
+		PreviousEndDate:   previousEnd.Format(dateFormat),
+	}
+
+	current, err := s.balances(query.Company, query.Outlet, report.StartDate, report.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, err := s.balances(query.Company, query.Outlet, report.PreviousStartDate, report.PreviousEndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	report.Revenue = section(category.CodeRevenue, "Pendapatan", current, previous, true)
+	report.Expense = section(category.CodeExpense, "Beban", current, previous, true)
+	report.NetIncome = round(report.Revenue.Total - report.Expense.Total)
+	report.PreviousNetIncome = round(*report.Revenue.Previous - *report.Expense.Previous)
+
+	return &report, nil
+}
+
+// BalanceSheet lists assets, liabilities and equity as of the date.
+func (s *StatementService) BalanceSheet(query BalanceSheetQuery) (*BalanceSheet, error) {
+	date := query.Date
+	if date == "" {
+		date = time.Now().Format(dateFormat)
+	}
+
+	balances, err := s.balances(query.Company, query.Outlet, "", date)
+	if err != nil {
+		return nil, err
+	}
+
+	report := BalanceSheet{
+		Date:      date,
+		Asset:     section(category.CodeAsset, "Aset", balances, nil, false),
+		Liability: section(category.CodeLiability, "Liabilitas", balances, nil, false),
+		Equity:    section(category.CodeEquity, "Ekuitas", balances, nil, false),
+	}
+
+	revenue := section(category.CodeRevenue, "", balances, nil, false)
+	expense := section(category.CodeExpense, "", balances, nil, false)
+	report.CurrentEarnings = round(revenue.Total - expense.Total)
+
+	if report.CurrentEarnings != 0 {
+		report.Equity.Lines = append(report.Equity.Lines, Line{
+			Name:   "Laba Tahun Berjalan",
+			Amount: report.CurrentEarnings,
+		})
+		report.Equity.Total = round(report.Equity.Total + report.CurrentEarnings)
+	}
+
+	report.LiabilityAndEquity = round(report.Liability.Total + report.Equity.Total)
+	report.Balanced = report.Asset.Total == report.LiabilityAndEquity
+
+	return &report, nil
+}
+
+// balances sums the journal lines of a company per account between two
+// dates, either of which may be empty.
+func (s *StatementService) balances(company uint, outlet uint, start string, end string) ([]balance, error) {
+	var balances []balance
+
+	db := s.db.Table("journal_lines").
+		Select("accounts.id AS account_id, accounts.code, accounts.name, accounts.normal, "+
+			"account_categories.code AS category_code, account_categories.name AS category_name, account_categories.normal AS category_normal, "+
+			"SUM(journal_lines.debit) AS debit, SUM(journal_lines.credit) AS credit").
+		Joins("INNER JOIN journal_entries ON journal_entries.id = journal_lines.entry_id").
+		Joins("INNER JOIN accounts ON accounts.id = journal_lines.account_id").
+		Joins("INNER JOIN account_categories ON account_categories.id = accounts.category_id").
+		Where("journal_entries.company_id = ?", company).
+		Group("accounts.id, accounts.code, accounts.name, accounts.normal, account_categories.code, account_categories.name, account_categories.normal").
+		Order("account_categories.code ASC, accounts.code ASC")
+
+	if outlet != 0 {
+		db.Where("journal_entries.outlet_id = ?", outlet)
+	}
+
+	if start != "" {
+		db.Where("DATE(journal_entries.date) >= ?", start)
+	}
+
+	if end != "" {
+		db.Where("DATE(journal_entries.date) <= ?", end)
+	}
+
+	if err := db.Find(&balances).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return balances, nil
+}
+
+// section groups the accounts of a category. The name of the category in the
+// chart is used when any of its accounts has a balance.
+func section(code string, name string, current []balance, previous []balance, compare bool) Section {
+	result := Section{
+		Code:  code,
+		Name:  name,
+		Lines: []Line{},
+	}
+
+	index := map[uint]int{}
+	add := func(v balance) int {
+		if i, ok := index[v.AccountID]; ok {
+			return i
+		}
+
+		line := Line{Code: v.Code, Name: v.Name}
+		if compare {
+			line.Previous = new(float64)
+		}
+
+		result.Name = v.CategoryName
+		result.Lines = append(result.Lines, line)
+		index[v.AccountID] = len(result.Lines) - 1
+
+		return len(result.Lines) - 1
+	}
+
+	for _, v := range current {
+		if v.CategoryCode != code {
+			continue
+		}
+
+		i := add(v)
+		result.Lines[i].Amount = v.Amount()
+		result.Total = round(result.Total + result.Lines[i].Amount)
+	}
+
+	if compare {
+		result.Previous = new(float64)
+	}
+
+	for _, v := range previous {
+		if v.CategoryCode != code {
+			continue
+		}
+
+		i := add(v)
+		*result.Lines[i].Previous = v.Amount()
+		*result.Previous = round(*result.Previous + v.Amount())
+	}
+
+	sort.SliceStable(result.Lines, func(i, j int) bool {
+		return result.Lines[i].Code < result.Lines[j].Code
+	})
+
+	return result
+}
+
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+func (s *StatementService) Using(tx *gorm.DB) *StatementService {
+	return &StatementService{tx}
+}
+
+func (s *StatementService) WithContext(ctx context.Context) *StatementService {
+	return &StatementService{s.db.WithContext(ctx)}
+}
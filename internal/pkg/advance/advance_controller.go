@@ -0,0 +1,188 @@
+package advance
+
+import (
+	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/auth"
+	"abude-backend/internal/pkg/transition"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AdvanceController struct {
+	*common.BaseController
+	advance *AdvanceService
+}
+
+func NewController(ctrl *common.BaseController, advance *AdvanceService) *AdvanceController {
+	return &AdvanceController{ctrl, advance}
+}
+
+// @Summary Get One Advance
+// @Tags Advances
+// @Accept json
+// @Produce json
+// @Param id path string true "Advance ID"
+// @Success 200 {object} Advance{}
+// @Security JWT
+// @Router /api/advance/{id} [get]
+func (ctrl *AdvanceController) One(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	advance, err := ctrl.advance.FindOne(id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(advance)
+}
+
+// @Summary Get All Advance
+// @Tags Advances
+// @Accept json
+// @Produce json
+// @Param query query AdvanceQuery false "query"
+// @Success 200 {object} common.PaginatedResponse{result=[]Advance}
+// @Security JWT
+// @Router /api/advance [get]
+func (ctrl *AdvanceController) All(ctx *fiber.Ctx) error {
+	var query AdvanceQuery
+	if err := ctrl.Validation.Query(&query, ctx); err != nil {
+		return err
+	}
+
+	result := ctrl.advance.FindAll(query)
+
+	return ctx.Status(fiber.StatusOK).JSON(result)
+}
+
+// @Summary Create Advance
+// @Tags Advances
+// @Accept json
+// @Produce json
+// @Param request body AdvanceDTO true "Request Body"
+// @Success 201 {object} common.GeneralResponse{result=Advance}
+// @Security JWT
+// @Router /api/advance [post]
+func (ctrl *AdvanceController) Create(ctx *fiber.Ctx) error {
+	var data AdvanceDTO
+	if err := ctrl.Validation.Body(&data, ctx); err != nil {
+		return err
+	}
+
+	advance, err := ctrl.advance.Create(data)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(common.GeneralResponse{
+		Message: "Kasbon berhasil dibuat",
+		Result:  advance,
+	})
+}
+
+// @Summary Cancel Advance
+// @Tags Advances
+// @Accept json
+// @Produce json
+// @Param id path string true "Advance ID"
+// @Param request body transition.ReasonDTO false "Request Body"
+// @Success 200 {object} common.BasicResponse{}
+// @Security JWT
+// @Router /api/advance/{id}/cancel [patch]
+func (ctrl *AdvanceController) Cancel(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	reason, err := transition.Reason(ctrl.Validation, ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := ctrl.advance.Cancel(id, auth.GetCreds(ctx.Context()), reason); err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.BasicResponse{
+		Message: "Kasbon berhasil dibatalkan",
+	})
+}
+
+// @Summary Repay Advance
+// @Tags Advances
+// @Accept json
+// @Produce json
+// @Param id path string true "Advance ID"
+// @Param request body RepaymentDTO true "Request Body"
+// @Success 201 {object} common.GeneralResponse{result=Repayment}
+// @Security JWT
+// @Router /api/advance/{id}/repayment [post]
+func (ctrl *AdvanceController) Repay(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	var data RepaymentDTO
+	if err := ctrl.Validation.Body(&data, ctx); err != nil {
+		return err
+	}
+
+	repayment, err := ctrl.advance.Repay(id, data)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(common.GeneralResponse{
+		Message: "Pembayaran kasbon berhasil dibuat",
+		Result:  repayment,
+	})
+}
+
+// @Summary Get Advance Balance per Employee
+// @Tags Advances
+// @Accept json
+// @Produce json
+// @Param query query BalanceQuery false "query"
+// @Success 200 {object} []EmployeeBalance
+// @Security JWT
+// @Router /api/advance/balance [get]
+func (ctrl *AdvanceController) GetBalances(ctx *fiber.Ctx) error {
+	var query BalanceQuery
+	if err := ctrl.Validation.Query(&query, ctx); err != nil {
+		return err
+	}
+
+	result, err := ctrl.advance.GetBalances(query)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(result)
+}
+
+// @Summary Get Advance Balance per Outlet
+// @Tags Advances
+// @Accept json
+// @Produce json
+// @Param query query BalanceQuery false "query"
+// @Success 200 {object} []OutletBalance
+// @Security JWT
+// @Router /api/advance/balance/outlet [get]
+func (ctrl *AdvanceController) GetOutletBalances(ctx *fiber.Ctx) error {
+	var query BalanceQuery
+	if err := ctrl.Validation.Query(&query, ctx); err != nil {
+		return err
+	}
+
+	result, err := ctrl.advance.GetOutletBalances(query)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(result)
+}
@@ -0,0 +1,39 @@
+package advance
+
+import (
+	"abude-backend/pkg/pagination"
+	"time"
+)
+
+type AdvanceDTO struct {
+	Amount      float64   `json:"amount" form:"amount" validate:"required,gt=0"`
+	Installment float64   `json:"installment" form:"installment" validate:"min=0"`
+	Source      string    `json:"source" form:"source" validate:"required,oneof=outlet company" enums:"outlet,company"`
+	Date        time.Time `json:"date" form:"date" validate:"omitempty"`
+	Notes       string    `json:"notes" form:"notes" validate:"omitempty"`
+	Employee    uint      `json:"employee" form:"employee" validate:"required,exist=employees"`
+	Company     uint      `json:"company" form:"company" validate:"required,exist=companies"`
+	Outlet      *uint     `json:"outlet" form:"outlet" validate:"required_if=Source outlet,omitempty,exist=outlets"`
+}
+
+type AdvanceQuery struct {
+	pagination.Pagination
+	Status   []string `query:"status" enums:"accepted,approved,canceled"`
+	Employee uint     `query:"employee"`
+	Company  uint     `query:"company"`
+	Outlet   uint     `query:"outlet"`
+	Open     bool     `query:"open"` // Only advances that are not fully repaid
+}
+
+type RepaymentDTO struct {
+	Amount float64   `json:"amount" form:"amount" validate:"required,gt=0"`
+	Method string    `json:"method" form:"method" validate:"required,oneof=cash transfer" enums:"cash,transfer"`
+	Date   time.Time `json:"date" form:"date" validate:"omitempty"`
+	Notes  string    `json:"notes" form:"notes" validate:"omitempty"`
+}
+
+type BalanceQuery struct {
+	Company  uint `query:"company" validate:"required"`
+	Outlet   uint `query:"outlet"`
+	Employee uint `query:"employee"`
+}
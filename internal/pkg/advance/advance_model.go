@@ -0,0 +1,186 @@
+package advance
+
+import (
+	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/accounts/journal"
+	"abude-backend/internal/pkg/company"
+	"abude-backend/internal/pkg/employee"
+	"abude-backend/internal/pkg/outlet"
+	"abude-backend/internal/pkg/sequence"
+	"abude-backend/internal/pkg/user"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	StatusAccepted = "accepted"
+	StatusApproved = "approved" // Swept into a handover
+	StatusCanceled = "canceled"
+)
+
+const (
+	SourceOutlet  = "outlet"  // Paid from the outlet's cash drawer
+	SourceCompany = "company" // Paid by the company, outside of any outlet
+)
+
+const (
+	MethodCash      = "cash"
+	MethodTransfer  = "transfer"
+	MethodDeduction = "deduction" // Deducted from a wage or payroll run
+)
+
+// Advance is cash lent to an employee against their salary (kasbon). It is
+// repaid in installments deducted from the following wages, or in cash.
+type Advance struct {
+	common.BaseModel
+	user.WithEditor
+	Code        string    `json:"code" gorm:"type:varchar(50)"`
+	Amount      float64   `json:"amount"`
+	Repaid      float64   `json:"repaid"`
+	Installment float64   `json:"installment"` // Deducted per wage, 0 deducts everything at once
+	Source      string    `json:"source" gorm:"type:enum('outlet','company')" enums:"outlet,company"`
+	Status      string    `json:"status" gorm:"type:enum('accepted','approved','canceled')" enums:"accepted,approved,canceled"`
+	Date        time.Time `json:"date"`
+	Notes       string    `json:"notes"`
+
+	Repayments []Repayment `json:"repayments,omitempty" gorm:"constraint:OnDelete:CASCADE;"`
+
+	Employee   *employee.Employee `json:"employee,omitempty" gorm:"constraint:OnDelete:RESTRICT;"`
+	EmployeeID uint               `json:"-"`
+
+	Company   *company.Company `json:"company,omitempty" gorm:"constraint:OnDelete:CASCADE;"`
+	CompanyID uint             `json:"-"`
+
+	Outlet   *outlet.Outlet `json:"outlet,omitempty" gorm:"constraint:OnDelete:SET NULL;"`
+	OutletID *uint          `json:"-"`
+}
+
+func (Advance) TableName() string {
+	return "employee_advances"
+}
+
+func (advance *Advance) BeforeCreate(tx *gorm.DB) error {
+	if advance.Code != "" {
+		return nil
+	}
+
+	var outlet uint
+	if advance.OutletID != nil {
+		outlet = *advance.OutletID
+	}
+
+	code, err := sequence.Next(tx, sequence.TypeAdvance, advance.CompanyID, outlet, time.Now())
+	if err != nil {
+		return err
+	}
+
+	advance.Code = code
+
+	return nil
+}
+
+// Outstanding returns the amount still owed by the employee.
+func (advance *Advance) Outstanding() float64 {
+	return advance.Amount - advance.Repaid
+}
+
+// Due returns the amount to deduct from the next wage.
+func (advance *Advance) Due() float64 {
+	outstanding := advance.Outstanding()
+	if advance.Installment > 0 && advance.Installment < outstanding {
+		return advance.Installment
+	}
+
+	return outstanding
+}
+
+// Schedule returns the planned installments of the outstanding amount.
+func (advance *Advance) Schedule() []float64 {
+	schedule := []float64{}
+	if advance.Status == StatusCanceled {
+		return schedule
+	}
+
+	outstanding := advance.Outstanding()
+	for outstanding > 0 {
+		due := outstanding
+		if advance.Installment > 0 && advance.Installment < due {
+			due = advance.Installment
+		}
+
+		schedule = append(schedule, due)
+		outstanding -= due
+	}
+
+	return schedule
+}
+
+// posting books the cash lent to the employee.
+func (advance *Advance) posting() journal.Posting {
+	return journal.Posting{
+		Company:     advance.CompanyID,
+		Outlet:      advance.OutletID,
+		User:        advance.EditorID,
+		Date:        advance.Date,
+		Source:      journal.SourceAdvance,
+		SourceID:    advance.ID,
+		Description: "Kasbon " + advance.Code,
+		Lines: []journal.PostingLine{
+			{Key: journal.KeyEmployeeAdvance, Debit: advance.Amount},
+			{Key: journal.KeyCash, Credit: advance.Amount},
+		},
+	}
+}
+
+// Repayment is a payment towards an advance. Deductions keep the wage they
+// were taken from; WageID has no relation to avoid an import cycle with the
+// wage package.
+type Repayment struct {
+	common.BaseModel
+	Amount float64   `json:"amount"`
+	Method string    `json:"method" gorm:"type:enum('cash','transfer','deduction')" enums:"cash,transfer,deduction"`
+	Date   time.Time `json:"date"`
+	Notes  string    `json:"notes"`
+	WageID *uint     `json:"wage"`
+
+	Advance   *Advance `json:"-" gorm:"constraint:OnDelete:CASCADE;"`
+	AdvanceID uint     `json:"-"`
+}
+
+func (Repayment) TableName() string {
+	return "employee_advance_repayments"
+}
+
+// posting books the cash received for an advance. Deductions are posted by
+// the wage they were taken from.
+func (repayment *Repayment) posting(advance *Advance) journal.Posting {
+	return journal.Posting{
+		Company:     advance.CompanyID,
+		Outlet:      advance.OutletID,
+		Date:        repayment.Date,
+		Source:      journal.SourceRepayment,
+		SourceID:    repayment.ID,
+		Description: "Pembayaran kasbon " + advance.Code,
+		Lines: []journal.PostingLine{
+			{Key: journal.KeyCash, Debit: repayment.Amount},
+			{Key: journal.KeyEmployeeAdvance, Credit: repayment.Amount},
+		},
+	}
+}
+
+// EmployeeBalance is the outstanding advances of a single employee.
+type EmployeeBalance struct {
+	Employee    employee.Employee `json:"employee" gorm:"embedded"`
+	Amount      float64           `json:"amount"`
+	Repaid      float64           `json:"repaid"`
+	Outstanding float64           `json:"outstanding"`
+}
+
+// OutletBalance is the outstanding advances paid from a single outlet.
+type OutletBalance struct {
+	Outlet      outlet.Outlet `json:"outlet" gorm:"embedded"`
+	Amount      float64       `json:"amount"`
+	Repaid      float64       `json:"repaid"`
+	Outstanding float64       `json:"outstanding"`
+}
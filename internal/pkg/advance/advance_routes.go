@@ -0,0 +1,18 @@
+package advance
+
+import (
+	"abude-backend/internal/common"
+)
+
+func LoadRoutes(r *common.Router) {
+	advanceService := NewService(r.DB)
+
+	advanceHandler := NewController(r.Controller, advanceService)
+	r.Router.Get("/advance", r.Auth(1), advanceHandler.All)
+	r.Router.Get("/advance/balance", r.Auth(2), advanceHandler.GetBalances)
+	r.Router.Get("/advance/balance/outlet", r.Auth(2), advanceHandler.GetOutletBalances)
+	r.Router.Get("/advance/:id", r.Auth(1), advanceHandler.One)
+	r.Router.Post("/advance", r.Auth(1), advanceHandler.Create)
+	r.Router.Patch("/advance/:id/cancel", r.Auth(2), advanceHandler.Cancel)
+	r.Router.Post("/advance/:id/repayment", r.Auth(1), advanceHandler.Repay)
+}
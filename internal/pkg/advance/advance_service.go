@@ -0,0 +1,341 @@
+package advance
+
+import (
+	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/accounts/journal"
+	"abude-backend/internal/pkg/transition"
+	"abude-backend/pkg/exception"
+	"abude-backend/pkg/pagination"
+	"context"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AdvanceService struct {
+	db *gorm.DB
+}
+
+func NewService(db *gorm.DB) *AdvanceService {
+	return &AdvanceService{db}
+}
+
+func (s *AdvanceService) FindOne(id int) (*Advance, error) {
+	var advance Advance
+	if err := s.db.
+		Preload("Employee").Preload("Company").Preload("Outlet").Preload("Editor").
+		Preload("Repayments", func(db *gorm.DB) *gorm.DB {
+			return db.Order("date ASC")
+		}).
+		First(&advance, id).Error; err != nil {
+		return nil, exception.DB(err, "Kasbon")
+	}
+
+	return &advance, nil
+}
+
+func (s *AdvanceService) FindAll(query AdvanceQuery) *pagination.Result[Advance] {
+	result := pagination.New[Advance](query.Pagination)
+
+	db := s.db.Model(&Advance{}).Preload("Employee").Preload("Outlet")
+	if len(query.Status) > 0 {
+		db.Where("status IN (?)", query.Status)
+	}
+
+	if query.Employee != 0 {
+		db.Where("employee_id = ?", query.Employee)
+	}
+
+	if query.Company != 0 {
+		db.Where("company_id = ?", query.Company)
+	}
+
+	if query.Outlet != 0 {
+		db.Where("outlet_id = ?", query.Outlet)
+	}
+
+	if query.Open {
+		db.Where("status != ? AND amount > repaid", StatusCanceled)
+	}
+
+	db.Order("date DESC")
+
+	return result.Paginate(db)
+}
+
+func (s *AdvanceService) Create(data AdvanceDTO) (*Advance, error) {
+	advance := Advance{
+		Amount:      data.Amount,
+		Installment: data.Installment,
+		Source:      data.Source,
+		Status:      StatusAccepted,
+		Date:        time.Now(),
+		Notes:       data.Notes,
+		EmployeeID:  data.Employee,
+		CompanyID:   data.Company,
+		OutletID:    data.Outlet,
+	}
+
+	if !data.Date.IsZero() {
+		advance.Date = data.Date
+	}
+
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&advance).Error; err != nil {
+			return err
+		}
+
+		return journal.Post(tx, advance.posting())
+	}); err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &advance, nil
+}
+
+// Cancel voids an advance that nothing was repaid on yet.
+func (s *AdvanceService) Cancel(id int, creds *common.Creds, reason string) error {
+	return exception.DB(s.db.Transaction(func(tx *gorm.DB) error {
+		var advance Advance
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&advance, id).Error; err != nil {
+			return exception.DB(err, "Kasbon")
+		}
+
+		if advance.Repaid > 0 {
+			return exception.BadRequest("Kasbon yang telah dibayar tidak dapat dibatalkan")
+		}
+
+		if err := transition.Apply(tx, transition.Change{
+			Type:   transition.TypeAdvance,
+			ID:     advance.ID,
+			From:   advance.Status,
+			To:     StatusCanceled,
+			Creds:  creds,
+			Reason: reason,
+		}); err != nil {
+			return err
+		}
+
+		if err := tx.Model(&advance).Update("status", StatusCanceled).Error; err != nil {
+			return err
+		}
+
+		return journal.Reverse(tx, journal.SourceAdvance, advance.ID, time.Now())
+	}))
+}
+
+// Repay records a cash or transfer repayment of an advance.
+func (s *AdvanceService) Repay(id int, data RepaymentDTO) (*Repayment, error) {
+	repayment := Repayment{
+		Amount:    data.Amount,
+		Method:    data.Method,
+		Date:      time.Now(),
+		Notes:     data.Notes,
+		AdvanceID: uint(id),
+	}
+
+	if !data.Date.IsZero() {
+		repayment.Date = data.Date
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var advance Advance
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&advance, id).Error; err != nil {
+			return exception.DB(err, "Kasbon")
+		}
+
+		if advance.Status == StatusCanceled {
+			return exception.BadRequest("Kasbon telah dibatalkan")
+		}
+
+		if data.Amount > advance.Outstanding() {
+			return exception.Validation(map[string]string{
+				"amount": "Jumlah melebihi sisa kasbon",
+			})
+		}
+
+		if err := tx.Create(&repayment).Error; err != nil {
+			return err
+		}
+
+		if err := journal.Post(tx, repayment.posting(&advance)); err != nil {
+			return err
+		}
+
+		return tx.Model(&advance).Update("repaid", gorm.Expr("repaid + ?", data.Amount)).Error
+	})
+	if err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &repayment, nil
+}
+
+func (s *AdvanceService) GetBalances(query BalanceQuery) ([]EmployeeBalance, error) {
+	var balances []EmployeeBalance
+
+	db := s.open().
+		Select("employees.*, SUM(employee_advances.amount) AS amount, SUM(employee_advances.repaid) AS repaid, SUM(employee_advances.amount - employee_advances.repaid) AS outstanding").
+		Joins("INNER JOIN employees ON employees.id = employee_advances.employee_id").
+		Where("employee_advances.company_id = ?", query.Company).
+		Group("employee_advances.employee_id").
+		Order("outstanding DESC")
+
+	if query.Outlet != 0 {
+		db.Where("employee_advances.outlet_id = ?", query.Outlet)
+	}
+
+	if query.Employee != 0 {
+		db.Where("employee_advances.employee_id = ?", query.Employee)
+	}
+
+	if err := db.Find(&balances).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return balances, nil
+}
+
+func (s *AdvanceService) GetOutletBalances(query BalanceQuery) ([]OutletBalance, error) {
+	var balances []OutletBalance
+
+	db := s.open().
+		Select("outlets.*, SUM(employee_advances.amount) AS amount, SUM(employee_advances.repaid) AS repaid, SUM(employee_advances.amount - employee_advances.repaid) AS outstanding").
+		Joins("INNER JOIN outlets ON outlets.id = employee_advances.outlet_id").
+		Where("employee_advances.company_id = ?", query.Company).
+		Group("employee_advances.outlet_id").
+		Order("outstanding DESC")
+
+	if query.Outlet != 0 {
+		db.Where("employee_advances.outlet_id = ?", query.Outlet)
+	}
+
+	if query.Employee != 0 {
+		db.Where("employee_advances.employee_id = ?", query.Employee)
+	}
+
+	if err := db.Find(&balances).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return balances, nil
+}
+
+func (s *AdvanceService) open() *gorm.DB {
+	return s.db.Table("employee_advances").
+		Where("employee_advances.status != ? AND employee_advances.amount > employee_advances.repaid", StatusCanceled)
+}
+
+// Due returns the installments of an employee's open advances that are due
+// on their next wage.
+func Due(tx *gorm.DB, employee uint, company uint) (float64, error) {
+	advances, err := load(tx, employee, company, false)
+	if err != nil {
+		return 0, err
+	}
+
+	var due float64
+	for _, advance := range advances {
+		due += advance.Due()
+	}
+
+	return due, nil
+}
+
+// Deduct repays the due installments of an employee's open advances from a
+// wage, oldest first, up to max. It returns the amount deducted.
+func Deduct(tx *gorm.DB, employee uint, company uint, max float64, wage uint, date time.Time) (float64, error) {
+	advances, err := load(tx, employee, company, true)
+	if err != nil {
+		return 0, err
+	}
+
+	var deducted float64
+	for _, advance := range advances {
+		if deducted >= max {
+			break
+		}
+
+		// Nothing is due yet, a later advance may still be
+		amount := math.Min(advance.Due(), max-deducted)
+		if amount <= 0 {
+			continue
+		}
+
+		repayment := Repayment{
+			Amount:    amount,
+			Method:    MethodDeduction,
+			Date:      date,
+			WageID:    &wage,
+			AdvanceID: advance.ID,
+		}
+
+		if err := tx.Create(&repayment).Error; err != nil {
+			return 0, err
+		}
+
+		if err := tx.Model(&advance).Update("repaid", gorm.Expr("repaid + ?", amount)).Error; err != nil {
+			return 0, err
+		}
+
+		deducted += amount
+	}
+
+	return deducted, nil
+}
+
+// Reverse undoes the deductions taken from a wage, as when it is canceled.
+func Reverse(tx *gorm.DB, wage uint) error {
+	var repayments []Repayment
+	if err := tx.Where("wage_id = ?", wage).Find(&repayments).Error; err != nil {
+		return err
+	}
+
+	for _, repayment := range repayments {
+		if err := tx.Model(&Advance{}).
+			Where("id = ?", repayment.AdvanceID).
+			Update("repaid", gorm.Expr("repaid - ?", repayment.Amount)).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&repayment).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func load(tx *gorm.DB, employee uint, company uint, lock bool) ([]Advance, error) {
+	db := tx.Where("employee_id = ? AND company_id = ? AND status != ? AND amount > repaid", employee, company, StatusCanceled)
+	if lock {
+		db = db.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+
+	var advances []Advance
+	if err := db.Order("date ASC, id ASC").Find(&advances).Error; err != nil {
+		return nil, err
+	}
+
+	return advances, nil
+}
+
+func (s *AdvanceService) Using(tx *gorm.DB) *AdvanceService {
+	db := s.db
+
+	defer func() {
+		s.db = db
+	}()
+
+	s.db = tx.WithContext(tx.Statement.Context)
+
+	return s
+}
+
+func (s *AdvanceService) WithContext(ctx context.Context) *AdvanceService {
+	s.db = s.db.WithContext(ctx)
+
+	return s
+}
@@ -0,0 +1,105 @@
+package attachment
+
+import (
+	"abude-backend/internal/common"
+	"abude-backend/pkg/exception"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AttachmentController struct {
+	*common.BaseController
+	attachment *AttachmentService
+}
+
+func NewController(ctrl *common.BaseController, attachment *AttachmentService) *AttachmentController {
+	return &AttachmentController{ctrl, attachment}
+}
+
+// @Summary Get Document Attachments
+// @Tags Attachments
+// @Accept json
+// @Produce json
+// @Param type path string true "Document Type" Enums(expense, purchase, wage)
+// @Param id path string true "Document ID"
+// @Success 200 {object} []file.File
+// @Security JWT
+// @Router /api/attachment/{type}/{id} [get]
+func (ctrl *AttachmentController) All(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	files, err := ctrl.attachment.FindAll(ctx.Params("type"), id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(files)
+}
+
+// @Summary Upload Document Attachments
+// @Description Accepts one or more files in the "file" field. Only JPEG, PNG, WebP and PDF files are allowed.
+// @Tags Attachments
+// @Accept mpfd
+// @Produce json
+// @Param type path string true "Document Type" Enums(expense, purchase, wage)
+// @Param id path string true "Document ID"
+// @Param file formData file true "File"
+// @Success 201 {object} common.GeneralResponse{result=[]file.File}
+// @Security JWT
+// @Router /api/attachment/{type}/{id} [post]
+func (ctrl *AttachmentController) Create(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	form, err := ctx.MultipartForm()
+	if err != nil {
+		return exception.BadRequest("Form tidak valid")
+	}
+
+	files, err := ctrl.attachment.Attach(ctx.Params("type"), id, form.File["file"])
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(common.GeneralResponse{
+		Message: "Lampiran berhasil diunggah",
+		Result:  files,
+	})
+}
+
+// @Summary Delete Document Attachment
+// @Tags Attachments
+// @Accept json
+// @Produce json
+// @Param type path string true "Document Type" Enums(expense, purchase, wage)
+// @Param id path string true "Document ID"
+// @Param file path string true "File ID"
+// @Success 200 {object} common.GeneralResponse{result=file.File}
+// @Security JWT
+// @Router /api/attachment/{type}/{id}/{file} [delete]
+func (ctrl *AttachmentController) Delete(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	fileId, err := ctrl.Validation.ParamsInt(ctx, "file")
+	if err != nil {
+		return err
+	}
+
+	file, err := ctrl.attachment.Detach(ctx.Params("type"), id, fileId)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.GeneralResponse{
+		Message: "Lampiran berhasil dihapus",
+		Result:  file,
+	})
+}
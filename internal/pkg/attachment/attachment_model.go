@@ -0,0 +1,30 @@
+package attachment
+
+const (
+	OwnerExpense  = "expense"
+	OwnerPurchase = "purchase"
+	OwnerWage     = "wage"
+)
+
+// owner describes the documents files can be attached to and the join
+// table that links them.
+type owner struct {
+	Name   string
+	Table  string
+	Join   string
+	Column string
+}
+
+var owners = map[string]owner{
+	OwnerExpense:  {"Pengeluaran", "expenses", "expense_attachments", "expense_id"},
+	OwnerPurchase: {"Pembelian", "purchases", "purchase_attachments", "purchase_id"},
+	OwnerWage:     {"Gaji", "wages", "wage_attachments", "wage_id"},
+}
+
+// Allowed types of uploaded files, detected from their content.
+var mimeTypes = map[string]bool{
+	"image/jpeg":      true,
+	"image/png":       true,
+	"image/webp":      true,
+	"application/pdf": true,
+}
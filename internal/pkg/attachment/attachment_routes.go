@@ -0,0 +1,14 @@
+package attachment
+
+import (
+	"abude-backend/internal/common"
+)
+
+func LoadRoutes(r *common.Router) {
+	attachmentService := NewService(r.DB, r.Config)
+
+	attachmentHandler := NewController(r.Controller, attachmentService)
+	r.Router.Get("/attachment/:type/:id", r.Auth(1), attachmentHandler.All)
+	r.Router.Post("/attachment/:type/:id", r.Auth(1), attachmentHandler.Create)
+	r.Router.Delete("/attachment/:type/:id/:file", r.Auth(1), attachmentHandler.Delete)
+}
@@ -0,0 +1,175 @@
+package attachment
+
+import (
+	"abude-backend/internal/config"
+	"abude-backend/pkg/exception"
+	"abude-backend/pkg/file"
+	"context"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+type AttachmentService struct {
+	db    *gorm.DB
+	files *file.FileService
+	limit int64
+}
+
+func NewService(db *gorm.DB, config *config.ServerConfig) *AttachmentService {
+	files := file.NewService(db, file.FileServiceConfig{
+		Url:        config.Url,
+		UrlPath:    "uploads",
+		UploadPath: config.UploadPath,
+	})
+
+	return &AttachmentService{db, files, int64(config.UploadLimit)}
+}
+
+func (s *AttachmentService) FindAll(kind string, id int) ([]file.File, error) {
+	owner, err := s.owner(kind, id)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []file.File{}
+	if err := s.db.Model(&file.File{}).
+		Joins(fmt.Sprintf("INNER JOIN %s ON %s.file_id = files.id", owner.Join, owner.Join)).
+		Where(fmt.Sprintf("%s.%s = ?", owner.Join, owner.Column), id).
+		Order("files.created_at ASC").
+		Find(&files).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return files, nil
+}
+
+// Attach validates and stores the uploaded files, then links them to the
+// document. Nothing is stored when any of the files is rejected.
+func (s *AttachmentService) Attach(kind string, id int, headers []*multipart.FileHeader) ([]file.File, error) {
+	owner, err := s.owner(kind, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(headers) == 0 {
+		return nil, exception.Validation(map[string]string{
+			"file": "File wajib diisi",
+		})
+	}
+
+	for _, fh := range headers {
+		if err := s.check(fh); err != nil {
+			return nil, err
+		}
+	}
+
+	var files []file.File
+	for _, fh := range headers {
+		saved, err := s.files.Save(fh)
+		if err != nil {
+			s.Remove(files)
+			return nil, err
+		}
+
+		files = append(files, *saved)
+
+		if err := s.db.Table(owner.Join).Create(map[string]interface{}{
+			owner.Column: id,
+			"file_id":    saved.ID,
+		}).Error; err != nil {
+			s.Remove(files)
+			return nil, exception.DB(err)
+		}
+	}
+
+	return files, nil
+}
+
+// Detach unlinks a file from the document and deletes it.
+func (s *AttachmentService) Detach(kind string, id int, fileId int) (*file.File, error) {
+	owner, err := s.owner(kind, id)
+	if err != nil {
+		return nil, err
+	}
+
+	result := s.db.Table(owner.Join).Where(fmt.Sprintf("%s = ? AND file_id = ?", owner.Column), id, fileId).Delete(nil)
+	if result.Error != nil {
+		return nil, exception.DB(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, exception.NotFound("Lampiran")
+	}
+
+	return s.files.Delete(uint(fileId))
+}
+
+func (s *AttachmentService) owner(kind string, id int) (*owner, error) {
+	owner, ok := owners[kind]
+	if !ok {
+		return nil, exception.NotFound("Jenis dokumen")
+	}
+
+	var count int64
+	if err := s.db.Table(owner.Table).Where("id = ?", id).Count(&count).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	if count == 0 {
+		return nil, exception.NotFound(owner.Name)
+	}
+
+	return &owner, nil
+}
+
+// check refuses files over the upload limit or of a type that is not
+// allowed. The type is detected from the content, not the file name.
+func (s *AttachmentService) check(fh *multipart.FileHeader) error {
+	if s.limit > 0 && fh.Size > s.limit {
+		return exception.Http(fiber.StatusRequestEntityTooLarge, fmt.Sprintf("Ukuran file %s melebihi batas %d MB", fh.Filename, s.limit/1024/1024))
+	}
+
+	f, err := fh.Open()
+	if err != nil {
+		return exception.BadRequest("Gagal membaca file")
+	}
+	defer f.Close()
+
+	buffer := make([]byte, 512)
+	n, _ := f.Read(buffer)
+
+	if mime := http.DetectContentType(buffer[:n]); !mimeTypes[mime] {
+		return exception.Http(fiber.StatusUnsupportedMediaType, fmt.Sprintf("Tipe file %s tidak didukung", fh.Filename))
+	}
+
+	return nil
+}
+
+// Remove deletes the files, such as those of a document being purged.
+func (s *AttachmentService) Remove(files []file.File) {
+	for _, f := range files {
+		s.files.Delete(f.ID)
+	}
+}
+
+func (s *AttachmentService) Using(tx *gorm.DB) *AttachmentService {
+	db := s.db
+
+	defer func() {
+		s.db = db
+	}()
+
+	s.db = tx.WithContext(tx.Statement.Context)
+
+	return s
+}
+
+func (s *AttachmentService) WithContext(ctx context.Context) *AttachmentService {
+	s.db = s.db.WithContext(ctx)
+
+	return s
+}
@@ -0,0 +1,138 @@
+package attendance
+
+import (
+	"abude-backend/internal/common"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AttendanceController struct {
+	*common.BaseController
+	attendance *AttendanceService
+}
+
+func NewController(ctrl *common.BaseController, attendance *AttendanceService) *AttendanceController {
+	return &AttendanceController{ctrl, attendance}
+}
+
+// @Summary Get One Attendance
+// @Tags Attendances
+// @Accept json
+// @Produce json
+// @Param id path string true "Attendance ID"
+// @Success 200 {object} Attendance{}
+// @Security JWT
+// @Router /api/attendance/{id} [get]
+func (ctrl *AttendanceController) One(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	attendance, err := ctrl.attendance.FindOne(id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(attendance)
+}
+
+// @Summary Get All Attendance
+// @Tags Attendances
+// @Accept json
+// @Produce json
+// @Param query query AttendanceQuery false "query"
+// @Success 200 {object} common.PaginatedResponse{result=[]Attendance}
+// @Security JWT
+// @Router /api/attendance [get]
+func (ctrl *AttendanceController) All(ctx *fiber.Ctx) error {
+	var query AttendanceQuery
+	if err := ctrl.Validation.Query(&query, ctx); err != nil {
+		return err
+	}
+
+	result := ctrl.attendance.FindAll(query)
+
+	return ctx.Status(fiber.StatusOK).JSON(result)
+}
+
+// @Summary Create Attendance
+// @Tags Attendances
+// @Accept json
+// @Produce json
+// @Param request body AttendanceDTO true "Request Body"
+// @Success 201 {object} common.GeneralResponse{result=Attendance}
+// @Security JWT
+// @Router /api/attendance [post]
+func (ctrl *AttendanceController) Create(ctx *fiber.Ctx) error {
+	var data AttendanceDTO
+	if err := ctrl.Validation.Body(&data, ctx); err != nil {
+		return err
+	}
+
+	attendance, err := ctrl.attendance.Create(data)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(common.GeneralResponse{
+		Message: "Absensi berhasil dibuat",
+		Result:  attendance,
+	})
+}
+
+// @Summary Update Attendance
+// @Tags Attendances
+// @Accept json
+// @Produce json
+// @Param id path string true "Attendance ID"
+// @Param request body AttendanceDTO true "Request Body"
+// @Success 200 {object} common.GeneralResponse{result=Attendance}
+// @Security JWT
+// @Router /api/attendance/{id} [put]
+func (ctrl *AttendanceController) Update(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	var data AttendanceDTO
+	if err := ctrl.Validation.Body(&data, ctx); err != nil {
+		return err
+	}
+
+	attendance, err := ctrl.attendance.Update(id, data)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.GeneralResponse{
+		Message: "Absensi berhasil diubah",
+		Result:  attendance,
+	})
+}
+
+// @Summary Delete Attendance
+// @Tags Attendances
+// @Accept json
+// @Produce json
+// @Param id path string true "Attendance ID"
+// @Success 200 {object} common.GeneralResponse{result=Attendance}
+// @Security JWT
+// @Router /api/attendance/{id} [delete]
+func (ctrl *AttendanceController) Delete(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	attendance, err := ctrl.attendance.Delete(id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.GeneralResponse{
+		Message: "Absensi berhasil dihapus",
+		Result:  attendance,
+	})
+}
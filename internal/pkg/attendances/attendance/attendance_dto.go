@@ -0,0 +1,24 @@
+package attendance
+
+import (
+	"abude-backend/pkg/pagination"
+	"time"
+)
+
+type AttendanceDTO struct {
+	ClockIn  time.Time  `json:"clockIn" form:"clockIn" validate:"required"`
+	ClockOut *time.Time `json:"clockOut" form:"clockOut" validate:"omitempty"`
+	Notes    string     `json:"notes" form:"notes" validate:"omitempty"`
+	Employee uint       `json:"employee" form:"employee" validate:"required,exist=employees"`
+	Outlet   uint       `json:"outlet" form:"outlet" validate:"required,exist=outlets"`
+	Shift    *uint      `json:"shift" form:"shift" validate:"omitempty,exist=shifts"`
+}
+
+type AttendanceQuery struct {
+	pagination.Pagination
+	Employee  uint      `query:"employee"`
+	Outlet    uint      `query:"outlet"`
+	Shift     uint      `query:"shift"`
+	StartDate time.Time `query:"startDate" format:"date"`
+	EndDate   time.Time `query:"endDate" format:"date"`
+}
@@ -0,0 +1,53 @@
+package attendance
+
+import (
+	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/attendances/shift"
+	"abude-backend/internal/pkg/employee"
+	"abude-backend/internal/pkg/outlet"
+	"time"
+)
+
+type Attendance struct {
+	common.BaseModel
+	Date     time.Time  `json:"date" gorm:"type:date"`
+	ClockIn  time.Time  `json:"clockIn"`
+	ClockOut *time.Time `json:"clockOut"`
+	Notes    string     `json:"notes"`
+
+	Employee   *employee.Employee `json:"employee,omitempty" gorm:"constraint:OnDelete:CASCADE;"`
+	EmployeeID uint               `json:"-"`
+
+	Outlet   *outlet.Outlet `json:"outlet,omitempty" gorm:"constraint:OnDelete:CASCADE;"`
+	OutletID uint           `json:"-"`
+
+	Shift   *shift.Shift `json:"shift,omitempty" gorm:"constraint:OnDelete:SET NULL;"`
+	ShiftID *uint        `json:"-"`
+}
+
+// Hours returns the worked hours, zero while the employee is still clocked in.
+func (a *Attendance) Hours() float64 {
+	if a.ClockOut == nil || a.ClockOut.Before(a.ClockIn) {
+		return 0
+	}
+
+	return a.ClockOut.Sub(a.ClockIn).Hours()
+}
+
+// Overtime returns the hours worked beyond the length of the shift.
+func (a *Attendance) Overtime() float64 {
+	if a.Shift == nil {
+		return 0
+	}
+
+	length := time.Duration(a.Shift.EndTime) - time.Duration(a.Shift.StartTime)
+	if length <= 0 {
+		length += 24 * time.Hour // Shift past midnight
+	}
+
+	if overtime := a.Hours() - length.Hours(); overtime > 0 {
+		return overtime
+	}
+
+	return 0
+}
@@ -0,0 +1,135 @@
+package attendance
+
+import (
+	"abude-backend/pkg/exception"
+	"abude-backend/pkg/pagination"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type AttendanceService struct {
+	db *gorm.DB
+}
+
+func NewService(db *gorm.DB) *AttendanceService {
+	return &AttendanceService{db}
+}
+
+func (s *AttendanceService) FindOne(id int) (*Attendance, error) {
+	var attendance Attendance
+	if err := s.db.Preload("Employee").Preload("Outlet").Preload("Shift").First(&attendance, id).Error; err != nil {
+		return nil, exception.DB(err, "Absensi")
+	}
+
+	return &attendance, nil
+}
+
+func (s *AttendanceService) FindAll(query AttendanceQuery) *pagination.Result[Attendance] {
+	result := pagination.New[Attendance](query.Pagination)
+
+	db := s.db.Model(&Attendance{}).Preload("Employee").Preload("Shift")
+	if query.Employee != 0 {
+		db.Where("employee_id = ?", query.Employee)
+	}
+
+	if query.Outlet != 0 {
+		db.Where("outlet_id = ?", query.Outlet)
+	}
+
+	if query.Shift != 0 {
+		db.Where("shift_id = ?", query.Shift)
+	}
+
+	if !query.StartDate.IsZero() {
+		db.Where("date >= ?", query.StartDate)
+	}
+
+	if !query.EndDate.IsZero() {
+		db.Where("date <= ?", query.EndDate)
+	}
+
+	db.Order("clock_in DESC")
+
+	return result.Paginate(db)
+}
+
+func (s *AttendanceService) Create(data AttendanceDTO) (*Attendance, error) {
+	var attendance Attendance
+	if err := s.fill(&attendance, data); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Create(&attendance).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &attendance, nil
+}
+
+func (s *AttendanceService) Update(id int, data AttendanceDTO) (*Attendance, error) {
+	var attendance Attendance
+	if err := s.db.First(&attendance, id).Error; err != nil {
+		return nil, exception.DB(err, "Absensi")
+	}
+
+	if err := s.fill(&attendance, data); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Save(&attendance).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &attendance, nil
+}
+
+func (s *AttendanceService) Delete(id int) (*Attendance, error) {
+	var attendance Attendance
+	if err := s.db.First(&attendance, id).Error; err != nil {
+		return nil, exception.DB(err, "Absensi")
+	}
+
+	if err := s.db.Delete(&attendance).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &attendance, nil
+}
+
+func (s *AttendanceService) fill(attendance *Attendance, data AttendanceDTO) error {
+	if data.ClockOut != nil && data.ClockOut.Before(data.ClockIn) {
+		return exception.Validation(map[string]string{
+			"clockOut": "Jam pulang tidak boleh sebelum jam masuk",
+		})
+	}
+
+	attendance.Date = time.Date(data.ClockIn.Year(), data.ClockIn.Month(), data.ClockIn.Day(), 0, 0, 0, 0, data.ClockIn.Location())
+	attendance.ClockIn = data.ClockIn
+	attendance.ClockOut = data.ClockOut
+	attendance.Notes = data.Notes
+	attendance.EmployeeID = data.Employee
+	attendance.OutletID = data.Outlet
+	attendance.ShiftID = data.Shift
+
+	return nil
+}
+
+func (s *AttendanceService) Using(tx *gorm.DB) *AttendanceService {
+	db := s.db
+
+	defer func() {
+		s.db = db
+	}()
+
+	s.db = tx.WithContext(tx.Statement.Context)
+
+	return s
+}
+
+func (s *AttendanceService) WithContext(ctx context.Context) *AttendanceService {
+	s.db = s.db.WithContext(ctx)
+
+	return s
+}
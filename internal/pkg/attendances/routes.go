@@ -2,6 +2,7 @@ package attendances
 
 import (
 	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/attendances/attendance"
 	"abude-backend/internal/pkg/attendances/shift"
 )
 
@@ -14,4 +15,13 @@ func LoadRoutes(r *common.Router) {
 	r.Router.Post("/shift", r.Auth(2), shiftHandler.Create)
 	r.Router.Put("/shift/:id", r.Auth(2), shiftHandler.Update)
 	r.Router.Delete("/shift/:id", r.Auth(2), shiftHandler.Delete)
+
+	attendanceService := attendance.NewService(r.DB)
+	attendanceHandler := attendance.NewController(r.Controller, attendanceService)
+
+	r.Router.Get("/attendance", r.Auth(1), attendanceHandler.All)
+	r.Router.Get("/attendance/:id", r.Auth(1), attendanceHandler.One)
+	r.Router.Post("/attendance", r.Auth(1), attendanceHandler.Create)
+	r.Router.Put("/attendance/:id", r.Auth(2), attendanceHandler.Update)
+	r.Router.Delete("/attendance/:id", r.Auth(2), attendanceHandler.Delete)
 }
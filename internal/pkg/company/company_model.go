@@ -3,10 +3,14 @@ package company
 import (
 	"abude-backend/internal/common"
 	"abude-backend/internal/pkg/user"
+
+	"gorm.io/gorm"
 )
 
 type Company struct {
 	common.BaseModel
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
 	Name   string `json:"name" gorm:"type:varchar(100)"`
 	Region string `json:"region" gorm:"type:varchar(100)"`
 
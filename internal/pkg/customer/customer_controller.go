@@ -0,0 +1,138 @@
+package customer
+
+import (
+	"abude-backend/internal/common"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type CustomerController struct {
+	*common.BaseController
+	customer *CustomerService
+}
+
+func NewController(ctrl *common.BaseController, customer *CustomerService) *CustomerController {
+	return &CustomerController{ctrl, customer}
+}
+
+// @Summary Get One Customer
+// @Tags Customers
+// @Accept json
+// @Produce json
+// @Param id path string true "Customer ID"
+// @Success 200 {object} Customer{}
+// @Security JWT
+// @Router /api/customer/{id} [get]
+func (ctrl *CustomerController) One(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	customer, err := ctrl.customer.FindOne(id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(customer)
+}
+
+// @Summary Get All Customer
+// @Tags Customers
+// @Accept json
+// @Produce json
+// @Param query query CustomerQuery false "query"
+// @Success 200 {object} common.PaginatedResponse{result=[]Customer}
+// @Security JWT
+// @Router /api/customer [get]
+func (ctrl *CustomerController) All(ctx *fiber.Ctx) error {
+	var query CustomerQuery
+	if err := ctrl.Validation.Query(&query, ctx); err != nil {
+		return err
+	}
+
+	result := ctrl.customer.FindAll(query)
+
+	return ctx.Status(fiber.StatusOK).JSON(result)
+}
+
+// @Summary Create Customer
+// @Tags Customers
+// @Accept json
+// @Produce json
+// @Param request body CustomerDTO true "Request Body"
+// @Success 201 {object} common.GeneralResponse{result=Customer}
+// @Security JWT
+// @Router /api/customer [post]
+func (ctrl *CustomerController) Create(ctx *fiber.Ctx) error {
+	var data CustomerDTO
+	if err := ctrl.Validation.Body(&data, ctx); err != nil {
+		return err
+	}
+
+	customer, err := ctrl.customer.Create(data)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(common.GeneralResponse{
+		Message: "Pelanggan berhasil dibuat",
+		Result:  customer,
+	})
+}
+
+// @Summary Update Customer
+// @Tags Customers
+// @Accept json
+// @Produce json
+// @Param id path string true "Customer ID"
+// @Param request body CustomerDTO true "Request Body"
+// @Success 200 {object} common.GeneralResponse{result=Customer}
+// @Security JWT
+// @Router /api/customer/{id} [put]
+func (ctrl *CustomerController) Update(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	var data CustomerDTO
+	if err := ctrl.Validation.Body(&data, ctx); err != nil {
+		return err
+	}
+
+	customer, err := ctrl.customer.Update(id, data)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.GeneralResponse{
+		Message: "Pelanggan berhasil diubah",
+		Result:  customer,
+	})
+}
+
+// @Summary Delete Customer
+// @Tags Customers
+// @Accept json
+// @Produce json
+// @Param id path string true "Customer ID"
+// @Success 200 {object} common.GeneralResponse{result=Customer}
+// @Security JWT
+// @Router /api/customer/{id} [delete]
+func (ctrl *CustomerController) Delete(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	customer, err := ctrl.customer.Delete(id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.GeneralResponse{
+		Message: "Pelanggan berhasil dihapus",
+		Result:  customer,
+	})
+}
@@ -0,0 +1,19 @@
+package customer
+
+import "abude-backend/pkg/pagination"
+
+type CustomerDTO struct {
+	Name        string  `form:"name" json:"name" validate:"required"`
+	Phone       string  `form:"phone" json:"phone" validate:"omitempty"`
+	Email       string  `form:"email" json:"email" validate:"omitempty,email"`
+	Address     string  `form:"address" json:"address" validate:"omitempty"`
+	TaxID       string  `form:"taxId" json:"taxId" validate:"omitempty"`
+	CreditLimit float64 `form:"creditLimit" json:"creditLimit" validate:"omitempty,gte=0"`
+	Company     uint    `form:"company" json:"company" validate:"required,exist=companies"`
+}
+
+type CustomerQuery struct {
+	pagination.Pagination
+	Keyword string `query:"keyword"`
+	Company uint   `query:"company"`
+}
@@ -0,0 +1,23 @@
+package customer
+
+import (
+	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/company"
+
+	"gorm.io/gorm"
+)
+
+type Customer struct {
+	common.BaseModel
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
+	Name        string  `json:"name" gorm:"type:varchar(100)"`
+	Phone       string  `json:"phone" gorm:"type:varchar(20)"`
+	Email       string  `json:"email" gorm:"type:varchar(100)"`
+	Address     string  `json:"address" gorm:"type:varchar(255)"`
+	TaxID       string  `json:"taxId" gorm:"type:varchar(30)"`
+	CreditLimit float64 `json:"creditLimit"` // 0 means no credit allowed
+
+	Company   *company.Company `json:"company,omitempty" gorm:"constraint:OnDelete:CASCADE;"`
+	CompanyID uint             `json:"-"`
+}
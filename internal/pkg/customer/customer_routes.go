@@ -0,0 +1,14 @@
+package customer
+
+import "abude-backend/internal/common"
+
+func LoadRoutes(r *common.Router) {
+	customerService := NewService(r.DB)
+	customerHandler := NewController(r.Controller, customerService)
+
+	r.Router.Get("/customer", r.Auth(0), customerHandler.All)
+	r.Router.Get("/customer/:id", r.Auth(0), customerHandler.One)
+	r.Router.Post("/customer", r.Auth(1), customerHandler.Create)
+	r.Router.Put("/customer/:id", r.Auth(1), customerHandler.Update)
+	r.Router.Delete("/customer/:id", r.Auth(1), customerHandler.Delete)
+}
@@ -0,0 +1,114 @@
+package customer
+
+import (
+	"abude-backend/pkg/exception"
+	"abude-backend/pkg/pagination"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type CustomerService struct {
+	db *gorm.DB
+}
+
+func NewService(db *gorm.DB) *CustomerService {
+	return &CustomerService{db}
+}
+
+func (s *CustomerService) FindOne(id int) (*Customer, error) {
+	var customer Customer
+	if err := s.db.Preload("Company").First(&customer, id).Error; err != nil {
+		return nil, exception.DB(err, "Pelanggan")
+	}
+
+	return &customer, nil
+}
+
+func (s *CustomerService) FindAll(query CustomerQuery) *pagination.Result[Customer] {
+	result := pagination.New[Customer](query.Pagination)
+
+	db := s.db.Model(&Customer{}).Preload("Company")
+
+	if query.Company != 0 {
+		db.Where("company_id = ?", query.Company)
+	}
+
+	if query.Keyword != "" {
+		db.Where("name LIKE ? OR phone LIKE ?", "%"+query.Keyword+"%", "%"+query.Keyword+"%")
+	}
+
+	db.Order("created_at DESC")
+
+	return result.Paginate(db)
+}
+
+func (s *CustomerService) Create(data CustomerDTO) (*Customer, error) {
+	customer := Customer{
+		Name:        data.Name,
+		Phone:       data.Phone,
+		Email:       data.Email,
+		Address:     data.Address,
+		TaxID:       data.TaxID,
+		CreditLimit: data.CreditLimit,
+		CompanyID:   data.Company,
+	}
+
+	if err := s.db.Create(&customer).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &customer, nil
+}
+
+func (s *CustomerService) Update(id int, data CustomerDTO) (*Customer, error) {
+	var customer Customer
+	if err := s.db.First(&customer, id).Error; err != nil {
+		return nil, exception.DB(err, "Pelanggan")
+	}
+
+	customer.Name = data.Name
+	customer.Phone = data.Phone
+	customer.Email = data.Email
+	customer.Address = data.Address
+	customer.TaxID = data.TaxID
+	customer.CreditLimit = data.CreditLimit
+	customer.CompanyID = data.Company
+
+	if err := s.db.Save(&customer).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &customer, nil
+}
+
+func (s *CustomerService) Delete(id int) (*Customer, error) {
+	var customer Customer
+	if err := s.db.First(&customer, id).Error; err != nil {
+		return nil, exception.DB(err, "Pelanggan")
+	}
+
+	if err := s.db.Delete(&customer).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &customer, nil
+}
+
+func (s *CustomerService) Using(tx *gorm.DB) *CustomerService {
+	db := s.db
+
+	defer func() {
+		s.db = db
+	}()
+
+	s.db = tx.WithContext(tx.Statement.Context)
+
+	return s
+}
+
+func (s *CustomerService) WithContext(ctx context.Context) *CustomerService {
+	s.db = s.db.WithContext(ctx)
+
+	return s
+}
@@ -3,10 +3,14 @@ package employee
 import (
 	"abude-backend/internal/common"
 	"abude-backend/internal/pkg/user"
+
+	"gorm.io/gorm"
 )
 
 type Employee struct {
 	common.BaseModel
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
 	Name        string `json:"name" gorm:"type:varchar(100)"`
 	Phonenumber string `json:"phonenumber" gorm:"type:varchar(25)"`
 	Address     string `json:"address" gorm:"type:varchar(150)"`
@@ -2,6 +2,7 @@ package handover
 
 import (
 	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/advance"
 	"abude-backend/internal/pkg/attendances/shift"
 	"abude-backend/internal/pkg/inventories/product"
 	"abude-backend/internal/pkg/outlet"
@@ -28,6 +29,8 @@ type Handover struct {
 	SalesTotal     float64   `json:"salesTotal"`
 	PurchasesTotal float64   `json:"purchasesTotal"`
 	ExpensesTotal  float64   `json:"expensesTotal"`
+	ReturnsTotal   float64   `json:"returnsTotal"`
+	AdvancesTotal  float64   `json:"advancesTotal"`
 	CashReceived   float64   `json:"cashReceived"`
 	CashReturned   float64   `json:"cashReturned"`
 	Date           time.Time `json:"date"`
@@ -35,6 +38,8 @@ type Handover struct {
 	Sales     []sale.Sale         `json:"-" gorm:"many2many:handover_sales;constraint:OnDelete:CASCADE;"`
 	Purchases []purchase.Purchase `json:"-" gorm:"many2many:handover_purchases;constraint:OnDelete:CASCADE;"`
 	Expenses  []expense.Expense   `json:"-" gorm:"many2many:handover_expenses;constraint:OnDelete:CASCADE;"`
+	Returns   []sale.SaleReturn   `json:"-" gorm:"many2many:handover_sale_returns;constraint:OnDelete:CASCADE;"`
+	Advances  []advance.Advance   `json:"-" gorm:"many2many:handover_advances;constraint:OnDelete:CASCADE;"`
 
 	SaleItems     []HandoverItem `json:"sales" gorm:"-"`
 	PurchaseItems []HandoverItem `json:"purchases" gorm:"-"`
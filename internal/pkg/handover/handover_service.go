@@ -1,12 +1,16 @@
 package handover
 
 import (
+	"abude-backend/internal/pkg/accounts/period"
+	"abude-backend/internal/pkg/advance"
 	"abude-backend/internal/pkg/transactions/expense"
 	"abude-backend/internal/pkg/transactions/purchase"
 	"abude-backend/internal/pkg/transactions/sale"
+	"abude-backend/internal/pkg/transition"
 	"abude-backend/pkg/exception"
 	"abude-backend/pkg/pagination"
 	"context"
+	"math"
 
 	"gorm.io/gorm"
 )
@@ -108,17 +112,50 @@ func (s *HandoverService) Create(data HandoverDTO) (*Handover, error) {
 	expenses := expense.NewService(s.db).FindAll(expense.ExpenseQuery{
 		Outlet: data.Outlet,
 		Status: []string{expense.StatusAccepted},
+		// Expenses still waiting for approval stay out of the handover
+		ApprovalStatus: []string{expense.ApprovalApproved},
+		Pagination: pagination.Pagination{
+			Limit: -1,
+		},
+	})
+
+	returns := sale.NewReturnService(s.db).FindAll(sale.SaleReturnQuery{
+		Outlet: data.Outlet,
+		Status: []string{sale.StatusAccepted},
+		Pagination: pagination.Pagination{
+			Limit: -1,
+		},
+	})
+
+	advances := advance.NewService(s.db).FindAll(advance.AdvanceQuery{
+		Outlet: data.Outlet,
+		Status: []string{advance.StatusAccepted},
 		Pagination: pagination.Pagination{
 			Limit: -1,
 		},
 	})
 
 	var saleIds []uint
-	for _, sale := range sales.Result {
-		saleIds = append(saleIds, sale.ID)
-		handover.SalesTotal += sale.Total
+	var creditSales []sale.Sale
+	for _, v := range sales.Result {
+		saleIds = append(saleIds, v.ID)
+
+		// Credit sales are settled later through receivables, only their
+		// down payment is in the drawer
+		if v.Type != sale.TypeCredit {
+			handover.SalesTotal += v.Total
+		} else {
+			creditSales = append(creditSales, v)
+		}
 	}
 
+	down, err := downPayments(s.db, creditSales)
+	if err != nil {
+		return nil, exception.DB(err)
+	}
+
+	handover.SalesTotal += down
+
 	var purchaseIds []uint
 	for _, purchase := range purchases.Result {
 		purchaseIds = append(purchaseIds, purchase.ID)
@@ -131,7 +168,35 @@ func (s *HandoverService) Create(data HandoverDTO) (*Handover, error) {
 		handover.ExpensesTotal += expense.Amount
 	}
 
+	var returnIds []uint
+	for _, ret := range returns.Result {
+		returnIds = append(returnIds, ret.ID)
+
+		// Only cash refunds are paid out of the outlet's drawer, returns
+		// credited against a receivable pay nothing out
+		if ret.Method == sale.RefundCash {
+			handover.ReturnsTotal += ret.Total - ret.Credited
+		}
+	}
+
+	var advanceIds []uint
+	var sweptAdvances []advance.Advance
+	for _, v := range advances.Result {
+		// Only advances paid from the outlet's drawer
+		if v.Source != advance.SourceOutlet {
+			continue
+		}
+
+		advanceIds = append(advanceIds, v.ID)
+		sweptAdvances = append(sweptAdvances, v)
+		handover.AdvancesTotal += v.Amount
+	}
+
 	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := period.CheckOutlet(tx, handover.OutletID, handover.Date); err != nil {
+			return err
+		}
+
 		if err := tx.Create(&handover).Error; err != nil {
 			return err
 		}
@@ -148,6 +213,14 @@ func (s *HandoverService) Create(data HandoverDTO) (*Handover, error) {
 			return err
 		}
 
+		if err := tx.Model(&handover).Association("Returns").Append(&returns.Result); err != nil {
+			return err
+		}
+
+		if err := tx.Model(&handover).Association("Advances").Append(&sweptAdvances); err != nil {
+			return err
+		}
+
 		if err := tx.Model(&sale.Sale{}).Where("id IN (?)", saleIds).Update("status", sale.StatusApproved).Error; err != nil {
 			return err
 		}
@@ -160,6 +233,28 @@ func (s *HandoverService) Create(data HandoverDTO) (*Handover, error) {
 			return err
 		}
 
+		if err := tx.Model(&sale.SaleReturn{}).Where("id IN (?)", returnIds).Update("status", sale.StatusApproved).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&advance.Advance{}).Where("id IN (?)", advanceIds).Update("status", advance.StatusApproved).Error; err != nil {
+			return err
+		}
+
+		swept := map[string][]uint{
+			transition.TypeSale:     saleIds,
+			transition.TypePurchase: purchaseIds,
+			transition.TypeExpense:  expenseIds,
+			transition.TypeReturn:   returnIds,
+			transition.TypeAdvance:  advanceIds,
+		}
+
+		for docType, ids := range swept {
+			if err := transition.Record(tx, docType, ids, "accepted", "approved", "Serah terima"); err != nil {
+				return err
+			}
+		}
+
 		return nil
 	}); err != nil {
 		return nil, exception.DB(err)
@@ -168,6 +263,46 @@ func (s *HandoverService) Create(data HandoverDTO) (*Handover, error) {
 	return &handover, nil
 }
 
+// downPayments sums the cash taken as down payment on credit sales. Change is
+// given back from the cash, so payments made by other methods count first.
+func downPayments(tx *gorm.DB, sales []sale.Sale) (float64, error) {
+	if len(sales) == 0 {
+		return 0, nil
+	}
+
+	ids := make([]uint, len(sales))
+	for i, v := range sales {
+		ids[i] = v.ID
+	}
+
+	var rows []struct {
+		SaleID uint
+		Cash   float64
+		Other  float64
+	}
+
+	if err := tx.Model(&sale.SalePayment{}).
+		Select("sale_id, SUM(CASE WHEN method = ? THEN amount ELSE 0 END) AS cash, SUM(CASE WHEN method != ? THEN amount ELSE 0 END) AS other", sale.PaymentCash, sale.PaymentCash).
+		Where("sale_id IN (?)", ids).
+		Group("sale_id").
+		Scan(&rows).Error; err != nil {
+		return 0, err
+	}
+
+	totals := make(map[uint]float64)
+	for _, v := range sales {
+		totals[v.ID] = v.Total
+	}
+
+	var total float64
+	for _, v := range rows {
+		paid := math.Min(v.Cash+v.Other, totals[v.SaleID])
+		total += math.Max(paid-v.Other, 0)
+	}
+
+	return total, nil
+}
+
 func (s *HandoverService) Update(id int, data interface{}) (*Handover, error) {
 	var handover Handover
 	if err := s.db.First(&handover, id).Error; err != nil {
@@ -187,6 +322,10 @@ func (s *HandoverService) Delete(id int) (*Handover, error) {
 		return nil, exception.DB(err)
 	}
 
+	if err := period.CheckOutlet(s.db, handover.OutletID, handover.Date); err != nil {
+		return nil, exception.DB(err)
+	}
+
 	if err := s.db.Delete(&handover).Error; err != nil {
 		return nil, exception.DB(err)
 	}
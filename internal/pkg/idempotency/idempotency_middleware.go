@@ -0,0 +1,153 @@
+package idempotency
+
+import (
+	"abude-backend/internal/pkg/auth"
+	"abude-backend/pkg/exception"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	HeaderKey      = "Idempotency-Key"
+	HeaderReplayed = "Idempotent-Replayed"
+)
+
+// Keys older than this are forgotten and may be used again.
+const retention = 24 * time.Hour
+
+// A key still in progress after this long is taken to belong to a request
+// that crashed or timed out, and a retry may take it over.
+const lease = time.Minute
+
+// NewMiddleware replays the stored response when a request is retried with
+// the same Idempotency-Key. It must be placed after the auth middleware since
+// keys are scoped per user.
+func NewMiddleware(db *gorm.DB) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		value := ctx.Get(HeaderKey)
+		if value == "" {
+			return ctx.Next()
+		}
+
+		if len(value) > 100 {
+			return exception.BadRequest("Idempotency-Key terlalu panjang")
+		}
+
+		creds := auth.GetCreds(ctx.Context())
+		hash := sha256.Sum256(append([]byte(ctx.Method()+" "+ctx.Path()+"\n"), ctx.Body()...))
+
+		key := Key{
+			Key:    value,
+			Method: ctx.Method(),
+			Path:   ctx.Path(),
+			Hash:   hex.EncodeToString(hash[:]),
+			UserID: creds.ID,
+		}
+
+		var stored Key
+		err := db.Where(&Key{Key: value, UserID: creds.ID}).First(&stored).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return exception.DB(err)
+		}
+
+		taken := false
+		if err == nil {
+			if time.Since(stored.CreatedAt) > retention {
+				if err := db.Delete(&stored).Error; err != nil {
+					return exception.DB(err)
+				}
+			} else {
+				if stored.Hash != key.Hash {
+					return exception.Http(fiber.StatusUnprocessableEntity, "Idempotency-Key telah digunakan untuk permintaan yang berbeda")
+				}
+
+				if stored.Status == 0 {
+					taken, err = takeOver(db, &stored)
+					if err != nil {
+						return exception.DB(err)
+					}
+
+					if !taken {
+						return exception.Http(fiber.StatusConflict, "Permintaan dengan Idempotency-Key ini sedang diproses")
+					}
+
+					key = stored
+				} else {
+					ctx.Set(HeaderReplayed, "true")
+					ctx.Set(fiber.HeaderContentType, stored.ContentType)
+
+					return ctx.Status(stored.Status).Send(stored.Response)
+				}
+			}
+		}
+
+		// The unique index turns a concurrent retry into a conflict
+		if !taken {
+			if err := db.Create(&key).Error; err != nil {
+				if duplicate(db, err) {
+					return exception.Http(fiber.StatusConflict, "Permintaan dengan Idempotency-Key ini sedang diproses")
+				}
+
+				return exception.DB(err)
+			}
+		}
+
+		if err := ctx.Next(); err != nil {
+			// Failed requests are not stored so the client can retry them
+			db.Delete(&key)
+
+			return err
+		}
+
+		response := ctx.Response()
+		if response.StatusCode() >= fiber.StatusInternalServerError {
+			db.Delete(&key)
+
+			return nil
+		}
+
+		if err := db.Model(&key).Updates(Key{
+			Status:      response.StatusCode(),
+			ContentType: string(response.Header.ContentType()),
+			Response:    append([]byte(nil), response.Body()...),
+		}).Error; err != nil {
+			return exception.DB(err)
+		}
+
+		return nil
+	}
+}
+
+// takeOver claims a key whose lease ran out. Only one of several retries
+// racing for the key succeeds.
+func takeOver(db *gorm.DB, key *Key) (bool, error) {
+	now := time.Now()
+	if now.Sub(key.CreatedAt) < lease {
+		return false, nil
+	}
+
+	result := db.Model(&Key{}).
+		Where("id = ? AND status = 0 AND created_at < ?", key.ID, now.Add(-lease)).
+		Update("created_at", now)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	key.CreatedAt = now
+
+	return result.RowsAffected > 0, nil
+}
+
+// duplicate reports whether the error violates a unique index.
+func duplicate(db *gorm.DB, err error) bool {
+	if translator, ok := db.Dialector.(gorm.ErrorTranslator); ok {
+		err = translator.Translate(err)
+	}
+
+	return errors.Is(err, gorm.ErrDuplicatedKey)
+}
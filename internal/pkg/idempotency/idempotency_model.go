@@ -0,0 +1,26 @@
+package idempotency
+
+import (
+	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/user"
+)
+
+// Key remembers the response of a request sent with an Idempotency-Key
+// header. A Status of 0 means the original request is still in progress.
+type Key struct {
+	common.BaseModel
+	Key         string `gorm:"type:varchar(100);uniqueIndex:idx_idempotency_user_key"`
+	Method      string `gorm:"type:varchar(10)"`
+	Path        string `gorm:"type:varchar(255)"`
+	Hash        string `gorm:"type:varchar(64)"`
+	Status      int
+	ContentType string `gorm:"type:varchar(100)"`
+	Response    []byte
+
+	User   *user.User `gorm:"constraint:OnDelete:CASCADE;"`
+	UserID uint       `gorm:"uniqueIndex:idx_idempotency_user_key"`
+}
+
+func (Key) TableName() string {
+	return "idempotency_keys"
+}
@@ -1,9 +1,15 @@
 package category
 
-import "abude-backend/internal/common"
+import (
+	"abude-backend/internal/common"
+
+	"gorm.io/gorm"
+)
 
 type Category struct {
 	common.BaseModel
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
 	Name        string `json:"name" gorm:"type:varchar(100)"`
 	Description string `json:"description"`
 
@@ -1,6 +1,9 @@
 package inventory
 
 import (
+	"abude-backend/internal/pkg/accounts/journal"
+	"abude-backend/internal/pkg/accounts/period"
+	"abude-backend/internal/pkg/outlet"
 	"abude-backend/internal/pkg/transactions/purchase"
 	"abude-backend/pkg/exception"
 	"abude-backend/pkg/pagination"
@@ -228,15 +231,28 @@ func (s *InventoryService) GetStockSummary(query StockSummaryQuery) ([]StockSumm
 
 	purchaseQuery := s.db.Table("purchase_items").
 		Select("products.id AS product_id, SUM(purchase_items.quantity) AS stock_in, SUM(purchase_items.total) AS value_in, 0 AS stock_out, 0 AS value_out").
+		Joins("INNER JOIN purchases ON purchases.id = purchase_items.purchase_id AND purchases.deleted_at IS NULL").
 		Joins("INNER JOIN products ON products.id = purchase_items.product_id").
 		Group("purchase_items.product_id").Where("purchase_items.status = 0")
 
 	saleQuery := s.db.Table("sale_items").
 		Select("products.id AS product_id, 0 AS stock_in, 0 AS value_in, SUM(ingredients.quantity) AS stock_out, SUM(ingredients.quantity * products.price) AS value_out").
+		Joins("INNER JOIN sales ON sales.id = sale_items.sale_id AND sales.deleted_at IS NULL").
 		Joins("INNER JOIN ingredients ON ingredients.base_id = sale_items.product_id").
 		Joins("INNER JOIN products ON products.id = ingredients.ingredient_id").
 		Group("ingredients.ingredient_id").Where("sale_items.status = 0")
 
+	// Returned items that were never prepared put their ingredients back,
+	// so they are counted as a negative stock out.
+	returnQuery := s.db.Table("sale_return_items").
+		Select("products.id AS product_id, 0 AS stock_in, 0 AS value_in, -SUM(ingredients.quantity * sale_return_items.quantity) AS stock_out, -SUM(ingredients.quantity * sale_return_items.quantity * products.price) AS value_out").
+		Joins("INNER JOIN sale_returns ON sale_returns.id = sale_return_items.sale_return_id").
+		Joins("INNER JOIN sales ON sales.id = sale_returns.sale_id AND sales.deleted_at IS NULL").
+		Joins("INNER JOIN ingredients ON ingredients.base_id = sale_return_items.product_id").
+		Joins("INNER JOIN products ON products.id = ingredients.ingredient_id").
+		Group("ingredients.ingredient_id").
+		Where("sale_return_items.status = 0 AND sale_return_items.restock = 1 AND sale_returns.status != 'canceled'")
+
 	if query.Outlet != 0 {
 		purchaseQuery.Where("purchase_items.purchase_id IN (?)", s.db.
 			Table("outlet_purchases").
@@ -246,6 +262,10 @@ func (s *InventoryService) GetStockSummary(query StockSummaryQuery) ([]StockSumm
 			Table("outlet_sales").
 			Select("outlet_sales.sale_id").
 			Where("outlet_sales.outlet_id = ?", query.Outlet))
+		returnQuery.Where("sale_returns.sale_id IN (?)", s.db.
+			Table("outlet_sales").
+			Select("outlet_sales.sale_id").
+			Where("outlet_sales.outlet_id = ?", query.Outlet))
 	}
 
 	inventoryQuery := s.db.Table("inventories").
@@ -261,7 +281,7 @@ func (s *InventoryService) GetStockSummary(query StockSummaryQuery) ([]StockSumm
 	}
 
 	if err := s.db.Select("products.*, SUM(stock_in) AS stock_in, SUM(stock_out) AS stock_out, SUM(value_in) AS value_in, SUM(value_out) AS value_out, SUM(available) AS available, SUM(total_value) AS total_value").
-		Table("(? UNION ?) AS s", saleQuery, purchaseQuery).
+		Table("(? UNION ? UNION ?) AS s", saleQuery, purchaseQuery, returnQuery).
 		Joins("INNER JOIN (?) AS i ON i.product_id = s.product_id", inventoryQuery).
 		Joins("INNER JOIN products ON products.id=s.product_id").
 		Group("s.product_id, i.product_id").
@@ -320,13 +340,17 @@ func (s *InventoryService) CreateRecap(data RecapitulationDTO) (*Recapitulation,
 			TotalValue: item.TotalValue,
 			StockIn:    item.StockIn,
 			ValueIn:    item.ValueIn,
-			ValueOut:   item.StockOut,
+			ValueOut:   item.ValueOut,
 			StockOut:   item.StockOut,
 			ProductID:  item.Product.ID,
 		})
 	}
 
 	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := period.CheckOutlet(tx, data.Outlet, data.Date); err != nil {
+			return err
+		}
+
 		if err := tx.Create(&recap).Error; err != nil {
 			return err
 		}
@@ -336,7 +360,7 @@ func (s *InventoryService) CreateRecap(data RecapitulationDTO) (*Recapitulation,
 			Table("outlet_purchases").
 			Select("purchase_id").
 			Where("outlet_id = ?", data.Outlet),
-		).Preload("Purchase").Find(&purchases).Error; err != nil {
+		).Where("purchase_id IN (?)", tx.Model(&purchase.Purchase{}).Select("id")).Preload("Purchase").Find(&purchases).Error; err != nil {
 			return err
 		}
 
@@ -352,6 +376,16 @@ func (s *InventoryService) CreateRecap(data RecapitulationDTO) (*Recapitulation,
 			return err
 		}
 
+		if err := tx.Table("sale_return_items").
+			Where("status = 0 AND restock = 1 AND sale_return_id IN (?)", s.db.
+				Table("sale_returns").
+				Select("sale_returns.id").
+				Joins("INNER JOIN outlet_sales ON outlet_sales.sale_id = sale_returns.sale_id").
+				Where("outlet_sales.outlet_id = ? AND sale_returns.status != 'canceled'", data.Outlet)).
+			Update("status", 1).Error; err != nil {
+			return err
+		}
+
 		service := NewService(tx)
 		for _, v := range purchases {
 			if err := service.StockIn(InventoryDTO{
@@ -371,6 +405,22 @@ func (s *InventoryService) CreateRecap(data RecapitulationDTO) (*Recapitulation,
 				continue
 			}
 
+			// More ingredients were returned than consumed
+			if v.StockOut < 0 {
+				if err := service.StockIn(InventoryDTO{
+					Source:   "outlet",
+					SourceID: data.Outlet,
+					Date:     datatypes.Date(time.Now()),
+					Product:  v.Product.ID,
+					Price:    v.Product.Price,
+					Quantity: -v.StockOut,
+				}); err != nil {
+					return err
+				}
+
+				continue
+			}
+
 			if err := service.StockOut(InventoryDTO{
 				Source:   "outlet",
 				SourceID: data.Outlet,
@@ -383,7 +433,12 @@ func (s *InventoryService) CreateRecap(data RecapitulationDTO) (*Recapitulation,
 			}
 		}
 
-		return nil
+		var outlet outlet.Outlet
+		if err := tx.First(&outlet, data.Outlet).Error; err != nil {
+			return err
+		}
+
+		return journal.Post(tx, recap.posting(&outlet))
 	}); err != nil {
 		return nil, exception.DB(err)
 	}
@@ -2,11 +2,11 @@ package inventory
 
 import (
 	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/accounts/journal"
 	"abude-backend/internal/pkg/inventories/product"
 	"abude-backend/internal/pkg/outlet"
+	"abude-backend/internal/pkg/sequence"
 	"abude-backend/internal/pkg/user"
-	"abude-backend/pkg/utils"
-	"fmt"
 	"time"
 
 	"gorm.io/gorm"
@@ -51,14 +51,39 @@ func (Recapitulation) TableName() string {
 }
 
 func (recap *Recapitulation) BeforeCreate(tx *gorm.DB) error {
-	now := time.Now()
+	if recap.Code != "" {
+		return nil
+	}
 
-	var count int64
-	tx.Model(&Recapitulation{}).
-		Where("DATE(created_at) = ?", now.Format("2006-01-02")).
-		Count(&count)
+	code, err := sequence.NextForOutlet(tx, sequence.TypeRecapitulation, recap.OutletID, time.Now())
+	if err != nil {
+		return err
+	}
 
-	recap.Code = fmt.Sprintf("STX-%s%d%s", now.Format("20060102"), recap.OutletID, utils.NumberToDigit(int(count+1), 3))
+	recap.Code = code
 
 	return nil
 }
+
+// posting books the value of the ingredients consumed since the previous
+// recap as cost of goods sold.
+func (recap *Recapitulation) posting(outlet *outlet.Outlet) journal.Posting {
+	var value float64
+	for _, item := range recap.Items {
+		value += item.ValueOut
+	}
+
+	return journal.Posting{
+		Company:     outlet.CompanyID,
+		Outlet:      &outlet.ID,
+		User:        recap.EditorID,
+		Date:        recap.Date,
+		Source:      journal.SourceRecap,
+		SourceID:    recap.ID,
+		Description: "Rekapitulasi persediaan " + recap.Code,
+		Lines: []journal.PostingLine{
+			{Key: journal.KeyCOGS, Debit: value},
+			{Key: journal.KeyInventory, Credit: value},
+		},
+	}
+}
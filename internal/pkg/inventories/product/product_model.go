@@ -4,6 +4,8 @@ import (
 	"abude-backend/internal/common"
 	"abude-backend/internal/pkg/company"
 	"abude-backend/internal/pkg/inventories/category"
+
+	"gorm.io/gorm"
 )
 
 type Ingredient struct {
@@ -19,6 +21,8 @@ type Ingredient struct {
 
 type Product struct {
 	common.BaseModel
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
 	Name        string  `json:"name" gorm:"type:varchar(100)"`
 	Description string  `json:"description"`
 	Price       float64 `json:"price"`
@@ -0,0 +1,60 @@
+package loyalty
+
+import (
+	"abude-backend/pkg/pagination"
+	"time"
+)
+
+type ProgramDTO struct {
+	SpendUnit  float64 `json:"spendUnit" form:"spendUnit" validate:"required,gt=0"`
+	PointValue float64 `json:"pointValue" form:"pointValue" validate:"required,gt=0"`
+	ExpiryDays int     `json:"expiryDays" form:"expiryDays" validate:"omitempty,gte=0"`
+	Status     bool    `json:"status" form:"status" validate:"omitempty"`
+	Company    uint    `json:"company" form:"company" validate:"required,exist=companies"`
+}
+
+type ProgramQuery struct {
+	Company uint `query:"company" validate:"required"`
+}
+
+type TierDTO struct {
+	Name     string  `json:"name" form:"name" validate:"required"`
+	MinSpend float64 `json:"minSpend" form:"minSpend" validate:"omitempty,gte=0"`
+	EarnRate float64 `json:"earnRate" form:"earnRate" validate:"required,gt=0"`
+	Company  uint    `json:"company" form:"company" validate:"required,exist=companies"`
+}
+
+type TierQuery struct {
+	pagination.Pagination
+	Company uint `query:"company"`
+}
+
+type MemberDTO struct {
+	Name     string `json:"name" form:"name" validate:"required"`
+	Phone    string `json:"phone" form:"phone" validate:"required"`
+	Card     string `json:"card" form:"card" validate:"omitempty"`
+	Status   bool   `json:"status" form:"status" validate:"omitempty"`
+	Customer *uint  `json:"customer" form:"customer" validate:"omitempty,exist=customers"`
+	Company  uint   `json:"company" form:"company" validate:"required,exist=companies"`
+}
+
+type MemberQuery struct {
+	pagination.Pagination
+	Keyword string `query:"keyword"`
+	Company uint   `query:"company"`
+	Tier    uint   `query:"tier"`
+}
+
+type LookupQuery struct {
+	Company uint   `query:"company" validate:"required"`
+	Code    string `query:"code" validate:"required"` // Phone or card number
+}
+
+type EntryQuery struct {
+	pagination.Pagination
+	Type      []string  `query:"type" enums:"earn,redeem,expire,reverse"`
+	StartDate time.Time `query:"startDate" format:"date-time"`
+	EndDate   time.Time `query:"endDate" format:"date-time"`
+
+	Member uint `query:"-"`
+}
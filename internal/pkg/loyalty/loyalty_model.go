@@ -0,0 +1,94 @@
+package loyalty
+
+import (
+	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/company"
+	"abude-backend/internal/pkg/customer"
+	"time"
+)
+
+const (
+	EntryEarn    = "earn"
+	EntryRedeem  = "redeem"
+	EntryExpire  = "expire"
+	EntryReverse = "reverse"
+	EntryReturn  = "return" // Points taken back for goods returned from a sale
+)
+
+// Program holds the loyalty settings of a company.
+type Program struct {
+	common.BaseModel
+	SpendUnit  float64 `json:"spendUnit"`  // Spend needed to earn one point
+	PointValue float64 `json:"pointValue"` // Discount value of one point
+	ExpiryDays int     `json:"expiryDays"` // 0 means points never expire
+	Status     bool    `json:"status"`
+
+	Company   *company.Company `json:"company,omitempty" gorm:"constraint:OnDelete:CASCADE;"`
+	CompanyID uint             `json:"-" gorm:"uniqueIndex"`
+}
+
+func (Program) TableName() string {
+	return "loyalty_programs"
+}
+
+type Tier struct {
+	common.BaseModel
+	Name     string  `json:"name" gorm:"type:varchar(50)"`
+	MinSpend float64 `json:"minSpend"`
+	EarnRate float64 `json:"earnRate"` // Multiplier applied to earned points
+
+	Company   *company.Company `json:"company,omitempty" gorm:"constraint:OnDelete:CASCADE;"`
+	CompanyID uint             `json:"-"`
+}
+
+func (Tier) TableName() string {
+	return "loyalty_tiers"
+}
+
+type Member struct {
+	common.BaseModel
+	Name       string  `json:"name" gorm:"type:varchar(100)"`
+	Phone      string  `json:"phone" gorm:"type:varchar(20);index"`
+	Card       string  `json:"card" gorm:"type:varchar(50);index"`
+	Points     float64 `json:"points"`
+	TotalSpend float64 `json:"totalSpend"`
+	Status     bool    `json:"status"`
+
+	Tier   *Tier `json:"tier,omitempty" gorm:"constraint:OnDelete:SET NULL;"`
+	TierID *uint `json:"-"`
+
+	Customer   *customer.Customer `json:"customer,omitempty" gorm:"constraint:OnDelete:SET NULL;"`
+	CustomerID *uint              `json:"-"`
+
+	Company   *company.Company `json:"company,omitempty" gorm:"constraint:OnDelete:CASCADE;"`
+	CompanyID uint             `json:"-"`
+}
+
+func (Member) TableName() string {
+	return "loyalty_members"
+}
+
+// Entry is a single movement in a member's points ledger. Earned entries keep
+// track of the points not yet redeemed or expired in Remaining.
+type Entry struct {
+	common.BaseModel
+	Type      string     `json:"type" gorm:"type:enum('earn','redeem','expire','reverse','return')" enums:"earn,redeem,expire,reverse,return"`
+	Points    float64    `json:"points"`
+	Remaining float64    `json:"-"`
+	Amount    float64    `json:"amount"` // Spend or discount value behind the entry
+	Note      string     `json:"note" gorm:"type:varchar(150)"`
+	ExpiresAt *time.Time `json:"expiresAt"`
+
+	SaleID   *uint `json:"sale" gorm:"index"`
+	ReturnID *uint `json:"return,omitempty" gorm:"index"` // Sale return the entry came from
+
+	Member   *Member `json:"-" gorm:"constraint:OnDelete:CASCADE;"`
+	MemberID uint    `json:"-"`
+
+	Company   *company.Company `json:"-" gorm:"constraint:OnDelete:CASCADE;"`
+	CompanyID uint             `json:"-"`
+}
+
+func (Entry) TableName() string {
+	return "loyalty_entries"
+}
@@ -0,0 +1,27 @@
+package loyalty
+
+import (
+	"abude-backend/internal/common"
+)
+
+func LoadRoutes(r *common.Router) {
+	programService := NewProgramService(r.DB)
+	memberService := NewMemberService(r.DB)
+
+	programHandler := NewProgramController(r.Controller, programService)
+	r.Router.Get("/loyalty/program", r.Auth(1), programHandler.One)
+	r.Router.Put("/loyalty/program", r.Auth(2), programHandler.Save)
+	r.Router.Get("/loyalty/tier", r.Auth(1), programHandler.AllTier)
+	r.Router.Post("/loyalty/tier", r.Auth(2), programHandler.CreateTier)
+	r.Router.Put("/loyalty/tier/:id", r.Auth(2), programHandler.UpdateTier)
+	r.Router.Delete("/loyalty/tier/:id", r.Auth(2), programHandler.DeleteTier)
+
+	memberHandler := NewMemberController(r.Controller, memberService)
+	r.Router.Get("/loyalty/member", r.Auth(1), memberHandler.All)
+	r.Router.Get("/loyalty/member/lookup", r.Auth(1), memberHandler.Lookup)
+	r.Router.Get("/loyalty/member/:id", r.Auth(1), memberHandler.One)
+	r.Router.Get("/loyalty/member/:id/points", r.Auth(1), memberHandler.Entries)
+	r.Router.Post("/loyalty/member", r.Auth(1), memberHandler.Create)
+	r.Router.Put("/loyalty/member/:id", r.Auth(1), memberHandler.Update)
+	r.Router.Delete("/loyalty/member/:id", r.Auth(2), memberHandler.Delete)
+}
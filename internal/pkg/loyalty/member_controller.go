@@ -0,0 +1,186 @@
+package loyalty
+
+import (
+	"abude-backend/internal/common"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type MemberController struct {
+	*common.BaseController
+	member *MemberService
+}
+
+func NewMemberController(ctrl *common.BaseController, member *MemberService) *MemberController {
+	return &MemberController{ctrl, member}
+}
+
+// @Summary Get One Member
+// @Tags Loyalty
+// @Accept json
+// @Produce json
+// @Param id path string true "Member ID"
+// @Success 200 {object} Member{}
+// @Security JWT
+// @Router /api/loyalty/member/{id} [get]
+func (ctrl *MemberController) One(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	member, err := ctrl.member.FindOne(id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(member)
+}
+
+// @Summary Lookup Member by Phone or Card
+// @Tags Loyalty
+// @Accept json
+// @Produce json
+// @Param query query LookupQuery true "query"
+// @Success 200 {object} Member{}
+// @Security JWT
+// @Router /api/loyalty/member/lookup [get]
+func (ctrl *MemberController) Lookup(ctx *fiber.Ctx) error {
+	var query LookupQuery
+	if err := ctrl.Validation.Query(&query, ctx); err != nil {
+		return err
+	}
+
+	member, err := ctrl.member.Lookup(query)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(member)
+}
+
+// @Summary Get All Member
+// @Tags Loyalty
+// @Accept json
+// @Produce json
+// @Param query query MemberQuery false "query"
+// @Success 200 {object} common.PaginatedResponse{result=[]Member}
+// @Security JWT
+// @Router /api/loyalty/member [get]
+func (ctrl *MemberController) All(ctx *fiber.Ctx) error {
+	var query MemberQuery
+	if err := ctrl.Validation.Query(&query, ctx); err != nil {
+		return err
+	}
+
+	result := ctrl.member.FindAll(query)
+
+	return ctx.Status(fiber.StatusOK).JSON(result)
+}
+
+// @Summary Get Member Points History
+// @Tags Loyalty
+// @Accept json
+// @Produce json
+// @Param id path string true "Member ID"
+// @Param query query EntryQuery false "query"
+// @Success 200 {object} common.PaginatedResponse{result=[]Entry}
+// @Security JWT
+// @Router /api/loyalty/member/{id}/points [get]
+func (ctrl *MemberController) Entries(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	var query EntryQuery
+	if err := ctrl.Validation.Query(&query, ctx); err != nil {
+		return err
+	}
+
+	query.Member = uint(id)
+	result := ctrl.member.FindEntries(query)
+
+	return ctx.Status(fiber.StatusOK).JSON(result)
+}
+
+// @Summary Create Member
+// @Tags Loyalty
+// @Accept json
+// @Produce json
+// @Param request body MemberDTO true "Request Body"
+// @Success 201 {object} common.GeneralResponse{result=Member}
+// @Security JWT
+// @Router /api/loyalty/member [post]
+func (ctrl *MemberController) Create(ctx *fiber.Ctx) error {
+	var data MemberDTO
+	if err := ctrl.Validation.Body(&data, ctx); err != nil {
+		return err
+	}
+
+	member, err := ctrl.member.Create(data)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(common.GeneralResponse{
+		Message: "Member berhasil dibuat",
+		Result:  member,
+	})
+}
+
+// @Summary Update Member
+// @Tags Loyalty
+// @Accept json
+// @Produce json
+// @Param id path string true "Member ID"
+// @Param request body MemberDTO true "Request Body"
+// @Success 200 {object} common.GeneralResponse{result=Member}
+// @Security JWT
+// @Router /api/loyalty/member/{id} [put]
+func (ctrl *MemberController) Update(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	var data MemberDTO
+	if err := ctrl.Validation.Body(&data, ctx); err != nil {
+		return err
+	}
+
+	member, err := ctrl.member.Update(id, data)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.GeneralResponse{
+		Message: "Member berhasil diubah",
+		Result:  member,
+	})
+}
+
+// @Summary Delete Member
+// @Tags Loyalty
+// @Accept json
+// @Produce json
+// @Param id path string true "Member ID"
+// @Success 200 {object} common.GeneralResponse{result=Member}
+// @Security JWT
+// @Router /api/loyalty/member/{id} [delete]
+func (ctrl *MemberController) Delete(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	member, err := ctrl.member.Delete(id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.GeneralResponse{
+		Message: "Member berhasil dihapus",
+		Result:  member,
+	})
+}
@@ -0,0 +1,188 @@
+package loyalty
+
+import (
+	"abude-backend/pkg/exception"
+	"abude-backend/pkg/pagination"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type MemberService struct {
+	db *gorm.DB
+}
+
+func NewMemberService(db *gorm.DB) *MemberService {
+	return &MemberService{db}
+}
+
+func (s *MemberService) FindOne(id int) (*Member, error) {
+	if err := NewPointService(s.db).Expire(uint(id)); err != nil {
+		return nil, err
+	}
+
+	var member Member
+	if err := s.db.Preload("Tier").Preload("Customer").First(&member, id).Error; err != nil {
+		return nil, exception.DB(err, "Member")
+	}
+
+	return &member, nil
+}
+
+// Lookup finds an active member of the company by phone or card number.
+func (s *MemberService) Lookup(query LookupQuery) (*Member, error) {
+	var member Member
+	if err := s.db.
+		Where("company_id = ? AND status = ?", query.Company, true).
+		Where("phone = ? OR card = ?", query.Code, query.Code).
+		First(&member).Error; err != nil {
+		return nil, exception.DB(err, "Member")
+	}
+
+	return s.FindOne(int(member.ID))
+}
+
+func (s *MemberService) FindAll(query MemberQuery) *pagination.Result[Member] {
+	result := pagination.New[Member](query.Pagination)
+
+	db := s.db.Model(&Member{}).Preload("Tier")
+	if query.Company != 0 {
+		db.Where("company_id = ?", query.Company)
+	}
+
+	if query.Tier != 0 {
+		db.Where("tier_id = ?", query.Tier)
+	}
+
+	if query.Keyword != "" {
+		db.Where("name LIKE ? OR phone LIKE ? OR card LIKE ?", "%"+query.Keyword+"%", "%"+query.Keyword+"%", "%"+query.Keyword+"%")
+	}
+
+	db.Order("created_at DESC")
+
+	return result.Paginate(db)
+}
+
+func (s *MemberService) FindEntries(query EntryQuery) *pagination.Result[Entry] {
+	result := pagination.New[Entry](query.Pagination)
+
+	db := s.db.Model(&Entry{}).Where("member_id = ?", query.Member)
+	if len(query.Type) > 0 {
+		db.Where("type IN (?)", query.Type)
+	}
+
+	if !query.StartDate.IsZero() {
+		db.Where("created_at >= ?", query.StartDate)
+	}
+
+	if !query.EndDate.IsZero() {
+		db.Where("created_at <= ?", query.EndDate)
+	}
+
+	db.Order("created_at DESC")
+
+	return result.Paginate(db)
+}
+
+func (s *MemberService) Create(data MemberDTO) (*Member, error) {
+	if err := s.checkDuplicate(0, data); err != nil {
+		return nil, err
+	}
+
+	member := Member{
+		Name:       data.Name,
+		Phone:      data.Phone,
+		Card:       data.Card,
+		Status:     data.Status,
+		CustomerID: data.Customer,
+		CompanyID:  data.Company,
+	}
+
+	member.TierID = NewPointService(s.db).tierFor(data.Company, 0)
+
+	if err := s.db.Create(&member).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &member, nil
+}
+
+func (s *MemberService) Update(id int, data MemberDTO) (*Member, error) {
+	var member Member
+	if err := s.db.First(&member, id).Error; err != nil {
+		return nil, exception.DB(err, "Member")
+	}
+
+	if member.CompanyID != data.Company {
+		return nil, exception.BadRequest("Perusahaan member tidak dapat diubah")
+	}
+
+	if err := s.checkDuplicate(member.ID, data); err != nil {
+		return nil, err
+	}
+
+	member.Name = data.Name
+	member.Phone = data.Phone
+	member.Card = data.Card
+	member.Status = data.Status
+	member.CustomerID = data.Customer
+
+	if err := s.db.Omit("Tier").Save(&member).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &member, nil
+}
+
+func (s *MemberService) Delete(id int) (*Member, error) {
+	var member Member
+	if err := s.db.First(&member, id).Error; err != nil {
+		return nil, exception.DB(err, "Member")
+	}
+
+	if err := s.db.Delete(&member).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &member, nil
+}
+
+func (s *MemberService) checkDuplicate(id uint, data MemberDTO) error {
+	var count int64
+	db := s.db.Model(&Member{}).Where("company_id = ? AND id != ?", data.Company, id)
+	if data.Card != "" {
+		db.Where("phone = ? OR card = ?", data.Phone, data.Card)
+	} else {
+		db.Where("phone = ?", data.Phone)
+	}
+
+	if err := db.Count(&count).Error; err != nil {
+		return exception.DB(err)
+	}
+
+	if count > 0 {
+		return exception.Validation(map[string]string{
+			"phone": "No telepon atau kartu member telah digunakan",
+		})
+	}
+
+	return nil
+}
+
+func (s *MemberService) Using(tx *gorm.DB) *MemberService {
+	db := s.db
+
+	defer func() {
+		s.db = db
+	}()
+
+	s.db = tx.WithContext(tx.Statement.Context)
+
+	return s
+}
+
+func (s *MemberService) WithContext(ctx context.Context) *MemberService {
+	s.db = s.db.WithContext(ctx)
+
+	return s
+}
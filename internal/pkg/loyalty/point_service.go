@@ -0,0 +1,447 @@
+package loyalty
+
+import (
+	"abude-backend/pkg/exception"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PointService keeps the points ledger of members. Every accrual and
+// redemption is linked to the sale it came from so it can be reversed.
+type PointService struct {
+	db *gorm.DB
+}
+
+func NewPointService(db *gorm.DB) *PointService {
+	return &PointService{db}
+}
+
+// Quote checks that the member can redeem the points and returns their
+// discount value.
+func (s *PointService) Quote(memberId uint, points float64) (float64, error) {
+	if err := s.Expire(memberId); err != nil {
+		return 0, err
+	}
+
+	member, program, err := s.load(memberId)
+	if err != nil {
+		return 0, err
+	}
+
+	if program == nil {
+		return 0, exception.BadRequest("Program loyalitas tidak aktif")
+	}
+
+	if points > member.Points {
+		return 0, exception.BadRequest(fmt.Sprintf("Poin member tidak mencukupi (tersedia %.0f)", member.Points))
+	}
+
+	return points * program.PointValue, nil
+}
+
+// Earn awards points for the amount spent in a sale and moves the member up
+// a tier when the total spend allows it.
+func (s *PointService) Earn(memberId uint, saleId uint, amount float64) error {
+	member, program, err := s.load(memberId)
+	if err != nil {
+		return err
+	}
+
+	if program == nil || amount <= 0 {
+		return nil
+	}
+
+	rate := 1.0
+	if member.TierID != nil {
+		var tier Tier
+		if err := s.db.First(&tier, *member.TierID).Error; err == nil {
+			rate = tier.EarnRate
+		}
+	}
+
+	points := math.Floor(amount / program.SpendUnit * rate)
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if points > 0 {
+			entry := Entry{
+				Type:      EntryEarn,
+				Points:    points,
+				Remaining: points,
+				Amount:    amount,
+				SaleID:    &saleId,
+				MemberID:  member.ID,
+				CompanyID: member.CompanyID,
+			}
+
+			if program.ExpiryDays > 0 {
+				expiresAt := time.Now().AddDate(0, 0, program.ExpiryDays)
+				entry.ExpiresAt = &expiresAt
+			}
+
+			if err := tx.Create(&entry).Error; err != nil {
+				return err
+			}
+		}
+
+		return NewPointService(tx).adjust(member, points, amount)
+	})
+}
+
+// Redeem spends points as a discount on a sale, using the points closest to
+// expiry first.
+func (s *PointService) Redeem(memberId uint, saleId uint, points float64) error {
+	value, err := s.Quote(memberId, points)
+	if err != nil {
+		return err
+	}
+
+	member, _, err := s.load(memberId)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := NewPointService(tx).consume(member.ID, points); err != nil {
+			return err
+		}
+
+		if err := tx.Create(&Entry{
+			Type:      EntryRedeem,
+			Points:    -points,
+			Amount:    value,
+			SaleID:    &saleId,
+			MemberID:  member.ID,
+			CompanyID: member.CompanyID,
+		}).Error; err != nil {
+			return err
+		}
+
+		return NewPointService(tx).adjust(member, -points, 0)
+	})
+}
+
+// Reverse undoes the accruals, redemptions and returns of a canceled sale.
+func (s *PointService) Reverse(saleId uint) error {
+	var entries []Entry
+	if err := s.db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("sale_id = ?", saleId).Order("id ASC").Find(&entries).Error; err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.Type == EntryReverse {
+			return nil
+		}
+	}
+
+	// Points taken back by returns are no longer owed by the member
+	var returned float64
+	for _, entry := range entries {
+		if entry.Type == EntryReturn {
+			returned -= entry.Points
+		}
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		for _, entry := range entries {
+			reverse := Entry{
+				Type:      EntryReverse,
+				Points:    -entry.Points,
+				Amount:    entry.Amount,
+				Note:      fmt.Sprintf("Pembatalan %s", entry.Type),
+				SaleID:    &saleId,
+				MemberID:  entry.MemberID,
+				CompanyID: entry.CompanyID,
+			}
+
+			var member Member
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&member, entry.MemberID).Error; err != nil {
+				return err
+			}
+
+			var spend float64
+
+			switch entry.Type {
+			case EntryEarn:
+				// Points already spent are taken back from the member's other points
+				if err := tx.Model(&entry).Update("remaining", 0).Error; err != nil {
+					return err
+				}
+
+				spent := entry.Points - entry.Remaining
+				if entry.ReturnID == nil {
+					spent -= returned
+				}
+
+				if spent > 0 {
+					if err := NewPointService(tx).consume(member.ID, math.Min(spent, math.Max(member.Points-entry.Remaining, 0))); err != nil {
+						return err
+					}
+				}
+
+				spend = -entry.Amount
+			case EntryReturn:
+				spend = entry.Amount
+			case EntryRedeem:
+				reverse.Remaining = reverse.Points
+				if program, err := NewProgramService(tx).FindOne(member.CompanyID); err == nil && program.ExpiryDays > 0 {
+					expiresAt := time.Now().AddDate(0, 0, program.ExpiryDays)
+					reverse.ExpiresAt = &expiresAt
+				}
+			default:
+				continue
+			}
+
+			if err := tx.Create(&reverse).Error; err != nil {
+				return err
+			}
+
+			if err := NewPointService(tx).adjust(&member, reverse.Points, spend); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Return takes back the points earned on the part of a sale that was
+// returned. Points of the sale the member already spent are taken from their
+// other points.
+func (s *PointService) Return(saleId uint, returnId uint, amount float64) error {
+	var earned Entry
+	if err := s.db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("sale_id = ? AND type = ? AND return_id IS NULL", saleId, EntryEarn).Limit(1).Find(&earned).Error; err != nil {
+		return err
+	}
+
+	if earned.ID == 0 || earned.Amount <= 0 || amount <= 0 {
+		return nil
+	}
+
+	var member Member
+	if err := s.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&member, earned.MemberID).Error; err != nil {
+		return err
+	}
+
+	amount = math.Min(amount, earned.Amount)
+	points := math.Floor(earned.Points * amount / earned.Amount)
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		unspent := math.Min(earned.Remaining, points)
+		if unspent > 0 {
+			if err := tx.Model(&earned).Update("remaining", gorm.Expr("remaining - ?", unspent)).Error; err != nil {
+				return err
+			}
+		}
+
+		if spent := points - unspent; spent > 0 {
+			if err := NewPointService(tx).consume(member.ID, math.Min(spent, math.Max(member.Points-earned.Remaining, 0))); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Create(&Entry{
+			Type:      EntryReturn,
+			Points:    -points,
+			Amount:    amount,
+			Note:      "Retur penjualan",
+			SaleID:    &saleId,
+			ReturnID:  &returnId,
+			MemberID:  member.ID,
+			CompanyID: member.CompanyID,
+		}).Error; err != nil {
+			return err
+		}
+
+		return NewPointService(tx).adjust(&member, -points, -amount)
+	})
+}
+
+// Restore gives back the points taken for a return that was canceled.
+func (s *PointService) Restore(returnId uint) error {
+	var entries []Entry
+	if err := s.db.Where("return_id = ?", returnId).Order("id ASC").Find(&entries).Error; err != nil {
+		return err
+	}
+
+	var taken *Entry
+	for i, entry := range entries {
+		switch entry.Type {
+		case EntryReturn:
+			taken = &entries[i]
+		case EntryEarn:
+			return nil
+		}
+	}
+
+	if taken == nil {
+		return nil
+	}
+
+	var member Member
+	if err := s.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&member, taken.MemberID).Error; err != nil {
+		return err
+	}
+
+	restored := Entry{
+		Type:      EntryEarn,
+		Points:    -taken.Points,
+		Remaining: -taken.Points,
+		Amount:    taken.Amount,
+		Note:      "Pembatalan retur",
+		SaleID:    taken.SaleID,
+		ReturnID:  &returnId,
+		MemberID:  member.ID,
+		CompanyID: member.CompanyID,
+	}
+
+	if program, err := NewProgramService(s.db).FindOne(member.CompanyID); err == nil && program.ExpiryDays > 0 {
+		expiresAt := time.Now().AddDate(0, 0, program.ExpiryDays)
+		restored.ExpiresAt = &expiresAt
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&restored).Error; err != nil {
+			return err
+		}
+
+		return NewPointService(tx).adjust(&member, restored.Points, restored.Amount)
+	})
+}
+
+// Expire writes off earned points whose expiry date has passed.
+func (s *PointService) Expire(memberId uint) error {
+	var entries []Entry
+	if err := s.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("member_id = ? AND remaining > 0 AND expires_at < ?", memberId, time.Now()).
+		Find(&entries).Error; err != nil {
+		return exception.DB(err)
+	}
+
+	if len(entries) == 0 {
+		return nil
+	}
+
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		var total float64
+		for _, entry := range entries {
+			if err := tx.Create(&Entry{
+				Type:      EntryExpire,
+				Points:    -entry.Remaining,
+				MemberID:  entry.MemberID,
+				CompanyID: entry.CompanyID,
+				Note:      fmt.Sprintf("Kedaluwarsa dari %s", entry.CreatedAt.Format("2006-01-02")),
+			}).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Model(&entry).Update("remaining", 0).Error; err != nil {
+				return err
+			}
+
+			total += entry.Remaining
+		}
+
+		return tx.Model(&Member{}).Where("id = ?", memberId).Update("points", gorm.Expr("points - ?", total)).Error
+	}); err != nil {
+		return exception.DB(err)
+	}
+
+	return nil
+}
+
+// consume takes points out of the earned entries, oldest expiry first.
+func (s *PointService) consume(memberId uint, points float64) error {
+	var entries []Entry
+	if err := s.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("member_id = ? AND remaining > 0", memberId).
+		Order("expires_at IS NULL, expires_at ASC, id ASC").
+		Find(&entries).Error; err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if points <= 0 {
+			break
+		}
+
+		used := math.Min(entry.Remaining, points)
+		if err := s.db.Model(&entry).Update("remaining", gorm.Expr("remaining - ?", used)).Error; err != nil {
+			return err
+		}
+
+		points -= used
+	}
+
+	return nil
+}
+
+// load returns the member with its company's program, or a nil program when
+// the company has no active loyalty program. The member stays locked until
+// the sale is saved, so concurrent sales cannot spend the same points.
+func (s *PointService) load(memberId uint) (*Member, *Program, error) {
+	var member Member
+	if err := s.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&member, memberId).Error; err != nil {
+		return nil, nil, exception.DB(err, "Member")
+	}
+
+	var program Program
+	if err := s.db.Where("company_id = ? AND status = ?", member.CompanyID, true).First(&program).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &member, nil, nil
+		}
+
+		return nil, nil, exception.DB(err)
+	}
+
+	return &member, &program, nil
+}
+
+// adjust adds to the points and total spend of a locked member and moves it
+// to the tier its new total spend qualifies for.
+func (s *PointService) adjust(member *Member, points float64, spend float64) error {
+	member.Points += points
+	member.TotalSpend += spend
+
+	return s.db.Model(&Member{}).Where("id = ?", member.ID).Updates(map[string]interface{}{
+		"points":      gorm.Expr("points + ?", points),
+		"total_spend": gorm.Expr("total_spend + ?", spend),
+		"tier_id":     s.tierFor(member.CompanyID, member.TotalSpend),
+	}).Error
+}
+
+// tierFor returns the highest tier the total spend qualifies for.
+func (s *PointService) tierFor(company uint, spend float64) *uint {
+	var tier Tier
+	if err := s.db.
+		Where("company_id = ? AND min_spend <= ?", company, spend).
+		Order("min_spend DESC").
+		First(&tier).Error; err != nil {
+		return nil
+	}
+
+	return &tier.ID
+}
+
+func (s *PointService) Using(tx *gorm.DB) *PointService {
+	db := s.db
+
+	defer func() {
+		s.db = db
+	}()
+
+	s.db = tx.WithContext(tx.Statement.Context)
+
+	return s
+}
+
+func (s *PointService) WithContext(ctx context.Context) *PointService {
+	s.db = s.db.WithContext(ctx)
+
+	return s
+}
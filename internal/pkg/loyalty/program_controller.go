@@ -0,0 +1,163 @@
+package loyalty
+
+import (
+	"abude-backend/internal/common"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ProgramController struct {
+	*common.BaseController
+	program *ProgramService
+}
+
+func NewProgramController(ctrl *common.BaseController, program *ProgramService) *ProgramController {
+	return &ProgramController{ctrl, program}
+}
+
+// @Summary Get Loyalty Program
+// @Tags Loyalty
+// @Accept json
+// @Produce json
+// @Param query query ProgramQuery true "query"
+// @Success 200 {object} Program{}
+// @Security JWT
+// @Router /api/loyalty/program [get]
+func (ctrl *ProgramController) One(ctx *fiber.Ctx) error {
+	var query ProgramQuery
+	if err := ctrl.Validation.Query(&query, ctx); err != nil {
+		return err
+	}
+
+	program, err := ctrl.program.FindOne(query.Company)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(program)
+}
+
+// @Summary Save Loyalty Program
+// @Tags Loyalty
+// @Accept json
+// @Produce json
+// @Param request body ProgramDTO true "Request Body"
+// @Success 200 {object} common.GeneralResponse{result=Program}
+// @Security JWT
+// @Router /api/loyalty/program [put]
+func (ctrl *ProgramController) Save(ctx *fiber.Ctx) error {
+	var data ProgramDTO
+	if err := ctrl.Validation.Body(&data, ctx); err != nil {
+		return err
+	}
+
+	program, err := ctrl.program.Save(data)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.GeneralResponse{
+		Message: "Program loyalitas berhasil disimpan",
+		Result:  program,
+	})
+}
+
+// @Summary Get All Loyalty Tier
+// @Tags Loyalty
+// @Accept json
+// @Produce json
+// @Param query query TierQuery false "query"
+// @Success 200 {object} common.PaginatedResponse{result=[]Tier}
+// @Security JWT
+// @Router /api/loyalty/tier [get]
+func (ctrl *ProgramController) AllTier(ctx *fiber.Ctx) error {
+	var query TierQuery
+	if err := ctrl.Validation.Query(&query, ctx); err != nil {
+		return err
+	}
+
+	result := ctrl.program.FindTiers(query)
+
+	return ctx.Status(fiber.StatusOK).JSON(result)
+}
+
+// @Summary Create Loyalty Tier
+// @Tags Loyalty
+// @Accept json
+// @Produce json
+// @Param request body TierDTO true "Request Body"
+// @Success 201 {object} common.GeneralResponse{result=Tier}
+// @Security JWT
+// @Router /api/loyalty/tier [post]
+func (ctrl *ProgramController) CreateTier(ctx *fiber.Ctx) error {
+	var data TierDTO
+	if err := ctrl.Validation.Body(&data, ctx); err != nil {
+		return err
+	}
+
+	tier, err := ctrl.program.CreateTier(data)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(common.GeneralResponse{
+		Message: "Tingkat berhasil dibuat",
+		Result:  tier,
+	})
+}
+
+// @Summary Update Loyalty Tier
+// @Tags Loyalty
+// @Accept json
+// @Produce json
+// @Param id path string true "Tier ID"
+// @Param request body TierDTO true "Request Body"
+// @Success 200 {object} common.GeneralResponse{result=Tier}
+// @Security JWT
+// @Router /api/loyalty/tier/{id} [put]
+func (ctrl *ProgramController) UpdateTier(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	var data TierDTO
+	if err := ctrl.Validation.Body(&data, ctx); err != nil {
+		return err
+	}
+
+	tier, err := ctrl.program.UpdateTier(id, data)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.GeneralResponse{
+		Message: "Tingkat berhasil diubah",
+		Result:  tier,
+	})
+}
+
+// @Summary Delete Loyalty Tier
+// @Tags Loyalty
+// @Accept json
+// @Produce json
+// @Param id path string true "Tier ID"
+// @Success 200 {object} common.GeneralResponse{result=Tier}
+// @Security JWT
+// @Router /api/loyalty/tier/{id} [delete]
+func (ctrl *ProgramController) DeleteTier(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	tier, err := ctrl.program.DeleteTier(id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.GeneralResponse{
+		Message: "Tingkat berhasil dihapus",
+		Result:  tier,
+	})
+}
@@ -0,0 +1,124 @@
+package loyalty
+
+import (
+	"abude-backend/pkg/exception"
+	"abude-backend/pkg/pagination"
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+type ProgramService struct {
+	db *gorm.DB
+}
+
+func NewProgramService(db *gorm.DB) *ProgramService {
+	return &ProgramService{db}
+}
+
+func (s *ProgramService) FindOne(company uint) (*Program, error) {
+	var program Program
+	if err := s.db.Where("company_id = ?", company).First(&program).Error; err != nil {
+		return nil, exception.DB(err, "Program loyalitas")
+	}
+
+	return &program, nil
+}
+
+// Save creates the company's program or updates it when it already exists.
+func (s *ProgramService) Save(data ProgramDTO) (*Program, error) {
+	var program Program
+	if err := s.db.Where("company_id = ?", data.Company).First(&program).Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, exception.DB(err)
+	}
+
+	program.SpendUnit = data.SpendUnit
+	program.PointValue = data.PointValue
+	program.ExpiryDays = data.ExpiryDays
+	program.Status = data.Status
+	program.CompanyID = data.Company
+
+	if err := s.db.Save(&program).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &program, nil
+}
+
+func (s *ProgramService) FindTiers(query TierQuery) *pagination.Result[Tier] {
+	result := pagination.New[Tier](query.Pagination)
+
+	db := s.db.Model(&Tier{})
+	if query.Company != 0 {
+		db.Where("company_id = ?", query.Company)
+	}
+
+	db.Order("min_spend ASC")
+
+	return result.Paginate(db)
+}
+
+func (s *ProgramService) CreateTier(data TierDTO) (*Tier, error) {
+	tier := Tier{
+		Name:      data.Name,
+		MinSpend:  data.MinSpend,
+		EarnRate:  data.EarnRate,
+		CompanyID: data.Company,
+	}
+
+	if err := s.db.Create(&tier).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &tier, nil
+}
+
+func (s *ProgramService) UpdateTier(id int, data TierDTO) (*Tier, error) {
+	var tier Tier
+	if err := s.db.First(&tier, id).Error; err != nil {
+		return nil, exception.DB(err, "Tingkat")
+	}
+
+	tier.Name = data.Name
+	tier.MinSpend = data.MinSpend
+	tier.EarnRate = data.EarnRate
+	tier.CompanyID = data.Company
+
+	if err := s.db.Save(&tier).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &tier, nil
+}
+
+func (s *ProgramService) DeleteTier(id int) (*Tier, error) {
+	var tier Tier
+	if err := s.db.First(&tier, id).Error; err != nil {
+		return nil, exception.DB(err, "Tingkat")
+	}
+
+	if err := s.db.Delete(&tier).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &tier, nil
+}
+
+func (s *ProgramService) Using(tx *gorm.DB) *ProgramService {
+	db := s.db
+
+	defer func() {
+		s.db = db
+	}()
+
+	s.db = tx.WithContext(tx.Statement.Context)
+
+	return s
+}
+
+func (s *ProgramService) WithContext(ctx context.Context) *ProgramService {
+	s.db = s.db.WithContext(ctx)
+
+	return s
+}
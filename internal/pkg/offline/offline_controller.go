@@ -0,0 +1,67 @@
+package offline
+
+import (
+	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/auth"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type OfflineController struct {
+	*common.BaseController
+	offline *OfflineService
+}
+
+func NewController(ctrl *common.BaseController, offline *OfflineService) *OfflineController {
+	return &OfflineController{ctrl, offline}
+}
+
+// @Summary Sync Offline Documents
+// @Tags Offline
+// @Accept json
+// @Produce json
+// @Param request body SyncDTO true "Request Body"
+// @Success 200 {object} common.GeneralResponse{result=[]Result}
+// @Security JWT
+// @Router /api/sync [post]
+func (ctrl *OfflineController) Sync(ctx *fiber.Ctx) error {
+	var data SyncDTO
+	if err := ctrl.Validation.Body(&data, ctx); err != nil {
+		return err
+	}
+
+	creds := auth.GetCreds(ctx.Context())
+	data.User = creds.ID
+
+	results, err := ctrl.offline.Sync(data)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.GeneralResponse{
+		Message: "Sinkronisasi selesai",
+		Result:  results,
+	})
+}
+
+// @Summary Get Changes Since Cursor
+// @Tags Offline
+// @Accept json
+// @Produce json
+// @Param query query ChangesQuery true "query"
+// @Success 200 {object} Changes{}
+// @Security JWT
+// @Router /api/sync/changes [get]
+func (ctrl *OfflineController) Changes(ctx *fiber.Ctx) error {
+	var query ChangesQuery
+	if err := ctrl.Validation.Query(&query, ctx); err != nil {
+		return err
+	}
+
+	changes, err := ctrl.offline.GetChanges(query)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(changes)
+}
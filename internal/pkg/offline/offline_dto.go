@@ -0,0 +1,25 @@
+package offline
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type DocumentDTO struct {
+	ID        string          `json:"id" validate:"required,uuid"`
+	Type      string          `json:"type" validate:"required,oneof=sale purchase expense" enums:"sale,purchase,expense"`
+	CreatedAt time.Time       `json:"createdAt" validate:"required" format:"date-time"`
+	Data      json.RawMessage `json:"data" validate:"required" swaggertype:"object"` // Body of the matching create endpoint
+}
+
+type SyncDTO struct {
+	Outlet    uint          `json:"outlet" validate:"required,exist=outlets"`
+	Documents []DocumentDTO `json:"documents" validate:"required,min=1,max=500,dive"`
+
+	User uint `json:"-"`
+}
+
+type ChangesQuery struct {
+	Company uint      `query:"company" validate:"required"`
+	Cursor  time.Time `query:"cursor" format:"date-time"` // Leave empty for a full download
+}
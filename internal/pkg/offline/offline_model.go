@@ -0,0 +1,61 @@
+package offline
+
+import (
+	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/attendances/shift"
+	"abude-backend/internal/pkg/inventories/product"
+	"abude-backend/internal/pkg/outlet"
+	"abude-backend/internal/pkg/user"
+	"time"
+)
+
+const (
+	TypeSale     = "sale"
+	TypePurchase = "purchase"
+	TypeExpense  = "expense"
+)
+
+const (
+	ResultCreated   = "created"
+	ResultDuplicate = "duplicate"
+	ResultRejected  = "rejected"
+)
+
+// Document maps a client generated UUID to the document created from it,
+// so a batch can be sent again without creating duplicates.
+type Document struct {
+	common.BaseModel
+	ClientID   string    `json:"id" gorm:"type:varchar(36);uniqueIndex"`
+	Type       string    `json:"type" gorm:"type:enum('sale','purchase','expense')" enums:"sale,purchase,expense"`
+	DocumentID uint      `json:"document"`
+	ClientDate time.Time `json:"clientDate"`
+
+	Outlet   *outlet.Outlet `json:"-" gorm:"constraint:OnDelete:CASCADE;"`
+	OutletID uint           `json:"-"`
+
+	User   *user.User `json:"-" gorm:"constraint:OnDelete:SET NULL;"`
+	UserID *uint      `json:"-"`
+}
+
+func (Document) TableName() string {
+	return "sync_documents"
+}
+
+type Result struct {
+	ID       string            `json:"id"`
+	Type     string            `json:"type"`
+	Status   string            `json:"status" enums:"created,duplicate,rejected"`
+	Document uint              `json:"document,omitempty"`
+	Reason   string            `json:"reason,omitempty"`
+	Errors   map[string]string `json:"errors,omitempty"`
+}
+
+// Changes holds the records changed since the client's cursor, along with the
+// IDs of the products deleted since then for the client to drop. The returned
+// cursor is passed back on the next request.
+type Changes struct {
+	Cursor          time.Time         `json:"cursor"`
+	Products        []product.Product `json:"products"`
+	DeletedProducts []uint            `json:"deletedProducts"`
+	Shifts          []shift.Shift     `json:"shifts"`
+}
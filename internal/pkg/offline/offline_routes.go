@@ -0,0 +1,11 @@
+package offline
+
+import "abude-backend/internal/common"
+
+func LoadRoutes(r *common.Router) {
+	offlineService := NewService(r.DB, r.Controller.Validation)
+	offlineHandler := NewController(r.Controller, offlineService)
+
+	r.Router.Post("/sync", r.Auth(1), offlineHandler.Sync)
+	r.Router.Get("/sync/changes", r.Auth(1), offlineHandler.Changes)
+}
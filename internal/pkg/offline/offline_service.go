@@ -0,0 +1,290 @@
+package offline
+
+import (
+	"abude-backend/internal/pkg/attendances/shift"
+	"abude-backend/internal/pkg/inventories/product"
+	"abude-backend/internal/pkg/outlet"
+	"abude-backend/internal/pkg/transactions/expense"
+	"abude-backend/internal/pkg/transactions/purchase"
+	"abude-backend/internal/pkg/transactions/sale"
+	"abude-backend/pkg/exception"
+	"abude-backend/pkg/validation"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// errSynced is returned when another request saved the document first.
+var errSynced = errors.New("document already synced")
+
+type OfflineService struct {
+	db         *gorm.DB
+	validation *validation.Validation
+}
+
+func NewService(db *gorm.DB, validation *validation.Validation) *OfflineService {
+	return &OfflineService{db, validation}
+}
+
+// Sync applies a batch of documents created offline in the order they were
+// created. Each document is saved in its own transaction, so one bad
+// document does not hold back the rest of the batch.
+func (s *OfflineService) Sync(data SyncDTO) ([]Result, error) {
+	var outlet outlet.Outlet
+	if err := s.db.First(&outlet, data.Outlet).Error; err != nil {
+		return nil, exception.DB(err, "Outlet")
+	}
+
+	documents := data.Documents
+	sort.SliceStable(documents, func(i, j int) bool {
+		return documents[i].CreatedAt.Before(documents[j].CreatedAt)
+	})
+
+	var results []Result
+	for _, document := range documents {
+		result := Result{ID: document.ID, Type: document.Type}
+
+		synced, err := s.synced(document.ID)
+		if err != nil {
+			return nil, exception.DB(err)
+		}
+
+		if synced != nil {
+			result.Status = ResultDuplicate
+			result.Document = synced.DocumentID
+			results = append(results, result)
+			continue
+		}
+
+		id, err := s.apply(document, &outlet, data.User)
+
+		var httpError exception.HttpError
+		switch {
+		case err == nil:
+			result.Status = ResultCreated
+			result.Document = id
+		case errors.Is(err, errSynced):
+			// Sent again while the first copy was being saved
+			synced, err := s.synced(document.ID)
+			if err != nil {
+				return nil, exception.DB(err)
+			}
+
+			if synced == nil {
+				return nil, exception.DB(errSynced)
+			}
+
+			result.Status = ResultDuplicate
+			result.Document = synced.DocumentID
+		case errors.As(err, &httpError):
+			result.Status = ResultRejected
+			result.Reason = httpError.Message
+			result.Errors = httpError.Errors
+		default:
+			log.Printf("offline sync: document %s: %v", document.ID, err)
+
+			result.Status = ResultRejected
+			result.Reason = "Dokumen gagal disimpan, coba kirim ulang"
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// synced returns the record of a document already saved, or nil.
+func (s *OfflineService) synced(clientId string) (*Document, error) {
+	var synced Document
+	err := s.db.Where("client_id = ?", clientId).First(&synced).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &synced, nil
+}
+
+// apply saves a document. Its client ID is claimed first, so a copy sent
+// concurrently waits on the unique key and fails with errSynced.
+func (s *OfflineService) apply(document DocumentDTO, outlet *outlet.Outlet, user uint) (uint, error) {
+	var id uint
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		synced := Document{
+			ClientID:   document.ID,
+			Type:       document.Type,
+			ClientDate: document.CreatedAt,
+			OutletID:   outlet.ID,
+			UserID:     &user,
+		}
+
+		if err := tx.Create(&synced).Error; err != nil {
+			if duplicate(tx, err) {
+				return errSynced
+			}
+
+			return err
+		}
+
+		switch document.Type {
+		case TypeSale:
+			var data sale.SaleDTO
+			if err := s.decode(document, &data); err != nil {
+				return err
+			}
+
+			data.Source = "outlet"
+			data.SourceID = outlet.ID
+			data.User = user
+			if data.Date.IsZero() {
+				data.Date = document.CreatedAt
+			}
+
+			if err := s.validation.ValidateStruct(&data); err != nil {
+				return err
+			}
+
+			record, err := sale.NewService(tx).Create(data)
+			if err != nil {
+				return err
+			}
+			id = record.ID
+		case TypePurchase:
+			var data purchase.PurchaseDTO
+			if err := s.decode(document, &data); err != nil {
+				return err
+			}
+
+			data.Source = "outlet"
+			data.SourceID = outlet.ID
+			data.User = user
+			if data.Date.IsZero() {
+				data.Date = document.CreatedAt
+			}
+
+			if err := s.validation.ValidateStruct(&data); err != nil {
+				return err
+			}
+
+			record, err := purchase.NewService(tx).Create(data)
+			if err != nil {
+				return err
+			}
+			id = record.ID
+		case TypeExpense:
+			var data expense.ExpenseDTO
+			if err := s.decode(document, &data); err != nil {
+				return err
+			}
+
+			data.Outlet = outlet.ID
+			data.Company = outlet.CompanyID
+			if data.Date.IsZero() {
+				data.Date = document.CreatedAt
+			}
+
+			if err := s.validation.ValidateStruct(&data); err != nil {
+				return err
+			}
+
+			record, err := expense.NewService(tx).Create(data)
+			if err != nil {
+				return err
+			}
+			id = record.ID
+		default:
+			return exception.BadRequest("Tipe dokumen tidak valid")
+		}
+
+		return tx.Model(&synced).Update("document_id", id).Error
+	})
+
+	return id, err
+}
+
+// duplicate reports whether the error violates a unique index.
+func duplicate(db *gorm.DB, err error) bool {
+	if translator, ok := db.Dialector.(gorm.ErrorTranslator); ok {
+		err = translator.Translate(err)
+	}
+
+	return errors.Is(err, gorm.ErrDuplicatedKey)
+}
+
+func (s *OfflineService) decode(document DocumentDTO, data interface{}) error {
+	if err := json.Unmarshal(document.Data, data); err != nil {
+		return exception.Validation(map[string]string{
+			"data": "Format dokumen tidak valid",
+		})
+	}
+
+	return nil
+}
+
+// GetChanges returns the products and shifts of a company changed after the
+// cursor, for refreshing the client's local cache. Products soft deleted after
+// the cursor are returned as deletions.
+func (s *OfflineService) GetChanges(query ChangesQuery) (*Changes, error) {
+	changes := Changes{
+		Cursor:          time.Now(),
+		Products:        []product.Product{},
+		DeletedProducts: []uint{},
+		Shifts:          []shift.Shift{},
+	}
+
+	products := s.db.Preload("Category").Preload("Ingredients").Where("company_id = ? AND updated_at < ?", query.Company, changes.Cursor)
+	shifts := s.db.Where("company_id = ? AND updated_at < ?", query.Company, changes.Cursor)
+	if !query.Cursor.IsZero() {
+		products.Where("updated_at >= ?", query.Cursor)
+		shifts.Where("updated_at >= ?", query.Cursor)
+	}
+
+	if err := products.Order("updated_at ASC").Find(&changes.Products).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	if err := shifts.Order("updated_at ASC").Find(&changes.Shifts).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	// A full download holds no deleted products to drop
+	if query.Cursor.IsZero() {
+		return &changes, nil
+	}
+
+	if err := s.db.Unscoped().Model(&product.Product{}).
+		Where("company_id = ? AND deleted_at >= ? AND deleted_at < ?", query.Company, query.Cursor, changes.Cursor).
+		Order("deleted_at ASC").
+		Pluck("id", &changes.DeletedProducts).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &changes, nil
+}
+
+func (s *OfflineService) Using(tx *gorm.DB) *OfflineService {
+	db := s.db
+
+	defer func() {
+		s.db = db
+	}()
+
+	s.db = tx.WithContext(tx.Statement.Context)
+
+	return s
+}
+
+func (s *OfflineService) WithContext(ctx context.Context) *OfflineService {
+	s.db = s.db.WithContext(ctx)
+
+	return s
+}
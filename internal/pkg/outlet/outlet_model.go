@@ -4,6 +4,8 @@ import (
 	"abude-backend/internal/common"
 	"abude-backend/internal/pkg/company"
 	"abude-backend/internal/pkg/employee"
+
+	"gorm.io/gorm"
 )
 
 type OutletCount struct {
@@ -14,6 +16,8 @@ type OutletCount struct {
 
 type Outlet struct {
 	common.BaseModel
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
 	Name      string  `json:"name" gorm:"type:varchar(100)"`
 	Address   string  `json:"address" gorm:"type:varchar(255)"`
 	Latitude  float64 `json:"latitude"`
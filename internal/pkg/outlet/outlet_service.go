@@ -54,7 +54,7 @@ func (s *OutletService) FindAll(query OutletQuery) *pagination.Result[Outlet] {
 		db.Where("id IN (?)", s.db.
 			Select("outlet_employees.outlet_id").
 			Table("outlet_employees").
-			Joins("INNER JOIN employees ON employees.id=outlet_employees.employee_id").
+			Joins("INNER JOIN employees ON employees.id=outlet_employees.employee_id AND employees.deleted_at IS NULL").
 			Where("employees.user_id = ?", query.Employee),
 		)
 	}
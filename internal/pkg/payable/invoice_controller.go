@@ -0,0 +1,149 @@
+package payable
+
+import (
+	"abude-backend/internal/common"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type InvoiceController struct {
+	*common.BaseController
+	invoice *InvoiceService
+}
+
+func NewInvoiceController(ctrl *common.BaseController, invoice *InvoiceService) *InvoiceController {
+	return &InvoiceController{ctrl, invoice}
+}
+
+// @Summary Get One Supplier Invoice
+// @Tags Payables
+// @Accept json
+// @Produce json
+// @Param id path string true "Invoice ID"
+// @Success 200 {object} Invoice{}
+// @Security JWT
+// @Router /api/payable/invoice/{id} [get]
+func (ctrl *InvoiceController) One(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	invoice, err := ctrl.invoice.FindOne(id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(invoice)
+}
+
+// @Summary Get All Supplier Invoice
+// @Tags Payables
+// @Accept json
+// @Produce json
+// @Param query query InvoiceQuery false "query"
+// @Success 200 {object} common.PaginatedResponse{result=[]Invoice}
+// @Security JWT
+// @Router /api/payable/invoice [get]
+func (ctrl *InvoiceController) All(ctx *fiber.Ctx) error {
+	var query InvoiceQuery
+	if err := ctrl.Validation.Query(&query, ctx); err != nil {
+		return err
+	}
+
+	result := ctrl.invoice.FindAll(query)
+
+	return ctx.Status(fiber.StatusOK).JSON(result)
+}
+
+// @Summary Create Supplier Invoice
+// @Tags Payables
+// @Accept json
+// @Produce json
+// @Param request body InvoiceDTO true "Request Body"
+// @Success 201 {object} common.GeneralResponse{result=Invoice}
+// @Security JWT
+// @Router /api/payable/invoice [post]
+func (ctrl *InvoiceController) Create(ctx *fiber.Ctx) error {
+	var data InvoiceDTO
+	if err := ctrl.Validation.Body(&data, ctx); err != nil {
+		return err
+	}
+
+	invoice, err := ctrl.invoice.Create(data)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(common.GeneralResponse{
+		Message: "Faktur berhasil dibuat",
+		Result:  invoice,
+	})
+}
+
+// @Summary Cancel Supplier Invoice
+// @Tags Payables
+// @Accept json
+// @Produce json
+// @Param id path string true "Invoice ID"
+// @Success 200 {object} common.BasicResponse{}
+// @Security JWT
+// @Router /api/payable/invoice/{id}/cancel [patch]
+func (ctrl *InvoiceController) Cancel(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := ctrl.invoice.Cancel(id); err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.BasicResponse{
+		Message: "Faktur berhasil dibatalkan",
+	})
+}
+
+// @Summary Get Supplier Balances
+// @Tags Payables
+// @Accept json
+// @Produce json
+// @Param query query BalanceQuery false "query"
+// @Success 200 {object} []SupplierBalance
+// @Security JWT
+// @Router /api/payable/balance [get]
+func (ctrl *InvoiceController) GetBalances(ctx *fiber.Ctx) error {
+	var query BalanceQuery
+	if err := ctrl.Validation.Query(&query, ctx); err != nil {
+		return err
+	}
+
+	result, err := ctrl.invoice.GetBalances(query)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(result)
+}
+
+// @Summary Get Payables Aging
+// @Tags Payables
+// @Accept json
+// @Produce json
+// @Param query query AgingQuery true "query"
+// @Success 200 {object} []Aging
+// @Security JWT
+// @Router /api/payable/aging [get]
+func (ctrl *InvoiceController) GetAging(ctx *fiber.Ctx) error {
+	var query AgingQuery
+	if err := ctrl.Validation.Query(&query, ctx); err != nil {
+		return err
+	}
+
+	result, err := ctrl.invoice.GetAging(query)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(result)
+}
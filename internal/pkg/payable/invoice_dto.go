@@ -0,0 +1,37 @@
+package payable
+
+import (
+	"abude-backend/pkg/pagination"
+	"time"
+)
+
+type InvoiceDTO struct {
+	Number    string    `json:"number" form:"number" validate:"omitempty"`
+	Note      string    `json:"note" form:"note" validate:"omitempty"`
+	Date      time.Time `json:"date" form:"date" validate:"omitempty" format:"date-time"`
+	DueDate   time.Time `json:"dueDate" form:"dueDate" validate:"required" format:"date-time"`
+	Purchases []uint    `json:"purchases" form:"purchases" validate:"required,min=1"` // Purchase IDs
+	Supplier  uint      `json:"supplier" form:"supplier" validate:"required,exist=suppliers"`
+	Company   uint      `json:"company" form:"company" validate:"required,exist=companies"`
+}
+
+type InvoiceQuery struct {
+	pagination.Pagination
+	Company   uint      `query:"company"`
+	Supplier  uint      `query:"supplier"`
+	Status    []string  `query:"status" enums:"unpaid,partial,paid,canceled"`
+	Overdue   bool      `query:"overdue"`
+	StartDate time.Time `query:"startDate" format:"date-time"`
+	EndDate   time.Time `query:"endDate" format:"date-time"`
+}
+
+type BalanceQuery struct {
+	Company  uint `query:"company"`
+	Supplier uint `query:"supplier"`
+}
+
+type AgingQuery struct {
+	Company  uint      `query:"company" validate:"required"`
+	Supplier uint      `query:"supplier"`
+	Date     time.Time `query:"date" format:"date-time"` // Reference date, defaults to today
+}
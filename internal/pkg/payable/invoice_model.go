@@ -0,0 +1,102 @@
+package payable
+
+import (
+	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/company"
+	"abude-backend/internal/pkg/sequence"
+	"abude-backend/internal/pkg/supplier"
+	"abude-backend/internal/pkg/transactions/purchase"
+	"abude-backend/internal/pkg/user"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	StatusUnpaid   = "unpaid"
+	StatusPartial  = "partial"
+	StatusPaid     = "paid"
+	StatusCanceled = "canceled"
+)
+
+type Invoice struct {
+	common.BaseModel
+	user.WithEditor
+	Code    string    `json:"code" gorm:"type:varchar(50)"`
+	Number  string    `json:"number" gorm:"type:varchar(100)"`
+	Note    string    `json:"note" gorm:"type:varchar(150)"`
+	Total   float64   `json:"total"`
+	Paid    float64   `json:"paid"`
+	Status  string    `json:"status" gorm:"type:enum('unpaid','partial','paid','canceled')" enums:"unpaid,partial,paid,canceled"`
+	Date    time.Time `json:"date"`
+	DueDate time.Time `json:"dueDate"`
+
+	Purchases []purchase.Purchase `json:"purchases,omitempty" gorm:"many2many:supplier_invoice_purchases;constraint:OnDelete:CASCADE;"`
+
+	Supplier   *supplier.Supplier `json:"supplier,omitempty" gorm:"constraint:OnDelete:RESTRICT;"`
+	SupplierID uint               `json:"-"`
+
+	Company   *company.Company `json:"company,omitempty" gorm:"constraint:OnDelete:CASCADE;"`
+	CompanyID uint             `json:"-"`
+}
+
+func (Invoice) TableName() string {
+	return "supplier_invoices"
+}
+
+// Outstanding returns the amount still owed on the invoice.
+func (invoice *Invoice) Outstanding() float64 {
+	return invoice.Total - invoice.Paid
+}
+
+func (invoice *Invoice) BeforeCreate(tx *gorm.DB) error {
+	if invoice.Code != "" {
+		return nil
+	}
+
+	code, err := sequence.Next(tx, sequence.TypeInvoice, invoice.CompanyID, 0, time.Now())
+	if err != nil {
+		return err
+	}
+
+	invoice.Code = code
+
+	return nil
+}
+
+// SupplierBalance is the outstanding payable of a single supplier.
+type SupplierBalance struct {
+	Supplier    supplier.Supplier `json:"supplier" gorm:"embedded"`
+	Total       float64           `json:"total"`
+	Paid        float64           `json:"paid"`
+	Outstanding float64           `json:"outstanding"`
+}
+
+// Aging buckets the outstanding payable of a supplier by days past due.
+type Aging struct {
+	Supplier supplier.Supplier `json:"supplier"`
+	Current  float64           `json:"current"`
+	Days30   float64           `json:"days30"`
+	Days60   float64           `json:"days60"`
+	Days90   float64           `json:"days90"`
+	Over90   float64           `json:"over90"`
+	Total    float64           `json:"total"`
+}
+
+// Add puts the amount into the bucket matching the days past due.
+func (aging *Aging) Add(overdue int, amount float64) {
+	switch {
+	case overdue <= 0:
+		aging.Current += amount
+	case overdue <= 30:
+		aging.Days30 += amount
+	case overdue <= 60:
+		aging.Days60 += amount
+	case overdue <= 90:
+		aging.Days90 += amount
+	default:
+		aging.Over90 += amount
+	}
+
+	aging.Total += amount
+}
@@ -0,0 +1,257 @@
+package payable
+
+import (
+	"abude-backend/internal/pkg/supplier"
+	"abude-backend/internal/pkg/transactions/purchase"
+	"abude-backend/pkg/exception"
+	"abude-backend/pkg/pagination"
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type InvoiceService struct {
+	db *gorm.DB
+}
+
+func NewInvoiceService(db *gorm.DB) *InvoiceService {
+	return &InvoiceService{db}
+}
+
+func (s *InvoiceService) FindOne(id int) (*Invoice, error) {
+	var invoice Invoice
+	if err := s.db.Preload("Supplier").Preload("Company").Preload("Purchases").First(&invoice, id).Error; err != nil {
+		return nil, exception.DB(err, "Faktur")
+	}
+
+	return &invoice, nil
+}
+
+func (s *InvoiceService) FindAll(query InvoiceQuery) *pagination.Result[Invoice] {
+	result := pagination.New[Invoice](query.Pagination)
+
+	db := s.db.Model(&Invoice{}).Preload("Supplier")
+	if query.Company != 0 {
+		db.Where("company_id = ?", query.Company)
+	}
+
+	if query.Supplier != 0 {
+		db.Where("supplier_id = ?", query.Supplier)
+	}
+
+	if len(query.Status) > 0 {
+		db.Where("status IN (?)", query.Status)
+	}
+
+	if query.Overdue {
+		db.Where("due_date < ? AND status IN (?)", time.Now(), []string{StatusUnpaid, StatusPartial})
+	}
+
+	if !query.StartDate.IsZero() {
+		db.Where("date >= ?", query.StartDate)
+	}
+
+	if !query.EndDate.IsZero() {
+		db.Where("date <= ?", query.EndDate)
+	}
+
+	db.Order("due_date ASC")
+
+	return result.Paginate(db)
+}
+
+func (s *InvoiceService) Create(data InvoiceDTO) (*Invoice, error) {
+	invoice := Invoice{
+		Number:     data.Number,
+		Note:       data.Note,
+		Status:     StatusUnpaid,
+		Date:       time.Now(),
+		DueDate:    data.DueDate,
+		SupplierID: data.Supplier,
+		CompanyID:  data.Company,
+	}
+
+	if !data.Date.IsZero() {
+		invoice.Date = data.Date
+	}
+
+	if invoice.DueDate.Before(invoice.Date) {
+		return nil, exception.Validation(map[string]string{
+			"dueDate": "Jatuh tempo tidak boleh sebelum tanggal faktur",
+		})
+	}
+
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		// The purchases stay locked so they cannot be invoiced twice at once
+		var purchases []purchase.Purchase
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id IN (?)", data.Purchases).Find(&purchases).Error; err != nil {
+			return err
+		}
+
+		if len(purchases) != len(data.Purchases) {
+			return exception.NotFound("Pembelian")
+		}
+
+		var invoiced int64
+		if err := tx.Table("supplier_invoice_purchases").
+			Joins("INNER JOIN supplier_invoices ON supplier_invoices.id = supplier_invoice_purchases.invoice_id").
+			Where("supplier_invoice_purchases.purchase_id IN (?) AND supplier_invoices.status != ?", data.Purchases, StatusCanceled).
+			Count(&invoiced).Error; err != nil {
+			return err
+		}
+
+		if invoiced > 0 {
+			return exception.BadRequest("Pembelian sudah memiliki faktur")
+		}
+
+		var owners []struct {
+			PurchaseID uint
+			CompanyID  uint
+		}
+
+		if err := tx.Table("outlet_purchases").
+			Select("outlet_purchases.purchase_id, outlets.company_id").
+			Joins("INNER JOIN outlets ON outlets.id = outlet_purchases.outlet_id").
+			Where("outlet_purchases.purchase_id IN (?)", data.Purchases).
+			Scan(&owners).Error; err != nil {
+			return err
+		}
+
+		companies := make(map[uint]uint)
+		for _, v := range owners {
+			companies[v.PurchaseID] = v.CompanyID
+		}
+
+		for _, v := range purchases {
+			if v.Type != purchase.TypeCredit || v.Status == purchase.StatusCanceled {
+				return exception.BadRequest(fmt.Sprintf("Pembelian '%s' bukan pembelian kredit yang aktif", v.Code))
+			}
+
+			if v.SupplierID == nil || *v.SupplierID != data.Supplier {
+				return exception.BadRequest(fmt.Sprintf("Pembelian '%s' bukan dari supplier ini", v.Code))
+			}
+
+			if companies[v.ID] != data.Company {
+				return exception.BadRequest(fmt.Sprintf("Pembelian '%s' bukan milik perusahaan ini", v.Code))
+			}
+
+			invoice.Total += v.Total
+		}
+
+		invoice.Purchases = purchases
+
+		return tx.Omit("Purchases.*").Create(&invoice).Error
+	}); err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &invoice, nil
+}
+
+func (s *InvoiceService) Cancel(id int) error {
+	return exception.DB(s.db.Transaction(func(tx *gorm.DB) error {
+		// Locked against payments allocated to it meanwhile
+		var invoice Invoice
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&invoice, id).Error; err != nil {
+			return exception.DB(err, "Faktur")
+		}
+
+		if invoice.Status == StatusCanceled {
+			return exception.BadRequest("Status tidak berubah")
+		}
+
+		if invoice.Paid > 0 {
+			return exception.BadRequest("Faktur yang sudah dibayar tidak dapat dibatalkan")
+		}
+
+		return tx.Model(&invoice).Update("status", StatusCanceled).Error
+	}))
+}
+
+func (s *InvoiceService) GetBalances(query BalanceQuery) ([]SupplierBalance, error) {
+	var balances []SupplierBalance
+
+	db := s.db.Table("supplier_invoices").
+		Select("suppliers.*, SUM(supplier_invoices.total) AS total, SUM(supplier_invoices.paid) AS paid, SUM(supplier_invoices.total - supplier_invoices.paid) AS outstanding").
+		Joins("INNER JOIN suppliers ON suppliers.id = supplier_invoices.supplier_id").
+		Where("supplier_invoices.status != ?", StatusCanceled).
+		Group("supplier_invoices.supplier_id").
+		Order("outstanding DESC")
+
+	if query.Company != 0 {
+		db.Where("supplier_invoices.company_id = ?", query.Company)
+	}
+
+	if query.Supplier != 0 {
+		db.Where("supplier_invoices.supplier_id = ?", query.Supplier)
+	}
+
+	if err := db.Find(&balances).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return balances, nil
+}
+
+func (s *InvoiceService) GetAging(query AgingQuery) ([]Aging, error) {
+	date := query.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	var invoices []Invoice
+	db := s.db.Preload("Supplier").
+		Where("company_id = ? AND status IN (?) AND date <= ?", query.Company, []string{StatusUnpaid, StatusPartial}, date).
+		Order("supplier_id ASC, due_date ASC")
+
+	if query.Supplier != 0 {
+		db.Where("supplier_id = ?", query.Supplier)
+	}
+
+	if err := db.Find(&invoices).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	var result []Aging
+	index := make(map[uint]int)
+	for _, invoice := range invoices {
+		i, ok := index[invoice.SupplierID]
+		if !ok {
+			var supplier supplier.Supplier
+			if invoice.Supplier != nil {
+				supplier = *invoice.Supplier
+			}
+
+			result = append(result, Aging{Supplier: supplier})
+			i = len(result) - 1
+			index[invoice.SupplierID] = i
+		}
+
+		overdue := int(math.Floor(date.Sub(invoice.DueDate).Hours() / 24))
+		result[i].Add(overdue, invoice.Outstanding())
+	}
+
+	return result, nil
+}
+
+func (s *InvoiceService) Using(tx *gorm.DB) *InvoiceService {
+	db := s.db
+
+	defer func() {
+		s.db = db
+	}()
+
+	s.db = tx.WithContext(tx.Statement.Context)
+
+	return s
+}
+
+func (s *InvoiceService) WithContext(ctx context.Context) *InvoiceService {
+	s.db = s.db.WithContext(ctx)
+
+	return s
+}
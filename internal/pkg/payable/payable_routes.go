@@ -0,0 +1,24 @@
+package payable
+
+import (
+	"abude-backend/internal/common"
+)
+
+func LoadRoutes(r *common.Router) {
+	invoiceService := NewInvoiceService(r.DB)
+	paymentService := NewPaymentService(r.DB)
+
+	invoiceHandler := NewInvoiceController(r.Controller, invoiceService)
+	r.Router.Get("/payable/balance", r.Auth(2), invoiceHandler.GetBalances)
+	r.Router.Get("/payable/aging", r.Auth(2), invoiceHandler.GetAging)
+	r.Router.Get("/payable/invoice", r.Auth(1), invoiceHandler.All)
+	r.Router.Get("/payable/invoice/:id", r.Auth(1), invoiceHandler.One)
+	r.Router.Post("/payable/invoice", r.Auth(1), invoiceHandler.Create)
+	r.Router.Patch("/payable/invoice/:id/cancel", r.Auth(2), invoiceHandler.Cancel)
+
+	paymentHandler := NewPaymentController(r.Controller, paymentService)
+	r.Router.Get("/payable/payment", r.Auth(1), paymentHandler.All)
+	r.Router.Get("/payable/payment/:id", r.Auth(1), paymentHandler.One)
+	r.Router.Post("/payable/payment", r.Auth(1), paymentHandler.Create)
+	r.Router.Patch("/payable/payment/:id/cancel", r.Auth(2), paymentHandler.Cancel)
+}
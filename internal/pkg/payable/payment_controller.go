@@ -0,0 +1,105 @@
+package payable
+
+import (
+	"abude-backend/internal/common"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PaymentController struct {
+	*common.BaseController
+	payment *PaymentService
+}
+
+func NewPaymentController(ctrl *common.BaseController, payment *PaymentService) *PaymentController {
+	return &PaymentController{ctrl, payment}
+}
+
+// @Summary Get One Supplier Payment
+// @Tags Payables
+// @Accept json
+// @Produce json
+// @Param id path string true "Payment ID"
+// @Success 200 {object} Payment{}
+// @Security JWT
+// @Router /api/payable/payment/{id} [get]
+func (ctrl *PaymentController) One(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	payment, err := ctrl.payment.FindOne(id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(payment)
+}
+
+// @Summary Get All Supplier Payment
+// @Tags Payables
+// @Accept json
+// @Produce json
+// @Param query query PaymentQuery false "query"
+// @Success 200 {object} common.PaginatedResponse{result=[]Payment}
+// @Security JWT
+// @Router /api/payable/payment [get]
+func (ctrl *PaymentController) All(ctx *fiber.Ctx) error {
+	var query PaymentQuery
+	if err := ctrl.Validation.Query(&query, ctx); err != nil {
+		return err
+	}
+
+	result := ctrl.payment.FindAll(query)
+
+	return ctx.Status(fiber.StatusOK).JSON(result)
+}
+
+// @Summary Create Supplier Payment
+// @Tags Payables
+// @Accept json
+// @Produce json
+// @Param request body PaymentDTO true "Request Body"
+// @Success 201 {object} common.GeneralResponse{result=Payment}
+// @Security JWT
+// @Router /api/payable/payment [post]
+func (ctrl *PaymentController) Create(ctx *fiber.Ctx) error {
+	var data PaymentDTO
+	if err := ctrl.Validation.Body(&data, ctx); err != nil {
+		return err
+	}
+
+	payment, err := ctrl.payment.Create(data)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(common.GeneralResponse{
+		Message: "Pembayaran berhasil dibuat",
+		Result:  payment,
+	})
+}
+
+// @Summary Cancel Supplier Payment
+// @Tags Payables
+// @Accept json
+// @Produce json
+// @Param id path string true "Payment ID"
+// @Success 200 {object} common.BasicResponse{}
+// @Security JWT
+// @Router /api/payable/payment/{id}/cancel [patch]
+func (ctrl *PaymentController) Cancel(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := ctrl.payment.Cancel(id); err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.BasicResponse{
+		Message: "Pembayaran berhasil dibatalkan",
+	})
+}
@@ -0,0 +1,31 @@
+package payable
+
+import (
+	"abude-backend/pkg/pagination"
+	"time"
+)
+
+type AllocationDTO struct {
+	Invoice uint    `json:"invoice" form:"invoice" validate:"required,exist=supplier_invoices"`
+	Amount  float64 `json:"amount" form:"amount" validate:"required,gt=0"`
+}
+
+type PaymentDTO struct {
+	Note        string          `json:"note" form:"note" validate:"omitempty"`
+	Method      string          `json:"method" form:"method" validate:"required,oneof=cash transfer" enums:"cash,transfer"`
+	Date        time.Time       `json:"date" form:"date" validate:"omitempty" format:"date-time"`
+	Allocations []AllocationDTO `json:"allocations" form:"allocations" validate:"required,min=1,dive,required"`
+	Supplier    uint            `json:"supplier" form:"supplier" validate:"required,exist=suppliers"`
+	Company     uint            `json:"company" form:"company" validate:"required,exist=companies"`
+	Outlet      *uint           `json:"outlet" form:"outlet" validate:"omitempty,exist=outlets"`
+}
+
+type PaymentQuery struct {
+	pagination.Pagination
+	Company   uint      `query:"company"`
+	Supplier  uint      `query:"supplier"`
+	Outlet    uint      `query:"outlet"`
+	Status    []string  `query:"status" enums:"paid,canceled"`
+	StartDate time.Time `query:"startDate" format:"date-time"`
+	EndDate   time.Time `query:"endDate" format:"date-time"`
+}
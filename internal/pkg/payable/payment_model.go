@@ -0,0 +1,97 @@
+package payable
+
+import (
+	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/accounts/journal"
+	"abude-backend/internal/pkg/company"
+	"abude-backend/internal/pkg/outlet"
+	"abude-backend/internal/pkg/sequence"
+	"abude-backend/internal/pkg/supplier"
+	"abude-backend/internal/pkg/user"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	MethodCash     = "cash"
+	MethodTransfer = "transfer"
+)
+
+type Allocation struct {
+	common.BaseModel
+	Amount float64 `json:"amount"`
+
+	Invoice   *Invoice `json:"invoice,omitempty" gorm:"constraint:OnDelete:RESTRICT;"`
+	InvoiceID uint     `json:"-"`
+
+	Payment   *Payment `json:"-" gorm:"constraint:OnDelete:CASCADE;"`
+	PaymentID uint     `json:"-"`
+}
+
+func (Allocation) TableName() string {
+	return "supplier_payment_allocations"
+}
+
+type Payment struct {
+	common.BaseModel
+	user.WithEditor
+	Code   string    `json:"code" gorm:"type:varchar(50)"`
+	Note   string    `json:"note" gorm:"type:varchar(150)"`
+	Amount float64   `json:"amount"`
+	Method string    `json:"method" gorm:"type:enum('cash','transfer')" enums:"cash,transfer"`
+	Status string    `json:"status" gorm:"type:enum('paid','canceled')" enums:"paid,canceled"`
+	Date   time.Time `json:"date"`
+
+	Allocations []Allocation `json:"allocations" gorm:"constraint:OnDelete:CASCADE;"`
+
+	Supplier   *supplier.Supplier `json:"supplier,omitempty" gorm:"constraint:OnDelete:RESTRICT;"`
+	SupplierID uint               `json:"-"`
+
+	Company   *company.Company `json:"company,omitempty" gorm:"constraint:OnDelete:CASCADE;"`
+	CompanyID uint             `json:"-"`
+
+	Outlet   *outlet.Outlet `json:"outlet,omitempty" gorm:"constraint:OnDelete:SET NULL;"`
+	OutletID *uint          `json:"-"`
+}
+
+func (Payment) TableName() string {
+	return "supplier_payments"
+}
+
+func (payment *Payment) BeforeCreate(tx *gorm.DB) error {
+	if payment.Code != "" {
+		return nil
+	}
+
+	var outlet uint
+	if payment.OutletID != nil {
+		outlet = *payment.OutletID
+	}
+
+	code, err := sequence.Next(tx, sequence.TypePayment, payment.CompanyID, outlet, time.Now())
+	if err != nil {
+		return err
+	}
+
+	payment.Code = code
+
+	return nil
+}
+
+// posting books the money paid against the payable of the invoices.
+func (payment *Payment) posting() journal.Posting {
+	return journal.Posting{
+		Company:     payment.CompanyID,
+		Outlet:      payment.OutletID,
+		User:        payment.EditorID,
+		Date:        payment.Date,
+		Source:      journal.SourcePayment,
+		SourceID:    payment.ID,
+		Description: "Pembayaran hutang " + payment.Code,
+		Lines: []journal.PostingLine{
+			{Key: journal.KeyPayable, Debit: payment.Amount},
+			{Key: journal.KeyCash, Credit: payment.Amount},
+		},
+	}
+}
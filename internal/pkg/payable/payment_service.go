@@ -0,0 +1,198 @@
+package payable
+
+import (
+	"abude-backend/internal/pkg/accounts/journal"
+	"abude-backend/pkg/exception"
+	"abude-backend/pkg/pagination"
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PaymentService struct {
+	db *gorm.DB
+}
+
+func NewPaymentService(db *gorm.DB) *PaymentService {
+	return &PaymentService{db}
+}
+
+func (s *PaymentService) FindOne(id int) (*Payment, error) {
+	var payment Payment
+	if err := s.db.
+		Preload("Supplier").Preload("Company").Preload("Outlet").
+		Preload("Allocations").Preload("Allocations.Invoice").
+		First(&payment, id).Error; err != nil {
+		return nil, exception.DB(err, "Pembayaran")
+	}
+
+	return &payment, nil
+}
+
+func (s *PaymentService) FindAll(query PaymentQuery) *pagination.Result[Payment] {
+	result := pagination.New[Payment](query.Pagination)
+
+	db := s.db.Model(&Payment{}).Preload("Supplier").Preload("Outlet")
+	if query.Company != 0 {
+		db.Where("company_id = ?", query.Company)
+	}
+
+	if query.Supplier != 0 {
+		db.Where("supplier_id = ?", query.Supplier)
+	}
+
+	if query.Outlet != 0 {
+		db.Where("outlet_id = ?", query.Outlet)
+	}
+
+	if len(query.Status) > 0 {
+		db.Where("status IN (?)", query.Status)
+	}
+
+	if !query.StartDate.IsZero() {
+		db.Where("date >= ?", query.StartDate)
+	}
+
+	if !query.EndDate.IsZero() {
+		db.Where("date <= ?", query.EndDate)
+	}
+
+	db.Order("date DESC")
+
+	return result.Paginate(db)
+}
+
+// Create records a payment to a supplier and spreads it over the allocated
+// invoices. Each allocation may not exceed what is still owed on the invoice.
+func (s *PaymentService) Create(data PaymentDTO) (*Payment, error) {
+	payment := Payment{
+		Note:       data.Note,
+		Method:     data.Method,
+		Status:     StatusPaid,
+		Date:       time.Now(),
+		SupplierID: data.Supplier,
+		CompanyID:  data.Company,
+		OutletID:   data.Outlet,
+	}
+
+	if !data.Date.IsZero() {
+		payment.Date = data.Date
+	}
+
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		for _, v := range data.Allocations {
+			var invoice Invoice
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&invoice, v.Invoice).Error; err != nil {
+				return exception.DB(err, "Faktur")
+			}
+
+			if invoice.SupplierID != data.Supplier || invoice.CompanyID != data.Company {
+				return exception.BadRequest(fmt.Sprintf("Faktur '%s' bukan milik supplier ini", invoice.Code))
+			}
+
+			if invoice.Status == StatusCanceled || invoice.Status == StatusPaid {
+				return exception.BadRequest(fmt.Sprintf("Faktur '%s' sudah lunas atau dibatalkan", invoice.Code))
+			}
+
+			if v.Amount > invoice.Outstanding() {
+				return exception.BadRequest(fmt.Sprintf("Pembayaran melebihi sisa tagihan faktur '%s' (%.2f)", invoice.Code, invoice.Outstanding()))
+			}
+
+			if err := s.applyPayment(tx, &invoice, v.Amount); err != nil {
+				return err
+			}
+
+			payment.Amount += v.Amount
+			payment.Allocations = append(payment.Allocations, Allocation{
+				Amount:    v.Amount,
+				InvoiceID: invoice.ID,
+			})
+		}
+
+		if err := tx.Create(&payment).Error; err != nil {
+			return err
+		}
+
+		return journal.Post(tx, payment.posting())
+	}); err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &payment, nil
+}
+
+// Cancel voids a payment and gives the allocated amounts back to the invoices.
+func (s *PaymentService) Cancel(id int) error {
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		var payment Payment
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Allocations").First(&payment, id).Error; err != nil {
+			return exception.DB(err, "Pembayaran")
+		}
+
+		if payment.Status == StatusCanceled {
+			return exception.BadRequest("Status tidak berubah")
+		}
+
+		for _, v := range payment.Allocations {
+			var invoice Invoice
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&invoice, v.InvoiceID).Error; err != nil {
+				return err
+			}
+
+			if err := s.applyPayment(tx, &invoice, -v.Amount); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(&payment).Update("status", StatusCanceled).Error; err != nil {
+			return err
+		}
+
+		return journal.Reverse(tx, journal.SourcePayment, payment.ID, time.Now())
+	}); err != nil {
+		return exception.DB(err)
+	}
+
+	return nil
+}
+
+// applyPayment adds the amount to what was paid on the invoice, which must be
+// locked by the caller so the status follows the amount stored.
+func (s *PaymentService) applyPayment(tx *gorm.DB, invoice *Invoice, amount float64) error {
+	invoice.Paid += amount
+
+	switch {
+	case invoice.Outstanding() <= 0:
+		invoice.Status = StatusPaid
+	case invoice.Paid > 0:
+		invoice.Status = StatusPartial
+	default:
+		invoice.Status = StatusUnpaid
+	}
+
+	return tx.Model(&Invoice{}).Where("id = ?", invoice.ID).Updates(map[string]interface{}{
+		"paid":   gorm.Expr("paid + ?", amount),
+		"status": invoice.Status,
+	}).Error
+}
+
+func (s *PaymentService) Using(tx *gorm.DB) *PaymentService {
+	db := s.db
+
+	defer func() {
+		s.db = db
+	}()
+
+	s.db = tx.WithContext(tx.Statement.Context)
+
+	return s
+}
+
+func (s *PaymentService) WithContext(ctx context.Context) *PaymentService {
+	s.db = s.db.WithContext(ctx)
+
+	return s
+}
@@ -0,0 +1,33 @@
+package payroll
+
+import (
+	"abude-backend/internal/common"
+)
+
+func LoadRoutes(r *common.Router) {
+	schemeService := NewSchemeService(r.DB)
+	periodService := NewPeriodService(r.DB)
+	payslipService := NewPayslipService(r.DB)
+
+	schemeHandler := NewSchemeController(r.Controller, schemeService)
+	r.Router.Get("/payroll/scheme", r.Auth(2), schemeHandler.All)
+	r.Router.Get("/payroll/scheme/:id", r.Auth(2), schemeHandler.One)
+	r.Router.Post("/payroll/scheme", r.Auth(2), schemeHandler.Create)
+	r.Router.Put("/payroll/scheme/:id", r.Auth(2), schemeHandler.Update)
+	r.Router.Delete("/payroll/scheme/:id", r.Auth(2), schemeHandler.Delete)
+
+	periodHandler := NewPeriodController(r.Controller, periodService)
+	r.Router.Get("/payroll/period", r.Auth(2), periodHandler.All)
+	r.Router.Get("/payroll/period/:id", r.Auth(2), periodHandler.One)
+	r.Router.Post("/payroll/period", r.Auth(2), periodHandler.Create)
+	r.Router.Put("/payroll/period/:id", r.Auth(2), periodHandler.Update)
+	r.Router.Delete("/payroll/period/:id", r.Auth(2), periodHandler.Delete)
+	r.Router.Post("/payroll/period/:id/compute", r.Auth(2), periodHandler.Compute)
+	r.Router.Patch("/payroll/period/:id/approve", r.Auth(3), periodHandler.Approve)
+	r.Router.Post("/payroll/period/:id/adjustment", r.Auth(2), periodHandler.AddAdjustment)
+	r.Router.Delete("/payroll/period/:id/adjustment/:adjustment", r.Auth(2), periodHandler.DeleteAdjustment)
+
+	payslipHandler := NewPayslipController(r.Controller, payslipService)
+	r.Router.Get("/payroll/payslip/:id", r.Auth(2), payslipHandler.One)
+	r.Router.Get("/payroll/period/:id/payslip", r.Auth(2), payslipHandler.Period)
+}
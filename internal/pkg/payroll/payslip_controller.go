@@ -0,0 +1,95 @@
+package payroll
+
+import (
+	"abude-backend/internal/common"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PayslipController struct {
+	*common.BaseController
+	payslip *PayslipService
+}
+
+func NewPayslipController(ctrl *common.BaseController, payslip *PayslipService) *PayslipController {
+	return &PayslipController{ctrl, payslip}
+}
+
+// @Summary Get Wage Payslip
+// @Tags Payroll
+// @Accept json
+// @Produce application/pdf
+// @Produce json
+// @Param id path string true "Wage ID"
+// @Param query query PayslipQuery false "query"
+// @Success 200 {object} Payslip
+// @Security JWT
+// @Router /api/payroll/payslip/{id} [get]
+func (ctrl *PayslipController) One(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	var query PayslipQuery
+	if err := ctrl.Validation.Query(&query, ctx); err != nil {
+		return err
+	}
+
+	payslip, err := ctrl.payslip.FindOne(id)
+	if err != nil {
+		return err
+	}
+
+	if query.Format == "json" {
+		return ctx.Status(fiber.StatusOK).JSON(payslip)
+	}
+
+	ctx.Set(fiber.HeaderContentType, "application/pdf")
+	ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`inline; filename="%s"`, payslip.Filename()))
+
+	return ctx.Status(fiber.StatusOK).Send(payslip.PDF())
+}
+
+// @Summary Get Payroll Period Payslips
+// @Description Downloads the PDF payslips of the period as a ZIP file, or lists them as JSON
+// @Tags Payroll
+// @Accept json
+// @Produce application/zip
+// @Produce json
+// @Param id path string true "Payroll Period ID"
+// @Param query query PeriodPayslipQuery false "query"
+// @Success 200 {object} []Payslip
+// @Security JWT
+// @Router /api/payroll/period/{id}/payslip [get]
+func (ctrl *PayslipController) Period(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	var query PeriodPayslipQuery
+	if err := ctrl.Validation.Query(&query, ctx); err != nil {
+		return err
+	}
+
+	if query.Format == "json" {
+		_, payslips, err := ctrl.payslip.FindPeriod(id)
+		if err != nil {
+			return err
+		}
+
+		return ctx.Status(fiber.StatusOK).JSON(payslips)
+	}
+
+	period, archive, err := ctrl.payslip.Archive(id)
+	if err != nil {
+		return err
+	}
+
+	ctx.Set(fiber.HeaderContentType, "application/zip")
+	ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="payslip-%d-%s.zip"`, period.ID, period.StartDate.Format("20060102")))
+
+	return ctx.Status(fiber.StatusOK).Send(archive)
+}
@@ -0,0 +1,148 @@
+package payroll
+
+import (
+	"abude-backend/pkg/pdf"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Characters per line of a payslip on A4 paper.
+const payslipColumns = 80
+
+type PayslipLine struct {
+	Name   string  `json:"name"`
+	Amount float64 `json:"amount"`
+}
+
+// Payslip is the breakdown of what an employee was paid by a wage.
+type Payslip struct {
+	Code          string        `json:"code"`
+	Date          time.Time     `json:"date"`
+	Period        string        `json:"period,omitempty"`
+	StartDate     *time.Time    `json:"startDate,omitempty"`
+	EndDate       *time.Time    `json:"endDate,omitempty"`
+	Company       string        `json:"company"`
+	Outlet        string        `json:"outlet"`
+	OutletAddress string        `json:"outletAddress"`
+	Employee      string        `json:"employee"`
+	Scheme        string        `json:"scheme,omitempty"`
+	Days          int           `json:"days,omitempty"`
+	Hours         float64       `json:"hours,omitempty"`
+	Overtime      float64       `json:"overtime,omitempty"`
+	Earnings      []PayslipLine `json:"earnings"`
+	Deductions    []PayslipLine `json:"deductions"`
+	Gross         float64       `json:"gross"`
+	Deducted      float64       `json:"deducted"`
+	Advances      float64       `json:"advances"` // Advance (kasbon) installments repaid
+	Net           float64       `json:"net"`
+}
+
+// Lines renders the payslip as plain text.
+func (p *Payslip) Lines() []string {
+	separator := strings.Repeat("-", payslipColumns)
+
+	lines := []string{
+		center(p.Company),
+		center(strings.TrimSpace(p.Outlet + " - " + p.OutletAddress)),
+		center("SLIP GAJI"),
+		separator,
+		field("No.", p.Code),
+		field("Tanggal", p.Date.Format("02/01/2006")),
+		field("Karyawan", p.Employee),
+	}
+
+	if p.Period != "" {
+		lines = append(lines, field("Periode", p.Period))
+	}
+
+	if p.StartDate != nil && p.EndDate != nil {
+		lines = append(lines, field("", fmt.Sprintf("%s s/d %s", p.StartDate.Format("02/01/2006"), p.EndDate.Format("02/01/2006"))))
+	}
+
+	if p.Scheme != "" {
+		lines = append(lines, field("Kehadiran", fmt.Sprintf("%d hari, %s jam, lembur %s jam", p.Days, quantity(p.Hours), quantity(p.Overtime))))
+	}
+
+	lines = append(lines, separator, "PENDAPATAN")
+	for _, v := range p.Earnings {
+		lines = append(lines, amount("  "+v.Name, v.Amount))
+	}
+
+	lines = append(lines, amount("Total Pendapatan", p.Gross), "", "POTONGAN")
+	for _, v := range p.Deductions {
+		lines = append(lines, amount("  "+v.Name, v.Amount))
+	}
+
+	lines = append(lines,
+		amount("Total Potongan", p.Deducted),
+		separator,
+		amount("GAJI BERSIH", p.Net),
+		separator,
+	)
+
+	return lines
+}
+
+// PDF renders the payslip on an A4 wide page.
+func (p *Payslip) PDF() []byte {
+	document := pdf.New(210, 10)
+	document.Margin = 15
+
+	for _, line := range p.Lines() {
+		document.AddLine(line)
+	}
+
+	return document.Bytes()
+}
+
+// Filename returns the name of the payslip's PDF file.
+func (p *Payslip) Filename() string {
+	name := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' {
+			return r
+		}
+
+		return '_'
+	}, p.Employee)
+
+	return fmt.Sprintf("%s-%s.pdf", p.Code, name)
+}
+
+func center(text string) string {
+	if len(text) >= payslipColumns {
+		return text[:payslipColumns]
+	}
+
+	return strings.Repeat(" ", (payslipColumns-len(text))/2) + text
+}
+
+func field(name string, value string) string {
+	return fmt.Sprintf("%-12s: %s", name, value)
+}
+
+func amount(name string, value float64) string {
+	text := money(value)
+
+	return fmt.Sprintf("%-*s %s", payslipColumns-len(text)-1, name, text)
+}
+
+// money formats an amount in rupiah with dots as thousand separators.
+func money(value float64) string {
+	sign := ""
+	if value < 0 {
+		sign = "-"
+		value = -value
+	}
+
+	digits := fmt.Sprintf("%.0f", value)
+	for i := len(digits) - 3; i > 0; i -= 3 {
+		digits = digits[:i] + "." + digits[i:]
+	}
+
+	return "Rp " + sign + digits
+}
+
+func quantity(value float64) string {
+	return strings.TrimSuffix(strings.TrimRight(fmt.Sprintf("%.2f", value), "0"), ".")
+}
@@ -0,0 +1,9 @@
+package payroll
+
+type PayslipQuery struct {
+	Format string `query:"format" validate:"omitempty,oneof=pdf json" enums:"pdf,json"` // Defaults to pdf
+}
+
+type PeriodPayslipQuery struct {
+	Format string `query:"format" validate:"omitempty,oneof=zip json" enums:"zip,json"` // Defaults to zip
+}
@@ -0,0 +1,196 @@
+package payroll
+
+import (
+	"abude-backend/internal/pkg/transactions/wage"
+	"abude-backend/pkg/exception"
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+type PayslipService struct {
+	db *gorm.DB
+}
+
+func NewPayslipService(db *gorm.DB) *PayslipService {
+	return &PayslipService{db}
+}
+
+// FindOne builds the payslip of a wage. Wages computed by a payroll period
+// get the breakdown of their slip.
+func (s *PayslipService) FindOne(id int) (*Payslip, error) {
+	var record wage.Wage
+	if err := s.db.Preload("Employee").Preload("Company").Preload("Outlet").First(&record, id).Error; err != nil {
+		return nil, exception.DB(err, "Gaji")
+	}
+
+	var slip Slip
+	err := s.db.Preload("Period").Where("wage_id = ?", record.ID).First(&slip).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, exception.DB(err)
+	}
+
+	if err != nil {
+		return s.build(&record, nil, nil)
+	}
+
+	var adjustments []Adjustment
+	if err := s.db.Where("period_id = ? AND employee_id = ?", slip.PeriodID, slip.EmployeeID).Order("id ASC").Find(&adjustments).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return s.build(&record, &slip, adjustments)
+}
+
+// FindPeriod builds the payslips of every wage of a payroll period.
+func (s *PayslipService) FindPeriod(id int) (*Period, []Payslip, error) {
+	var period Period
+	if err := s.db.First(&period, id).Error; err != nil {
+		return nil, nil, exception.DB(err, "Periode penggajian")
+	}
+
+	var slips []Slip
+	if err := s.db.Where("period_id = ? AND wage_id IS NOT NULL", period.ID).Order("id ASC").Find(&slips).Error; err != nil {
+		return nil, nil, exception.DB(err)
+	}
+
+	payslips := []Payslip{}
+	for _, slip := range slips {
+		payslip, err := s.FindOne(int(*slip.WageID))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		payslips = append(payslips, *payslip)
+	}
+
+	return &period, payslips, nil
+}
+
+// Archive puts the PDF payslips of a payroll period in a ZIP file.
+func (s *PayslipService) Archive(id int) (*Period, []byte, error) {
+	period, payslips, err := s.FindPeriod(id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var buffer bytes.Buffer
+	archive := zip.NewWriter(&buffer)
+	for _, payslip := range payslips {
+		w, err := archive.CreateHeader(&zip.FileHeader{
+			Name:     payslip.Filename(),
+			Method:   zip.Deflate,
+			Modified: payslip.Date,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if _, err := w.Write(payslip.PDF()); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, nil, err
+	}
+
+	return period, buffer.Bytes(), nil
+}
+
+func (s *PayslipService) build(record *wage.Wage, slip *Slip, adjustments []Adjustment) (*Payslip, error) {
+	payslip := Payslip{
+		Code:       record.Code,
+		Date:       record.Date,
+		Earnings:   []PayslipLine{},
+		Deductions: []PayslipLine{},
+	}
+
+	if record.Company != nil {
+		payslip.Company = record.Company.Name
+	}
+
+	if record.Outlet != nil {
+		payslip.Outlet = record.Outlet.Name
+		payslip.OutletAddress = record.Outlet.Address
+	}
+
+	if record.Employee != nil {
+		payslip.Employee = record.Employee.Name
+	}
+
+	if slip == nil {
+		payslip.Earnings = append(payslip.Earnings, PayslipLine{"Gaji", record.Amount})
+		payslip.Gross = record.Amount
+		payslip.Advances = record.Deduction
+	} else {
+		if slip.Period != nil {
+			payslip.Period = slip.Period.Name
+			payslip.StartDate = &slip.Period.StartDate
+			payslip.EndDate = &slip.Period.EndDate
+		}
+
+		payslip.Scheme = slip.Scheme
+		payslip.Days = slip.Days
+		payslip.Hours = slip.Hours
+		payslip.Overtime = slip.Overtime
+		payslip.Earnings = append(payslip.Earnings, PayslipLine{"Gaji Pokok", slip.BasePay})
+		if slip.OvertimePay > 0 {
+			payslip.Earnings = append(payslip.Earnings, PayslipLine{"Lembur", slip.OvertimePay})
+		}
+
+		// The scheme's allowance is what the adjustments do not explain
+		allowance := slip.Allowances
+		for _, v := range adjustments {
+			if v.Type == AdjustmentAllowance {
+				allowance -= v.Amount
+			}
+		}
+
+		if allowance > 0 {
+			payslip.Earnings = append(payslip.Earnings, PayslipLine{"Tunjangan", allowance})
+		}
+
+		for _, v := range adjustments {
+			if v.Type == AdjustmentAllowance {
+				payslip.Earnings = append(payslip.Earnings, PayslipLine{v.Name, v.Amount})
+			} else {
+				payslip.Deductions = append(payslip.Deductions, PayslipLine{v.Name, v.Amount})
+			}
+		}
+
+		payslip.Gross = slip.BasePay + slip.OvertimePay + slip.Allowances
+		payslip.Deducted = slip.Deductions
+		payslip.Advances = slip.Advances
+	}
+
+	if payslip.Advances > 0 {
+		payslip.Deductions = append(payslip.Deductions, PayslipLine{"Kasbon", payslip.Advances})
+	}
+
+	payslip.Deducted += payslip.Advances
+	payslip.Net = payslip.Gross - payslip.Deducted
+
+	return &payslip, nil
+}
+
+func (s *PayslipService) Using(tx *gorm.DB) *PayslipService {
+	db := s.db
+
+	defer func() {
+		s.db = db
+	}()
+
+	s.db = tx.WithContext(tx.Statement.Context)
+
+	return s
+}
+
+func (s *PayslipService) WithContext(ctx context.Context) *PayslipService {
+	s.db = s.db.WithContext(ctx)
+
+	return s
+}
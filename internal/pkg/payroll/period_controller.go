@@ -0,0 +1,254 @@
+package payroll
+
+import (
+	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/auth"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PeriodController struct {
+	*common.BaseController
+	period *PeriodService
+}
+
+func NewPeriodController(ctrl *common.BaseController, period *PeriodService) *PeriodController {
+	return &PeriodController{ctrl, period}
+}
+
+// @Summary Get One Payroll Period
+// @Tags Payroll
+// @Accept json
+// @Produce json
+// @Param id path string true "Payroll Period ID"
+// @Success 200 {object} Period{}
+// @Security JWT
+// @Router /api/payroll/period/{id} [get]
+func (ctrl *PeriodController) One(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	period, err := ctrl.period.FindOne(id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(period)
+}
+
+// @Summary Get All Payroll Period
+// @Tags Payroll
+// @Accept json
+// @Produce json
+// @Param query query PeriodQuery false "query"
+// @Success 200 {object} common.PaginatedResponse{result=[]Period}
+// @Security JWT
+// @Router /api/payroll/period [get]
+func (ctrl *PeriodController) All(ctx *fiber.Ctx) error {
+	var query PeriodQuery
+	if err := ctrl.Validation.Query(&query, ctx); err != nil {
+		return err
+	}
+
+	result := ctrl.period.FindAll(query)
+
+	return ctx.Status(fiber.StatusOK).JSON(result)
+}
+
+// @Summary Create Payroll Period
+// @Tags Payroll
+// @Accept json
+// @Produce json
+// @Param request body PeriodDTO true "Request Body"
+// @Success 201 {object} common.GeneralResponse{result=Period}
+// @Security JWT
+// @Router /api/payroll/period [post]
+func (ctrl *PeriodController) Create(ctx *fiber.Ctx) error {
+	var data PeriodDTO
+	if err := ctrl.Validation.Body(&data, ctx); err != nil {
+		return err
+	}
+
+	period, err := ctrl.period.Create(data)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(common.GeneralResponse{
+		Message: "Periode penggajian berhasil dibuat",
+		Result:  period,
+	})
+}
+
+// @Summary Update Payroll Period
+// @Tags Payroll
+// @Accept json
+// @Produce json
+// @Param id path string true "Payroll Period ID"
+// @Param request body PeriodDTO true "Request Body"
+// @Success 200 {object} common.GeneralResponse{result=Period}
+// @Security JWT
+// @Router /api/payroll/period/{id} [put]
+func (ctrl *PeriodController) Update(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	var data PeriodDTO
+	if err := ctrl.Validation.Body(&data, ctx); err != nil {
+		return err
+	}
+
+	period, err := ctrl.period.Update(id, data)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.GeneralResponse{
+		Message: "Periode penggajian berhasil diubah",
+		Result:  period,
+	})
+}
+
+// @Summary Delete Payroll Period
+// @Tags Payroll
+// @Accept json
+// @Produce json
+// @Param id path string true "Payroll Period ID"
+// @Success 200 {object} common.GeneralResponse{result=Period}
+// @Security JWT
+// @Router /api/payroll/period/{id} [delete]
+func (ctrl *PeriodController) Delete(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	period, err := ctrl.period.Delete(id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.GeneralResponse{
+		Message: "Periode penggajian berhasil dihapus",
+		Result:  period,
+	})
+}
+
+// @Summary Compute Payroll Period
+// @Description Recomputes the slips and draft wages of the period
+// @Tags Payroll
+// @Accept json
+// @Produce json
+// @Param id path string true "Payroll Period ID"
+// @Success 200 {object} common.GeneralResponse{result=Period}
+// @Security JWT
+// @Router /api/payroll/period/{id}/compute [post]
+func (ctrl *PeriodController) Compute(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	period, err := ctrl.period.Compute(id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.GeneralResponse{
+		Message: "Periode penggajian berhasil dihitung",
+		Result:  period,
+	})
+}
+
+// @Summary Approve Payroll Period
+// @Description Accepts the draft wages of the period and locks it
+// @Tags Payroll
+// @Accept json
+// @Produce json
+// @Param id path string true "Payroll Period ID"
+// @Success 200 {object} common.GeneralResponse{result=Period}
+// @Security JWT
+// @Router /api/payroll/period/{id}/approve [patch]
+func (ctrl *PeriodController) Approve(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	creds := auth.GetCreds(ctx.Context())
+	period, err := ctrl.period.Approve(id, creds)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.GeneralResponse{
+		Message: "Periode penggajian berhasil disetujui",
+		Result:  period,
+	})
+}
+
+// @Summary Add Payroll Adjustment
+// @Tags Payroll
+// @Accept json
+// @Produce json
+// @Param id path string true "Payroll Period ID"
+// @Param request body AdjustmentDTO true "Request Body"
+// @Success 201 {object} common.GeneralResponse{result=Adjustment}
+// @Security JWT
+// @Router /api/payroll/period/{id}/adjustment [post]
+func (ctrl *PeriodController) AddAdjustment(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	var data AdjustmentDTO
+	if err := ctrl.Validation.Body(&data, ctx); err != nil {
+		return err
+	}
+
+	adjustment, err := ctrl.period.AddAdjustment(id, data)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(common.GeneralResponse{
+		Message: "Penyesuaian berhasil ditambahkan",
+		Result:  adjustment,
+	})
+}
+
+// @Summary Delete Payroll Adjustment
+// @Tags Payroll
+// @Accept json
+// @Produce json
+// @Param id path string true "Payroll Period ID"
+// @Param adjustment path string true "Adjustment ID"
+// @Success 200 {object} common.GeneralResponse{result=Adjustment}
+// @Security JWT
+// @Router /api/payroll/period/{id}/adjustment/{adjustment} [delete]
+func (ctrl *PeriodController) DeleteAdjustment(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	adjustmentId, err := ctrl.Validation.ParamsInt(ctx, "adjustment")
+	if err != nil {
+		return err
+	}
+
+	adjustment, err := ctrl.period.DeleteAdjustment(id, adjustmentId)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.GeneralResponse{
+		Message: "Penyesuaian berhasil dihapus",
+		Result:  adjustment,
+	})
+}
@@ -0,0 +1,27 @@
+package payroll
+
+import (
+	"abude-backend/pkg/pagination"
+	"time"
+)
+
+type PeriodDTO struct {
+	Name      string    `json:"name" form:"name" validate:"required"`
+	StartDate time.Time `json:"startDate" form:"startDate" validate:"required" format:"date"`
+	EndDate   time.Time `json:"endDate" form:"endDate" validate:"required" format:"date"`
+	Outlet    uint      `json:"outlet" form:"outlet" validate:"required,exist=outlets"`
+}
+
+type PeriodQuery struct {
+	pagination.Pagination
+	Company uint     `query:"company"`
+	Outlet  uint     `query:"outlet"`
+	Status  []string `query:"status" enums:"draft,approved"`
+}
+
+type AdjustmentDTO struct {
+	Type     string  `json:"type" form:"type" validate:"required,oneof=allowance deduction" enums:"allowance,deduction"`
+	Name     string  `json:"name" form:"name" validate:"required"`
+	Amount   float64 `json:"amount" form:"amount" validate:"required,gt=0"`
+	Employee uint    `json:"employee" form:"employee" validate:"required,exist=employees"`
+}
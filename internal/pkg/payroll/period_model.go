@@ -0,0 +1,97 @@
+package payroll
+
+import (
+	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/company"
+	"abude-backend/internal/pkg/employee"
+	"abude-backend/internal/pkg/outlet"
+	"abude-backend/internal/pkg/transactions/wage"
+	"abude-backend/internal/pkg/user"
+	"time"
+)
+
+const (
+	StatusDraft    = "draft"
+	StatusApproved = "approved"
+)
+
+const (
+	AdjustmentAllowance = "allowance"
+	AdjustmentDeduction = "deduction"
+)
+
+// Period is a payroll run of an outlet. Computing it produces a draft wage
+// per employee; approving it accepts the wages and locks the period.
+type Period struct {
+	common.BaseModel
+	Name       string     `json:"name" gorm:"type:varchar(100)"`
+	StartDate  time.Time  `json:"startDate" gorm:"type:date"`
+	EndDate    time.Time  `json:"endDate" gorm:"type:date"`
+	Status     string     `json:"status" gorm:"type:enum('draft','approved')" enums:"draft,approved"`
+	Total      float64    `json:"total"`
+	ComputedAt *time.Time `json:"computedAt"`
+	ApprovedAt *time.Time `json:"approvedAt"`
+
+	Slips       []Slip       `json:"slips,omitempty" gorm:"constraint:OnDelete:CASCADE;"`
+	Adjustments []Adjustment `json:"adjustments,omitempty" gorm:"constraint:OnDelete:CASCADE;"`
+
+	Company   *company.Company `json:"company,omitempty" gorm:"constraint:OnDelete:CASCADE;"`
+	CompanyID uint             `json:"-"`
+
+	Outlet   *outlet.Outlet `json:"outlet,omitempty" gorm:"constraint:OnDelete:CASCADE;"`
+	OutletID uint           `json:"-"`
+
+	Approver   *user.User `json:"approver,omitempty" gorm:"constraint:OnDelete:SET NULL;"`
+	ApproverID *uint      `json:"-"`
+}
+
+func (Period) TableName() string {
+	return "payroll_periods"
+}
+
+// Adjustment is a one-off allowance or deduction of an employee in a period.
+type Adjustment struct {
+	common.BaseModel
+	Type   string  `json:"type" gorm:"type:enum('allowance','deduction')" enums:"allowance,deduction"`
+	Name   string  `json:"name" gorm:"type:varchar(100)"`
+	Amount float64 `json:"amount"`
+
+	Period   *Period `json:"-" gorm:"constraint:OnDelete:CASCADE;"`
+	PeriodID uint    `json:"-"`
+
+	Employee   *employee.Employee `json:"employee,omitempty" gorm:"constraint:OnDelete:CASCADE;"`
+	EmployeeID uint               `json:"-"`
+}
+
+func (Adjustment) TableName() string {
+	return "payroll_adjustments"
+}
+
+// Slip is the computed pay of an employee in a period.
+type Slip struct {
+	common.BaseModel
+	Scheme      string  `json:"scheme" gorm:"type:enum('monthly','daily','hourly')" enums:"monthly,daily,hourly"`
+	Rate        float64 `json:"rate"`
+	Days        int     `json:"days"`
+	Hours       float64 `json:"hours"`
+	Overtime    float64 `json:"overtime"` // Hours
+	BasePay     float64 `json:"basePay"`
+	OvertimePay float64 `json:"overtimePay"`
+	Allowances  float64 `json:"allowances"`
+	Deductions  float64 `json:"deductions"`
+	Advances    float64 `json:"advances"` // Advance (kasbon) installments repaid
+	Net         float64 `json:"net"`
+
+	Period   *Period `json:"-" gorm:"constraint:OnDelete:CASCADE;"`
+	PeriodID uint    `json:"-"`
+
+	Employee   *employee.Employee `json:"employee,omitempty" gorm:"constraint:OnDelete:CASCADE;"`
+	EmployeeID uint               `json:"-"`
+
+	Wage   *wage.Wage `json:"wage,omitempty" gorm:"constraint:OnDelete:SET NULL;"`
+	WageID *uint      `json:"-"`
+}
+
+func (Slip) TableName() string {
+	return "payroll_slips"
+}
@@ -0,0 +1,503 @@
+package payroll
+
+import (
+	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/accounts/journal"
+	accounting "abude-backend/internal/pkg/accounts/period"
+	"abude-backend/internal/pkg/advance"
+	"abude-backend/internal/pkg/attendances/attendance"
+	"abude-backend/internal/pkg/employee"
+	"abude-backend/internal/pkg/outlet"
+	"abude-backend/internal/pkg/transactions/expense"
+	"abude-backend/internal/pkg/transactions/wage"
+	"abude-backend/internal/pkg/transition"
+	"abude-backend/pkg/exception"
+	"abude-backend/pkg/pagination"
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PeriodService struct {
+	db *gorm.DB
+}
+
+func NewPeriodService(db *gorm.DB) *PeriodService {
+	return &PeriodService{db}
+}
+
+func (s *PeriodService) FindOne(id int) (*Period, error) {
+	var period Period
+	if err := s.db.
+		Preload("Company").Preload("Outlet").Preload("Approver").
+		Preload("Slips").Preload("Slips.Employee").Preload("Slips.Wage").
+		Preload("Adjustments").Preload("Adjustments.Employee").
+		First(&period, id).Error; err != nil {
+		return nil, exception.DB(err, "Periode penggajian")
+	}
+
+	return &period, nil
+}
+
+func (s *PeriodService) FindAll(query PeriodQuery) *pagination.Result[Period] {
+	result := pagination.New[Period](query.Pagination)
+
+	db := s.db.Model(&Period{}).Preload("Outlet")
+	if query.Company != 0 {
+		db.Where("company_id = ?", query.Company)
+	}
+
+	if query.Outlet != 0 {
+		db.Where("outlet_id = ?", query.Outlet)
+	}
+
+	if len(query.Status) > 0 {
+		db.Where("status IN (?)", query.Status)
+	}
+
+	db.Order("start_date DESC")
+
+	return result.Paginate(db)
+}
+
+func (s *PeriodService) Create(data PeriodDTO) (*Period, error) {
+	period := Period{Status: StatusDraft}
+	if err := s.fill(&period, data); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Create(&period).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &period, nil
+}
+
+func (s *PeriodService) Update(id int, data PeriodDTO) (*Period, error) {
+	var period Period
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := s.lock(tx, &period, id); err != nil {
+			return err
+		}
+
+		if err := s.fill(&period, data); err != nil {
+			return err
+		}
+
+		// The computed slips no longer match the period
+		if err := discard(tx, period.ID); err != nil {
+			return err
+		}
+
+		period.Total = 0
+		period.ComputedAt = nil
+
+		return tx.Save(&period).Error
+	})
+	if err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &period, nil
+}
+
+func (s *PeriodService) Delete(id int) (*Period, error) {
+	var period Period
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := s.lock(tx, &period, id); err != nil {
+			return err
+		}
+
+		if err := discard(tx, period.ID); err != nil {
+			return err
+		}
+
+		return tx.Delete(&period).Error
+	})
+	if err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &period, nil
+}
+
+// Compute replaces the slips and draft wages of the period with ones
+// computed from the salary schemes, attendances and adjustments.
+func (s *PeriodService) Compute(id int) (*Period, error) {
+	var period Period
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := s.lock(tx, &period, id); err != nil {
+			return err
+		}
+
+		if err := discard(tx, period.ID); err != nil {
+			return err
+		}
+
+		var schemes []Scheme
+		if err := tx.Where("company_id = ? AND status = ?", period.CompanyID, true).
+			Where("employee_id IN (?)", tx.Model(&outlet.OutletEmployee{}).Select("employee_id").Where("outlet_id = ?", period.OutletID)).
+			Where("employee_id IN (?)", tx.Model(&employee.Employee{}).Select("id")).
+			Find(&schemes).Error; err != nil {
+			return err
+		}
+
+		var attendances []attendance.Attendance
+		if err := tx.Preload("Shift").
+			Where("outlet_id = ? AND date >= ? AND date <= ?", period.OutletID, period.StartDate, period.EndDate).
+			Find(&attendances).Error; err != nil {
+			return err
+		}
+
+		var adjustments []Adjustment
+		if err := tx.Where("period_id = ?", period.ID).Find(&adjustments).Error; err != nil {
+			return err
+		}
+
+		wageService := wage.NewService(tx)
+
+		period.Total = 0
+		for _, scheme := range schemes {
+			slip := compute(&scheme, attendances, adjustments)
+			slip.PeriodID = period.ID
+
+			due, err := advance.Due(tx, scheme.EmployeeID, period.CompanyID)
+			if err != nil {
+				return err
+			}
+
+			slip.Advances = math.Max(0, math.Min(due, slip.Net))
+			slip.Net -= slip.Advances
+
+			if slip.Net+slip.Advances > 0 {
+				record, err := wageService.Draft(wage.WageDTO{
+					Amount:   slip.Net + slip.Advances,
+					Type:     wage.TypeDebit,
+					Date:     period.EndDate,
+					Notes:    fmt.Sprintf("Gaji %s", period.Name),
+					Employee: scheme.EmployeeID,
+					Company:  period.CompanyID,
+					Outlet:   period.OutletID,
+				}, slip.Advances)
+				if err != nil {
+					return err
+				}
+
+				slip.WageID = &record.ID
+			}
+
+			if err := tx.Create(&slip).Error; err != nil {
+				return err
+			}
+
+			period.Total += slip.Net
+		}
+
+		now := time.Now()
+		period.ComputedAt = &now
+
+		return tx.Save(&period).Error
+	})
+	if err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return s.FindOne(int(period.ID))
+}
+
+// Approve accepts the draft wages of the period and locks it.
+func (s *PeriodService) Approve(id int, creds *common.Creds) (*Period, error) {
+	var period Period
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := s.lock(tx, &period, id); err != nil {
+			return err
+		}
+
+		if period.ComputedAt == nil {
+			return exception.BadRequest("Periode penggajian belum dihitung")
+		}
+
+		// The wages of the period are dated on its last day
+		if err := accounting.Check(tx, period.CompanyID, period.EndDate); err != nil {
+			return err
+		}
+
+		var slips []Slip
+		if err := tx.Where("period_id = ? AND advances > 0 AND wage_id IS NOT NULL", period.ID).Find(&slips).Error; err != nil {
+			return err
+		}
+
+		for _, slip := range slips {
+			deducted, err := advance.Deduct(tx, slip.EmployeeID, period.CompanyID, slip.Advances, *slip.WageID, period.EndDate)
+			if err != nil {
+				return err
+			}
+
+			if deducted < slip.Advances {
+				return exception.BadRequest("Kasbon karyawan telah berubah, hitung ulang periode penggajian")
+			}
+		}
+
+		var wages []uint
+		if err := tx.Model(&Slip{}).Where("period_id = ? AND wage_id IS NOT NULL", period.ID).Pluck("wage_id", &wages).Error; err != nil {
+			return err
+		}
+
+		var expenses []uint
+		if err := tx.Model(&wage.Wage{}).Where("id IN (?) AND expense_id IS NOT NULL", wages).Pluck("expense_id", &expenses).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&wage.Wage{}).Where("id IN (?)", wages).Update("status", wage.StatusAccepted).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&expense.Expense{}).Where("id IN (?)", expenses).Update("status", expense.StatusAccepted).Error; err != nil {
+			return err
+		}
+
+		reason := fmt.Sprintf("Periode penggajian %d disetujui", period.ID)
+		if err := transition.Record(tx, transition.TypeWage, wages, wage.StatusDraft, wage.StatusAccepted, reason); err != nil {
+			return err
+		}
+
+		if err := transition.Record(tx, transition.TypeExpense, expenses, expense.StatusDraft, expense.StatusAccepted, reason); err != nil {
+			return err
+		}
+
+		// Drafts are left out of the journal until the period is approved
+		var records []wage.Wage
+		if err := tx.Where("id IN (?)", wages).Find(&records).Error; err != nil {
+			return err
+		}
+
+		for _, record := range records {
+			if err := journal.Post(tx, record.Posting()); err != nil {
+				return err
+			}
+		}
+
+		var spent []expense.Expense
+		if err := tx.Where("id IN (?)", expenses).Find(&spent).Error; err != nil {
+			return err
+		}
+
+		for _, record := range spent {
+			// Expenses still waiting for approval are posted once approved
+			if !record.Posted() {
+				continue
+			}
+
+			if err := journal.Post(tx, record.Posting()); err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+		period.Status = StatusApproved
+		period.ApprovedAt = &now
+		period.ApproverID = &creds.ID
+
+		return tx.Save(&period).Error
+	})
+	if err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &period, nil
+}
+
+func (s *PeriodService) AddAdjustment(id int, data AdjustmentDTO) (*Adjustment, error) {
+	adjustment := Adjustment{
+		Type:       data.Type,
+		Name:       data.Name,
+		Amount:     data.Amount,
+		EmployeeID: data.Employee,
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var period Period
+		if err := s.lock(tx, &period, id); err != nil {
+			return err
+		}
+
+		adjustment.PeriodID = period.ID
+
+		return tx.Create(&adjustment).Error
+	})
+	if err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &adjustment, nil
+}
+
+func (s *PeriodService) DeleteAdjustment(id int, adjustmentId int) (*Adjustment, error) {
+	var adjustment Adjustment
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var period Period
+		if err := s.lock(tx, &period, id); err != nil {
+			return err
+		}
+
+		if err := tx.Where("period_id = ?", period.ID).First(&adjustment, adjustmentId).Error; err != nil {
+			return exception.DB(err, "Penyesuaian")
+		}
+
+		return tx.Delete(&adjustment).Error
+	})
+	if err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &adjustment, nil
+}
+
+// lock loads the period for update and refuses approved periods.
+func (s *PeriodService) lock(tx *gorm.DB, period *Period, id int) error {
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(period, id).Error; err != nil {
+		return exception.DB(err, "Periode penggajian")
+	}
+
+	if period.Status == StatusApproved {
+		return exception.BadRequest("Periode penggajian telah disetujui dan dikunci")
+	}
+
+	return nil
+}
+
+func (s *PeriodService) fill(period *Period, data PeriodDTO) error {
+	if data.EndDate.Before(data.StartDate) {
+		return exception.Validation(map[string]string{
+			"endDate": "Tanggal selesai tidak boleh sebelum tanggal mulai",
+		})
+	}
+
+	var store outlet.Outlet
+	if err := s.db.First(&store, data.Outlet).Error; err != nil {
+		return exception.DB(err, "Outlet")
+	}
+
+	period.Name = data.Name
+	period.StartDate = data.StartDate
+	period.EndDate = data.EndDate
+	period.OutletID = store.ID
+	period.CompanyID = store.CompanyID
+
+	return nil
+}
+
+// discard removes the slips of a period along with their draft wages and
+// the expenses of those wages.
+func discard(tx *gorm.DB, period uint) error {
+	var slips []Slip
+	if err := tx.Preload("Wage").Where("period_id = ?", period).Find(&slips).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Where("period_id = ?", period).Delete(&Slip{}).Error; err != nil {
+		return err
+	}
+
+	for _, slip := range slips {
+		if slip.Wage == nil {
+			continue
+		}
+
+		if err := tx.Delete(slip.Wage).Error; err != nil {
+			return err
+		}
+
+		if slip.Wage.ExpenseID != nil {
+			if err := tx.Delete(&expense.Expense{}, *slip.Wage.ExpenseID).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// compute works out the pay of the scheme's employee from their
+// attendances and adjustments.
+func compute(scheme *Scheme, attendances []attendance.Attendance, adjustments []Adjustment) Slip {
+	slip := Slip{
+		Scheme:     scheme.Type,
+		Rate:       scheme.Rate,
+		Allowances: scheme.Allowance,
+		EmployeeID: scheme.EmployeeID,
+	}
+
+	days := map[string]bool{}
+	for _, v := range attendances {
+		if v.EmployeeID != scheme.EmployeeID {
+			continue
+		}
+
+		days[v.Date.Format("2006-01-02")] = true
+		slip.Hours += v.Hours()
+		slip.Overtime += v.Overtime()
+	}
+
+	slip.Days = len(days)
+	slip.Hours = round(slip.Hours)
+	slip.Overtime = round(slip.Overtime)
+
+	switch scheme.Type {
+	case SchemeMonthly:
+		slip.BasePay = scheme.Rate
+	case SchemeDaily:
+		slip.BasePay = scheme.Rate * float64(slip.Days)
+	case SchemeHourly:
+		slip.BasePay = round(scheme.Rate * slip.Hours)
+	}
+
+	slip.OvertimePay = round(scheme.OvertimeRate * slip.Overtime)
+
+	for _, v := range adjustments {
+		if v.EmployeeID != scheme.EmployeeID {
+			continue
+		}
+
+		if v.Type == AdjustmentDeduction {
+			slip.Deductions += v.Amount
+		} else {
+			slip.Allowances += v.Amount
+		}
+	}
+
+	slip.Net = slip.BasePay + slip.OvertimePay + slip.Allowances - slip.Deductions
+
+	return slip
+}
+
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+func (s *PeriodService) Using(tx *gorm.DB) *PeriodService {
+	db := s.db
+
+	defer func() {
+		s.db = db
+	}()
+
+	s.db = tx.WithContext(tx.Statement.Context)
+
+	return s
+}
+
+func (s *PeriodService) WithContext(ctx context.Context) *PeriodService {
+	s.db = s.db.WithContext(ctx)
+
+	return s
+}
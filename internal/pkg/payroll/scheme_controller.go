@@ -0,0 +1,138 @@
+package payroll
+
+import (
+	"abude-backend/internal/common"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type SchemeController struct {
+	*common.BaseController
+	scheme *SchemeService
+}
+
+func NewSchemeController(ctrl *common.BaseController, scheme *SchemeService) *SchemeController {
+	return &SchemeController{ctrl, scheme}
+}
+
+// @Summary Get One Salary Scheme
+// @Tags Payroll
+// @Accept json
+// @Produce json
+// @Param id path string true "Salary Scheme ID"
+// @Success 200 {object} Scheme{}
+// @Security JWT
+// @Router /api/payroll/scheme/{id} [get]
+func (ctrl *SchemeController) One(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	scheme, err := ctrl.scheme.FindOne(id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(scheme)
+}
+
+// @Summary Get All Salary Scheme
+// @Tags Payroll
+// @Accept json
+// @Produce json
+// @Param query query SchemeQuery false "query"
+// @Success 200 {object} common.PaginatedResponse{result=[]Scheme}
+// @Security JWT
+// @Router /api/payroll/scheme [get]
+func (ctrl *SchemeController) All(ctx *fiber.Ctx) error {
+	var query SchemeQuery
+	if err := ctrl.Validation.Query(&query, ctx); err != nil {
+		return err
+	}
+
+	result := ctrl.scheme.FindAll(query)
+
+	return ctx.Status(fiber.StatusOK).JSON(result)
+}
+
+// @Summary Create Salary Scheme
+// @Tags Payroll
+// @Accept json
+// @Produce json
+// @Param request body SchemeDTO true "Request Body"
+// @Success 201 {object} common.GeneralResponse{result=Scheme}
+// @Security JWT
+// @Router /api/payroll/scheme [post]
+func (ctrl *SchemeController) Create(ctx *fiber.Ctx) error {
+	var data SchemeDTO
+	if err := ctrl.Validation.Body(&data, ctx); err != nil {
+		return err
+	}
+
+	scheme, err := ctrl.scheme.Create(data)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(common.GeneralResponse{
+		Message: "Skema gaji berhasil dibuat",
+		Result:  scheme,
+	})
+}
+
+// @Summary Update Salary Scheme
+// @Tags Payroll
+// @Accept json
+// @Produce json
+// @Param id path string true "Salary Scheme ID"
+// @Param request body SchemeDTO true "Request Body"
+// @Success 200 {object} common.GeneralResponse{result=Scheme}
+// @Security JWT
+// @Router /api/payroll/scheme/{id} [put]
+func (ctrl *SchemeController) Update(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	var data SchemeDTO
+	if err := ctrl.Validation.Body(&data, ctx); err != nil {
+		return err
+	}
+
+	scheme, err := ctrl.scheme.Update(id, data)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.GeneralResponse{
+		Message: "Skema gaji berhasil diubah",
+		Result:  scheme,
+	})
+}
+
+// @Summary Delete Salary Scheme
+// @Tags Payroll
+// @Accept json
+// @Produce json
+// @Param id path string true "Salary Scheme ID"
+// @Success 200 {object} common.GeneralResponse{result=Scheme}
+// @Security JWT
+// @Router /api/payroll/scheme/{id} [delete]
+func (ctrl *SchemeController) Delete(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	scheme, err := ctrl.scheme.Delete(id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.GeneralResponse{
+		Message: "Skema gaji berhasil dihapus",
+		Result:  scheme,
+	})
+}
@@ -0,0 +1,19 @@
+package payroll
+
+import "abude-backend/pkg/pagination"
+
+type SchemeDTO struct {
+	Type         string  `json:"type" form:"type" validate:"required,oneof=monthly daily hourly" enums:"monthly,daily,hourly"`
+	Rate         float64 `json:"rate" form:"rate" validate:"required,gt=0"`
+	OvertimeRate float64 `json:"overtimeRate" form:"overtimeRate" validate:"min=0"`
+	Allowance    float64 `json:"allowance" form:"allowance" validate:"min=0"`
+	Status       bool    `json:"status" form:"status" validate:"omitempty"`
+	Employee     uint    `json:"employee" form:"employee" validate:"required,exist=employees"`
+	Company      uint    `json:"company" form:"company" validate:"required,exist=companies"`
+}
+
+type SchemeQuery struct {
+	pagination.Pagination
+	Company  uint `query:"company"`
+	Employee uint `query:"employee"`
+}
@@ -0,0 +1,34 @@
+package payroll
+
+import (
+	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/company"
+	"abude-backend/internal/pkg/employee"
+)
+
+const (
+	SchemeMonthly = "monthly"
+	SchemeDaily   = "daily"
+	SchemeHourly  = "hourly"
+)
+
+// Scheme is how an employee is paid. Rate is per month, per attended day or
+// per worked hour depending on Type; Allowance is added once per period.
+type Scheme struct {
+	common.BaseModel
+	Type         string  `json:"type" gorm:"type:enum('monthly','daily','hourly')" enums:"monthly,daily,hourly"`
+	Rate         float64 `json:"rate"`
+	OvertimeRate float64 `json:"overtimeRate"` // Per overtime hour
+	Allowance    float64 `json:"allowance"`
+	Status       bool    `json:"status"`
+
+	Employee   *employee.Employee `json:"employee,omitempty" gorm:"constraint:OnDelete:CASCADE;"`
+	EmployeeID uint               `json:"-" gorm:"uniqueIndex:idx_payroll_scheme"`
+
+	Company   *company.Company `json:"company,omitempty" gorm:"constraint:OnDelete:CASCADE;"`
+	CompanyID uint             `json:"-" gorm:"uniqueIndex:idx_payroll_scheme"`
+}
+
+func (Scheme) TableName() string {
+	return "payroll_schemes"
+}
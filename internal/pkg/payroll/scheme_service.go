@@ -0,0 +1,129 @@
+package payroll
+
+import (
+	"abude-backend/pkg/exception"
+	"abude-backend/pkg/pagination"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type SchemeService struct {
+	db *gorm.DB
+}
+
+func NewSchemeService(db *gorm.DB) *SchemeService {
+	return &SchemeService{db}
+}
+
+func (s *SchemeService) FindOne(id int) (*Scheme, error) {
+	var scheme Scheme
+	if err := s.db.Preload("Employee").Preload("Company").First(&scheme, id).Error; err != nil {
+		return nil, exception.DB(err, "Skema gaji")
+	}
+
+	return &scheme, nil
+}
+
+func (s *SchemeService) FindAll(query SchemeQuery) *pagination.Result[Scheme] {
+	result := pagination.New[Scheme](query.Pagination)
+
+	db := s.db.Model(&Scheme{}).Preload("Employee")
+	if query.Company != 0 {
+		db.Where("company_id = ?", query.Company)
+	}
+
+	if query.Employee != 0 {
+		db.Where("employee_id = ?", query.Employee)
+	}
+
+	db.Order("created_at DESC")
+
+	return result.Paginate(db)
+}
+
+func (s *SchemeService) Create(data SchemeDTO) (*Scheme, error) {
+	var scheme Scheme
+	if err := s.fill(&scheme, data); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Create(&scheme).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &scheme, nil
+}
+
+func (s *SchemeService) Update(id int, data SchemeDTO) (*Scheme, error) {
+	var scheme Scheme
+	if err := s.db.First(&scheme, id).Error; err != nil {
+		return nil, exception.DB(err, "Skema gaji")
+	}
+
+	if err := s.fill(&scheme, data); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Save(&scheme).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &scheme, nil
+}
+
+func (s *SchemeService) Delete(id int) (*Scheme, error) {
+	var scheme Scheme
+	if err := s.db.First(&scheme, id).Error; err != nil {
+		return nil, exception.DB(err, "Skema gaji")
+	}
+
+	if err := s.db.Delete(&scheme).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &scheme, nil
+}
+
+func (s *SchemeService) fill(scheme *Scheme, data SchemeDTO) error {
+	var count int64
+	if err := s.db.Model(&Scheme{}).
+		Where("employee_id = ? AND company_id = ? AND id != ?", data.Employee, data.Company, scheme.ID).
+		Count(&count).Error; err != nil {
+		return exception.DB(err)
+	}
+
+	if count > 0 {
+		return exception.Validation(map[string]string{
+			"employee": "Karyawan telah memiliki skema gaji",
+		})
+	}
+
+	scheme.Type = data.Type
+	scheme.Rate = data.Rate
+	scheme.OvertimeRate = data.OvertimeRate
+	scheme.Allowance = data.Allowance
+	scheme.Status = data.Status
+	scheme.EmployeeID = data.Employee
+	scheme.CompanyID = data.Company
+
+	return nil
+}
+
+func (s *SchemeService) Using(tx *gorm.DB) *SchemeService {
+	db := s.db
+
+	defer func() {
+		s.db = db
+	}()
+
+	s.db = tx.WithContext(tx.Statement.Context)
+
+	return s
+}
+
+func (s *SchemeService) WithContext(ctx context.Context) *SchemeService {
+	s.db = s.db.WithContext(ctx)
+
+	return s
+}
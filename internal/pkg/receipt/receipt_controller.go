@@ -0,0 +1,63 @@
+package receipt
+
+import (
+	"abude-backend/internal/common"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ReceiptController struct {
+	*common.BaseController
+	receipt *ReceiptService
+}
+
+func NewController(ctrl *common.BaseController, receipt *ReceiptService) *ReceiptController {
+	return &ReceiptController{ctrl, receipt}
+}
+
+// @Summary Get Receipt Setting
+// @Tags Receipts
+// @Accept json
+// @Produce json
+// @Param query query SettingQuery true "query"
+// @Success 200 {object} Setting{}
+// @Security JWT
+// @Router /api/receipt/setting [get]
+func (ctrl *ReceiptController) One(ctx *fiber.Ctx) error {
+	var query SettingQuery
+	if err := ctrl.Validation.Query(&query, ctx); err != nil {
+		return err
+	}
+
+	setting, err := ctrl.receipt.FindSetting(query.Company, query.Outlet)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(setting)
+}
+
+// @Summary Save Receipt Setting
+// @Tags Receipts
+// @Accept json
+// @Produce json
+// @Param request body SettingDTO true "Request Body"
+// @Success 200 {object} common.GeneralResponse{result=Setting}
+// @Security JWT
+// @Router /api/receipt/setting [put]
+func (ctrl *ReceiptController) Save(ctx *fiber.Ctx) error {
+	var data SettingDTO
+	if err := ctrl.Validation.Body(&data, ctx); err != nil {
+		return err
+	}
+
+	setting, err := ctrl.receipt.Save(data)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.GeneralResponse{
+		Message: "Pengaturan struk berhasil disimpan",
+		Result:  setting,
+	})
+}
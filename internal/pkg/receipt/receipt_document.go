@@ -0,0 +1,212 @@
+package receipt
+
+import (
+	"abude-backend/pkg/escpos"
+	"abude-backend/pkg/pdf"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Supported thermal paper widths in millimeters.
+const (
+	Paper58 = 58
+	Paper80 = 80
+)
+
+type Item struct {
+	Name     string
+	Quantity float64
+	Price    float64
+	Total    float64
+}
+
+type Payment struct {
+	Method string
+	Amount float64
+}
+
+// Document is the printable content of a sale receipt.
+type Document struct {
+	Header   string
+	Footer   string
+	Code     string
+	Cashier  string
+	Customer string
+	Date     time.Time
+	Items    []Item
+	Subtotal float64
+	Discount float64
+	Tax      float64
+	Total    float64
+	Payments []Payment
+	Change   float64
+}
+
+type row struct {
+	text  string
+	align byte
+	bold  bool
+}
+
+// Columns returns the characters per line of a paper width.
+func Columns(paper int) int {
+	if paper == Paper80 {
+		return 48
+	}
+
+	return 32
+}
+
+// Lines renders the receipt as plain text padded to the paper width.
+func (d *Document) Lines(paper int) []string {
+	width := Columns(paper)
+
+	var lines []string
+	for _, r := range d.rows(width) {
+		switch r.align {
+		case escpos.AlignCenter:
+			pad := (width - length(r.text)) / 2
+			lines = append(lines, strings.Repeat(" ", pad)+r.text)
+		case escpos.AlignRight:
+			lines = append(lines, fmt.Sprintf("%*s", width, r.text))
+		default:
+			lines = append(lines, r.text)
+		}
+	}
+
+	return lines
+}
+
+// ESCPOS renders the receipt as raw printer commands.
+func (d *Document) ESCPOS(paper int) []byte {
+	printer := escpos.New()
+	for _, r := range d.rows(Columns(paper)) {
+		printer.Align(r.align).Bold(r.bold).Line(r.text)
+	}
+
+	return printer.Bold(false).Align(escpos.AlignLeft).Feed(3).Cut().Bytes()
+}
+
+// PDF renders the receipt on a page as wide as the paper.
+func (d *Document) PDF(paper int) []byte {
+	columns := Columns(paper)
+
+	// Courier glyphs are 0.6 em wide
+	fontSize := math.Floor((float64(paper)-4)*72/25.4/(float64(columns)*0.6)*10) / 10
+
+	document := pdf.New(float64(paper), fontSize)
+	for _, line := range d.Lines(paper) {
+		document.AddLine(line)
+	}
+
+	return document.Bytes()
+}
+
+func (d *Document) rows(width int) []row {
+	var rows []row
+	separator := row{text: strings.Repeat("-", width)}
+
+	for i, line := range splitLines(d.Header) {
+		rows = append(rows, row{text: truncate(line, width), align: escpos.AlignCenter, bold: i == 0})
+	}
+
+	rows = append(rows, separator)
+	rows = append(rows, row{text: columns(width, d.Code, d.Date.Format("02/01/2006 15:04"))})
+	if d.Cashier != "" {
+		rows = append(rows, row{text: columns(width, "Kasir", d.Cashier)})
+	}
+
+	if d.Customer != "" {
+		rows = append(rows, row{text: columns(width, "Pelanggan", d.Customer)})
+	}
+
+	rows = append(rows, separator)
+	for _, item := range d.Items {
+		rows = append(rows, row{text: truncate(item.Name, width)})
+		rows = append(rows, row{text: columns(width, fmt.Sprintf("  %s x %s", quantity(item.Quantity), money(item.Price)), money(item.Total))})
+	}
+
+	rows = append(rows, separator)
+	rows = append(rows, row{text: columns(width, "Subtotal", money(d.Subtotal))})
+	if d.Discount > 0 {
+		rows = append(rows, row{text: columns(width, "Diskon", "-"+money(d.Discount))})
+	}
+
+	if d.Tax > 0 {
+		rows = append(rows, row{text: columns(width, "Pajak", money(d.Tax))})
+	}
+
+	rows = append(rows, row{text: columns(width, "Total", money(d.Total)), bold: true})
+	for _, payment := range d.Payments {
+		rows = append(rows, row{text: columns(width, strings.ToUpper(payment.Method), money(payment.Amount))})
+	}
+
+	if d.Change > 0 {
+		rows = append(rows, row{text: columns(width, "Kembali", money(d.Change))})
+	}
+
+	if footer := splitLines(d.Footer); len(footer) > 0 {
+		rows = append(rows, separator)
+		for _, line := range footer {
+			rows = append(rows, row{text: truncate(line, width), align: escpos.AlignCenter})
+		}
+	}
+
+	return rows
+}
+
+// columns puts the left text and right text on both ends of a line.
+func columns(width int, left string, right string) string {
+	space := width - length(right) - 1
+	if space < 0 {
+		return truncate(right, width)
+	}
+
+	return fmt.Sprintf("%-*s %s", space, truncate(left, space), right)
+}
+
+// truncate cuts the text to the width in characters.
+func truncate(text string, width int) string {
+	if runes := []rune(text); len(runes) > width {
+		return string(runes[:width])
+	}
+
+	return text
+}
+
+// length is the width of the text in characters.
+func length(text string) int {
+	return utf8.RuneCountInString(text)
+}
+
+func splitLines(text string) []string {
+	text = strings.TrimSpace(strings.ReplaceAll(text, "\r\n", "\n"))
+	if text == "" {
+		return nil
+	}
+
+	return strings.Split(text, "\n")
+}
+
+// money formats an amount in rupiah with dots as thousand separators.
+func money(value float64) string {
+	sign := ""
+	if value < 0 {
+		sign = "-"
+		value = -value
+	}
+
+	digits := fmt.Sprintf("%.0f", value)
+	for i := len(digits) - 3; i > 0; i -= 3 {
+		digits = digits[:i] + "." + digits[i:]
+	}
+
+	return sign + digits
+}
+
+func quantity(value float64) string {
+	return strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.2f", value), "0"), ".")
+}
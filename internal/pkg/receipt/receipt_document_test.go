@@ -0,0 +1,113 @@
+package receipt
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files")
+
+func sample() Document {
+	return Document{
+		Header:   "Kedai Kopi Nusantara\nJl. Merdeka No. 10, Bandung",
+		Footer:   "Terima kasih\nSelamat datang kembali",
+		Code:     "TX-2403-0001",
+		Cashier:  "Siti",
+		Customer: "José Gonçalves",
+		Date:     time.Date(2024, 3, 5, 14, 30, 0, 0, time.UTC),
+		Items: []Item{
+			{Name: "Kopi Susu Gula Aren Spesial Ukuran Besar Sekali", Quantity: 2, Price: 28000, Total: 56000},
+			{Name: "Crème Brûlée", Quantity: 1, Price: 35000, Total: 35000},
+			{Name: "Teh Tarik (dingin)", Quantity: 1.5, Price: 12000, Total: 18000},
+		},
+		Subtotal: 109000,
+		Discount: 5000,
+		Tax:      10400,
+		Total:    114400,
+		Payments: []Payment{
+			{Method: "cash", Amount: 120000},
+		},
+		Change: 5600,
+	}
+}
+
+func TestDocumentGolden(t *testing.T) {
+	document := sample()
+
+	cases := []struct {
+		name   string
+		render func() []byte
+	}{
+		{"receipt_58.escpos", func() []byte { return document.ESCPOS(Paper58) }},
+		{"receipt_80.escpos", func() []byte { return document.ESCPOS(Paper80) }},
+		{"receipt_58.pdf", func() []byte { return document.PDF(Paper58) }},
+		{"receipt_80.pdf", func() []byte { return document.PDF(Paper80) }},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			golden(t, c.name, c.render())
+		})
+	}
+}
+
+func TestLinesFitPaper(t *testing.T) {
+	document := sample()
+
+	for _, paper := range []int{Paper58, Paper80} {
+		for _, line := range document.Lines(paper) {
+			if length(line) > Columns(paper) {
+				t.Errorf("line %q is wider than %d columns", line, Columns(paper))
+			}
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	cases := []struct {
+		text  string
+		width int
+		want  string
+	}{
+		{"Teh Tarik", 20, "Teh Tarik"},
+		{"Teh Tarik", 3, "Teh"},
+		{"Crème Brûlée", 5, "Crème"},
+		{"Brûlée", 3, "Brû"},
+	}
+
+	for _, c := range cases {
+		if got := truncate(c.text, c.width); got != c.want {
+			t.Errorf("truncate(%q, %d) = %q, want %q", c.text, c.width, got, c.want)
+		}
+	}
+}
+
+// golden compares the output with its file in testdata. Run the tests with
+// -update to rewrite the files after checking the change is intended.
+func golden(t *testing.T, name string, got []byte) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.MkdirAll("testdata", 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s does not match %s", name, path)
+	}
+}
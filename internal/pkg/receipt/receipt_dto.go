@@ -0,0 +1,13 @@
+package receipt
+
+type SettingDTO struct {
+	Header  string `json:"header" form:"header" validate:"omitempty"`
+	Footer  string `json:"footer" form:"footer" validate:"omitempty"`
+	Company uint   `json:"company" form:"company" validate:"required,exist=companies"`
+	Outlet  *uint  `json:"outlet" form:"outlet" validate:"omitempty,exist=outlets"`
+}
+
+type SettingQuery struct {
+	Company uint `query:"company" validate:"required"`
+	Outlet  uint `query:"outlet"`
+}
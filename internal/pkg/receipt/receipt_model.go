@@ -0,0 +1,25 @@
+package receipt
+
+import (
+	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/company"
+	"abude-backend/internal/pkg/outlet"
+)
+
+// Setting holds the printed receipt header and footer. A setting with an
+// outlet overrides the company wide one.
+type Setting struct {
+	common.BaseModel
+	Header string `json:"header" gorm:"type:text"`
+	Footer string `json:"footer" gorm:"type:text"`
+
+	Company   *company.Company `json:"company,omitempty" gorm:"constraint:OnDelete:CASCADE;"`
+	CompanyID uint             `json:"-"`
+
+	Outlet   *outlet.Outlet `json:"outlet,omitempty" gorm:"constraint:OnDelete:CASCADE;"`
+	OutletID *uint          `json:"-"`
+}
+
+func (Setting) TableName() string {
+	return "receipt_settings"
+}
@@ -0,0 +1,11 @@
+package receipt
+
+import "abude-backend/internal/common"
+
+func LoadRoutes(r *common.Router) {
+	receiptService := NewService(r.DB)
+	receiptHandler := NewController(r.Controller, receiptService)
+
+	r.Router.Get("/receipt/setting", r.Auth(1), receiptHandler.One)
+	r.Router.Put("/receipt/setting", r.Auth(2), receiptHandler.Save)
+}
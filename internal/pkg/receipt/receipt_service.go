@@ -0,0 +1,81 @@
+package receipt
+
+import (
+	"abude-backend/pkg/exception"
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+type ReceiptService struct {
+	db *gorm.DB
+}
+
+func NewService(db *gorm.DB) *ReceiptService {
+	return &ReceiptService{db}
+}
+
+// FindSetting returns the outlet's setting, falling back to the company's.
+// A blank setting is returned when neither exists.
+func (s *ReceiptService) FindSetting(company uint, outlet uint) (*Setting, error) {
+	var settings []Setting
+	if err := s.db.
+		Where("company_id = ? AND (outlet_id IS NULL OR outlet_id = ?)", company, outlet).
+		Order("outlet_id IS NULL ASC").
+		Limit(1).
+		Find(&settings).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	if len(settings) == 0 {
+		return &Setting{CompanyID: company}, nil
+	}
+
+	return &settings[0], nil
+}
+
+// Save creates or replaces the setting of a company or one of its outlets.
+func (s *ReceiptService) Save(data SettingDTO) (*Setting, error) {
+	var setting Setting
+
+	db := s.db.Where("company_id = ?", data.Company)
+	if data.Outlet != nil {
+		db.Where("outlet_id = ?", *data.Outlet)
+	} else {
+		db.Where("outlet_id IS NULL")
+	}
+
+	if err := db.First(&setting).Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, exception.DB(err)
+	}
+
+	setting.Header = data.Header
+	setting.Footer = data.Footer
+	setting.CompanyID = data.Company
+	setting.OutletID = data.Outlet
+
+	if err := s.db.Save(&setting).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &setting, nil
+}
+
+func (s *ReceiptService) Using(tx *gorm.DB) *ReceiptService {
+	db := s.db
+
+	defer func() {
+		s.db = db
+	}()
+
+	s.db = tx.WithContext(tx.Statement.Context)
+
+	return s
+}
+
+func (s *ReceiptService) WithContext(ctx context.Context) *ReceiptService {
+	s.db = s.db.WithContext(ctx)
+
+	return s
+}
@@ -0,0 +1,149 @@
+package receivable
+
+import (
+	"abude-backend/internal/common"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ReceiptController struct {
+	*common.BaseController
+	receipt *ReceiptService
+}
+
+func NewReceiptController(ctrl *common.BaseController, receipt *ReceiptService) *ReceiptController {
+	return &ReceiptController{ctrl, receipt}
+}
+
+// @Summary Get One Customer Receipt
+// @Tags Receivables
+// @Accept json
+// @Produce json
+// @Param id path string true "Receipt ID"
+// @Success 200 {object} Receipt{}
+// @Security JWT
+// @Router /api/receivable/receipt/{id} [get]
+func (ctrl *ReceiptController) One(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	receipt, err := ctrl.receipt.FindOne(id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(receipt)
+}
+
+// @Summary Get All Customer Receipt
+// @Tags Receivables
+// @Accept json
+// @Produce json
+// @Param query query ReceiptQuery false "query"
+// @Success 200 {object} common.PaginatedResponse{result=[]Receipt}
+// @Security JWT
+// @Router /api/receivable/receipt [get]
+func (ctrl *ReceiptController) All(ctx *fiber.Ctx) error {
+	var query ReceiptQuery
+	if err := ctrl.Validation.Query(&query, ctx); err != nil {
+		return err
+	}
+
+	result := ctrl.receipt.FindAll(query)
+
+	return ctx.Status(fiber.StatusOK).JSON(result)
+}
+
+// @Summary Create Customer Receipt
+// @Tags Receivables
+// @Accept json
+// @Produce json
+// @Param request body ReceiptDTO true "Request Body"
+// @Success 201 {object} common.GeneralResponse{result=Receipt}
+// @Security JWT
+// @Router /api/receivable/receipt [post]
+func (ctrl *ReceiptController) Create(ctx *fiber.Ctx) error {
+	var data ReceiptDTO
+	if err := ctrl.Validation.Body(&data, ctx); err != nil {
+		return err
+	}
+
+	receipt, err := ctrl.receipt.Create(data)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(common.GeneralResponse{
+		Message: "Penerimaan berhasil dibuat",
+		Result:  receipt,
+	})
+}
+
+// @Summary Cancel Customer Receipt
+// @Tags Receivables
+// @Accept json
+// @Produce json
+// @Param id path string true "Receipt ID"
+// @Success 200 {object} common.BasicResponse{}
+// @Security JWT
+// @Router /api/receivable/receipt/{id}/cancel [patch]
+func (ctrl *ReceiptController) Cancel(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := ctrl.receipt.Cancel(id); err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.BasicResponse{
+		Message: "Penerimaan berhasil dibatalkan",
+	})
+}
+
+// @Summary Get Customer Balances
+// @Tags Receivables
+// @Accept json
+// @Produce json
+// @Param query query BalanceQuery false "query"
+// @Success 200 {object} []CustomerBalance
+// @Security JWT
+// @Router /api/receivable/balance [get]
+func (ctrl *ReceiptController) GetBalances(ctx *fiber.Ctx) error {
+	var query BalanceQuery
+	if err := ctrl.Validation.Query(&query, ctx); err != nil {
+		return err
+	}
+
+	result, err := ctrl.receipt.GetBalances(query)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(result)
+}
+
+// @Summary Get Receivables Aging
+// @Tags Receivables
+// @Accept json
+// @Produce json
+// @Param query query AgingQuery true "query"
+// @Success 200 {object} []Aging
+// @Security JWT
+// @Router /api/receivable/aging [get]
+func (ctrl *ReceiptController) GetAging(ctx *fiber.Ctx) error {
+	var query AgingQuery
+	if err := ctrl.Validation.Query(&query, ctx); err != nil {
+		return err
+	}
+
+	result, err := ctrl.receipt.GetAging(query)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(result)
+}
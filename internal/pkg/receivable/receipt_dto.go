@@ -0,0 +1,41 @@
+package receivable
+
+import (
+	"abude-backend/pkg/pagination"
+	"time"
+)
+
+type AllocationDTO struct {
+	Sale   uint    `json:"sale" form:"sale" validate:"required,exist=sales"`
+	Amount float64 `json:"amount" form:"amount" validate:"required,gt=0"`
+}
+
+type ReceiptDTO struct {
+	Note        string          `json:"note" form:"note" validate:"omitempty"`
+	Method      string          `json:"method" form:"method" validate:"required,oneof=cash transfer qris" enums:"cash,transfer,qris"`
+	Date        time.Time       `json:"date" form:"date" validate:"omitempty" format:"date-time"`
+	Allocations []AllocationDTO `json:"allocations" form:"allocations" validate:"required,min=1,dive,required"`
+	Customer    uint            `json:"customer" form:"customer" validate:"required,exist=customers"`
+	Outlet      *uint           `json:"outlet" form:"outlet" validate:"omitempty,exist=outlets"`
+}
+
+type ReceiptQuery struct {
+	pagination.Pagination
+	Company   uint      `query:"company"`
+	Customer  uint      `query:"customer"`
+	Outlet    uint      `query:"outlet"`
+	Status    []string  `query:"status" enums:"paid,canceled"`
+	StartDate time.Time `query:"startDate" format:"date-time"`
+	EndDate   time.Time `query:"endDate" format:"date-time"`
+}
+
+type BalanceQuery struct {
+	Company  uint `query:"company"`
+	Customer uint `query:"customer"`
+}
+
+type AgingQuery struct {
+	Company  uint      `query:"company" validate:"required"`
+	Customer uint      `query:"customer"`
+	Date     time.Time `query:"date" format:"date-time"` // Reference date, defaults to today
+}
@@ -0,0 +1,142 @@
+package receivable
+
+import (
+	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/accounts/journal"
+	"abude-backend/internal/pkg/company"
+	"abude-backend/internal/pkg/customer"
+	"abude-backend/internal/pkg/outlet"
+	"abude-backend/internal/pkg/sequence"
+	"abude-backend/internal/pkg/transactions/sale"
+	"abude-backend/internal/pkg/user"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	StatusPaid     = "paid"
+	StatusCanceled = "canceled"
+)
+
+const (
+	MethodCash     = "cash"
+	MethodTransfer = "transfer"
+	MethodQris     = "qris"
+)
+
+type Allocation struct {
+	common.BaseModel
+	Amount float64 `json:"amount"`
+
+	Sale   *sale.Sale `json:"sale,omitempty" gorm:"constraint:OnDelete:RESTRICT;"`
+	SaleID uint       `json:"-"`
+
+	Receipt   *Receipt `json:"-" gorm:"constraint:OnDelete:CASCADE;"`
+	ReceiptID uint     `json:"-"`
+}
+
+func (Allocation) TableName() string {
+	return "customer_receipt_allocations"
+}
+
+type Receipt struct {
+	common.BaseModel
+	user.WithEditor
+	Code   string    `json:"code" gorm:"type:varchar(50)"`
+	Note   string    `json:"note" gorm:"type:varchar(150)"`
+	Amount float64   `json:"amount"`
+	Method string    `json:"method" gorm:"type:enum('cash','transfer','qris')" enums:"cash,transfer,qris"`
+	Status string    `json:"status" gorm:"type:enum('paid','canceled')" enums:"paid,canceled"`
+	Date   time.Time `json:"date"`
+
+	Allocations []Allocation `json:"allocations" gorm:"constraint:OnDelete:CASCADE;"`
+
+	Customer   *customer.Customer `json:"customer,omitempty" gorm:"constraint:OnDelete:RESTRICT;"`
+	CustomerID uint               `json:"-"`
+
+	Company   *company.Company `json:"company,omitempty" gorm:"constraint:OnDelete:CASCADE;"`
+	CompanyID uint             `json:"-"`
+
+	Outlet   *outlet.Outlet `json:"outlet,omitempty" gorm:"constraint:OnDelete:SET NULL;"`
+	OutletID *uint          `json:"-"`
+}
+
+func (Receipt) TableName() string {
+	return "customer_receipts"
+}
+
+func (receipt *Receipt) BeforeCreate(tx *gorm.DB) error {
+	if receipt.Code != "" {
+		return nil
+	}
+
+	var outlet uint
+	if receipt.OutletID != nil {
+		outlet = *receipt.OutletID
+	}
+
+	code, err := sequence.Next(tx, sequence.TypeReceipt, receipt.CompanyID, outlet, time.Now())
+	if err != nil {
+		return err
+	}
+
+	receipt.Code = code
+
+	return nil
+}
+
+// CustomerBalance is the unpaid credit sales of a single customer.
+type CustomerBalance struct {
+	Customer    customer.Customer `json:"customer" gorm:"embedded"`
+	Total       float64           `json:"total"`
+	Paid        float64           `json:"paid"`
+	Credited    float64           `json:"credited"` // Returns taken off the receivable
+	Outstanding float64           `json:"outstanding"`
+}
+
+// Aging buckets the outstanding receivable of a customer by days past due.
+type Aging struct {
+	Customer customer.Customer `json:"customer"`
+	Current  float64           `json:"current"`
+	Days30   float64           `json:"days30"`
+	Days60   float64           `json:"days60"`
+	Days90   float64           `json:"days90"`
+	Over90   float64           `json:"over90"`
+	Total    float64           `json:"total"`
+}
+
+// Add puts the amount into the bucket matching the days past due.
+func (aging *Aging) Add(overdue int, amount float64) {
+	switch {
+	case overdue <= 0:
+		aging.Current += amount
+	case overdue <= 30:
+		aging.Days30 += amount
+	case overdue <= 60:
+		aging.Days60 += amount
+	case overdue <= 90:
+		aging.Days90 += amount
+	default:
+		aging.Over90 += amount
+	}
+
+	aging.Total += amount
+}
+
+// posting books the money received against the receivable of the sales.
+func (receipt *Receipt) posting() journal.Posting {
+	return journal.Posting{
+		Company:     receipt.CompanyID,
+		Outlet:      receipt.OutletID,
+		User:        receipt.EditorID,
+		Date:        receipt.Date,
+		Source:      journal.SourceReceipt,
+		SourceID:    receipt.ID,
+		Description: "Penerimaan piutang " + receipt.Code,
+		Lines: []journal.PostingLine{
+			{Key: journal.KeyCash, Debit: receipt.Amount},
+			{Key: journal.KeyReceivable, Credit: receipt.Amount},
+		},
+	}
+}
@@ -0,0 +1,256 @@
+package receivable
+
+import (
+	"abude-backend/internal/pkg/accounts/journal"
+	"abude-backend/internal/pkg/customer"
+	"abude-backend/internal/pkg/transactions/sale"
+	"abude-backend/pkg/exception"
+	"abude-backend/pkg/pagination"
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReceiptService struct {
+	db *gorm.DB
+}
+
+func NewReceiptService(db *gorm.DB) *ReceiptService {
+	return &ReceiptService{db}
+}
+
+func (s *ReceiptService) FindOne(id int) (*Receipt, error) {
+	var receipt Receipt
+	if err := s.db.
+		Preload("Customer").Preload("Company").Preload("Outlet").
+		Preload("Allocations").Preload("Allocations.Sale").
+		First(&receipt, id).Error; err != nil {
+		return nil, exception.DB(err, "Penerimaan")
+	}
+
+	return &receipt, nil
+}
+
+func (s *ReceiptService) FindAll(query ReceiptQuery) *pagination.Result[Receipt] {
+	result := pagination.New[Receipt](query.Pagination)
+
+	db := s.db.Model(&Receipt{}).Preload("Customer").Preload("Outlet")
+	if query.Company != 0 {
+		db.Where("company_id = ?", query.Company)
+	}
+
+	if query.Customer != 0 {
+		db.Where("customer_id = ?", query.Customer)
+	}
+
+	if query.Outlet != 0 {
+		db.Where("outlet_id = ?", query.Outlet)
+	}
+
+	if len(query.Status) > 0 {
+		db.Where("status IN (?)", query.Status)
+	}
+
+	if !query.StartDate.IsZero() {
+		db.Where("date >= ?", query.StartDate)
+	}
+
+	if !query.EndDate.IsZero() {
+		db.Where("date <= ?", query.EndDate)
+	}
+
+	db.Order("date DESC")
+
+	return result.Paginate(db)
+}
+
+// Create records money received from a customer and applies it to their
+// unpaid credit sales.
+func (s *ReceiptService) Create(data ReceiptDTO) (*Receipt, error) {
+	var customer customer.Customer
+	if err := s.db.First(&customer, data.Customer).Error; err != nil {
+		return nil, exception.DB(err, "Pelanggan")
+	}
+
+	receipt := Receipt{
+		Note:       data.Note,
+		Method:     data.Method,
+		Status:     StatusPaid,
+		Date:       time.Now(),
+		CustomerID: customer.ID,
+		CompanyID:  customer.CompanyID,
+		OutletID:   data.Outlet,
+	}
+
+	if !data.Date.IsZero() {
+		receipt.Date = data.Date
+	}
+
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		for _, v := range data.Allocations {
+			var record sale.Sale
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&record, v.Sale).Error; err != nil {
+				return exception.DB(err, "Penjualan")
+			}
+
+			if record.CustomerID == nil || *record.CustomerID != customer.ID {
+				return exception.BadRequest(fmt.Sprintf("Penjualan '%s' bukan milik pelanggan ini", record.Code))
+			}
+
+			if record.Type != sale.TypeCredit || record.Status == sale.StatusCanceled {
+				return exception.BadRequest(fmt.Sprintf("Penjualan '%s' bukan penjualan kredit yang aktif", record.Code))
+			}
+
+			if v.Amount > record.Outstanding() {
+				return exception.BadRequest(fmt.Sprintf("Pembayaran melebihi sisa tagihan penjualan '%s' (%.2f)", record.Code, record.Outstanding()))
+			}
+
+			if err := tx.Model(&sale.Sale{}).Where("id = ?", record.ID).Update("paid", gorm.Expr("paid + ?", v.Amount)).Error; err != nil {
+				return err
+			}
+
+			receipt.Amount += v.Amount
+			receipt.Allocations = append(receipt.Allocations, Allocation{
+				Amount: v.Amount,
+				SaleID: record.ID,
+			})
+		}
+
+		if err := tx.Create(&receipt).Error; err != nil {
+			return err
+		}
+
+		return journal.Post(tx, receipt.posting())
+	}); err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &receipt, nil
+}
+
+// Cancel voids a receipt and reopens the allocated amounts on the sales.
+func (s *ReceiptService) Cancel(id int) error {
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		// Locked so that a concurrent cancel waits and sees the new status
+		var receipt Receipt
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Allocations").First(&receipt, id).Error; err != nil {
+			return exception.DB(err, "Penerimaan")
+		}
+
+		if receipt.Status == StatusCanceled {
+			return exception.BadRequest("Status tidak berubah")
+		}
+
+		for _, v := range receipt.Allocations {
+			if err := tx.Model(&sale.Sale{}).
+				Where("id = ?", v.SaleID).
+				Update("paid", gorm.Expr("paid - ?", v.Amount)).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(&receipt).Update("status", StatusCanceled).Error; err != nil {
+			return err
+		}
+
+		return journal.Reverse(tx, journal.SourceReceipt, receipt.ID, time.Now())
+	}); err != nil {
+		return exception.DB(err)
+	}
+
+	return nil
+}
+
+func (s *ReceiptService) GetBalances(query BalanceQuery) ([]CustomerBalance, error) {
+	var balances []CustomerBalance
+
+	db := s.db.Table("sales").
+		Select("customers.*, SUM(sales.total) AS total, SUM(sales.paid) AS paid, SUM(sales.credited) AS credited, SUM(sales.total - sales.paid - sales.credited) AS outstanding").
+		Joins("INNER JOIN customers ON customers.id = sales.customer_id").
+		Where("sales.type = ? AND sales.status != ? AND sales.deleted_at IS NULL", sale.TypeCredit, sale.StatusCanceled).
+		Group("sales.customer_id").
+		Order("outstanding DESC")
+
+	if query.Company != 0 {
+		db.Where("customers.company_id = ?", query.Company)
+	}
+
+	if query.Customer != 0 {
+		db.Where("sales.customer_id = ?", query.Customer)
+	}
+
+	if err := db.Find(&balances).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return balances, nil
+}
+
+func (s *ReceiptService) GetAging(query AgingQuery) ([]Aging, error) {
+	date := query.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	var sales []sale.Sale
+	db := s.db.Preload("Client").
+		Where("type = ? AND status != ? AND total > paid + credited AND date <= ?", sale.TypeCredit, sale.StatusCanceled, date).
+		Where("customer_id IN (?)", s.db.Model(&customer.Customer{}).Select("id").Where("company_id = ?", query.Company)).
+		Order("customer_id ASC, date ASC")
+
+	if query.Customer != 0 {
+		db.Where("customer_id = ?", query.Customer)
+	}
+
+	if err := db.Find(&sales).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	var result []Aging
+	index := make(map[uint]int)
+	for _, v := range sales {
+		i, ok := index[*v.CustomerID]
+		if !ok {
+			var customer customer.Customer
+			if v.Client != nil {
+				customer = *v.Client
+			}
+
+			result = append(result, Aging{Customer: customer})
+			i = len(result) - 1
+			index[*v.CustomerID] = i
+		}
+
+		due := v.Date
+		if v.DueDate != nil {
+			due = *v.DueDate
+		}
+
+		overdue := int(math.Floor(date.Sub(due).Hours() / 24))
+		result[i].Add(overdue, v.Outstanding())
+	}
+
+	return result, nil
+}
+
+func (s *ReceiptService) Using(tx *gorm.DB) *ReceiptService {
+	db := s.db
+
+	defer func() {
+		s.db = db
+	}()
+
+	s.db = tx.WithContext(tx.Statement.Context)
+
+	return s
+}
+
+func (s *ReceiptService) WithContext(ctx context.Context) *ReceiptService {
+	s.db = s.db.WithContext(ctx)
+
+	return s
+}
@@ -0,0 +1,17 @@
+package receivable
+
+import (
+	"abude-backend/internal/common"
+)
+
+func LoadRoutes(r *common.Router) {
+	receiptService := NewReceiptService(r.DB)
+
+	receiptHandler := NewReceiptController(r.Controller, receiptService)
+	r.Router.Get("/receivable/balance", r.Auth(2), receiptHandler.GetBalances)
+	r.Router.Get("/receivable/aging", r.Auth(2), receiptHandler.GetAging)
+	r.Router.Get("/receivable/receipt", r.Auth(1), receiptHandler.All)
+	r.Router.Get("/receivable/receipt/:id", r.Auth(1), receiptHandler.One)
+	r.Router.Post("/receivable/receipt", r.Auth(1), receiptHandler.Create)
+	r.Router.Patch("/receivable/receipt/:id/cancel", r.Auth(2), receiptHandler.Cancel)
+}
@@ -0,0 +1,39 @@
+package revision
+
+import (
+	"abude-backend/internal/common"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type RevisionController struct {
+	*common.BaseController
+	revision *RevisionService
+}
+
+func NewController(ctrl *common.BaseController, revision *RevisionService) *RevisionController {
+	return &RevisionController{ctrl, revision}
+}
+
+// @Summary Get Document Revisions
+// @Tags Revisions
+// @Accept json
+// @Produce json
+// @Param type path string true "Document Type" Enums(sale, purchase)
+// @Param id path string true "Document ID"
+// @Success 200 {object} []Revision
+// @Security JWT
+// @Router /api/revision/{type}/{id} [get]
+func (ctrl *RevisionController) All(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := ctrl.revision.WithContext(ctx.Context()).FindAll(ctx.Params("type"), id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(result)
+}
@@ -0,0 +1,45 @@
+package revision
+
+import (
+	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/user"
+
+	"gorm.io/datatypes"
+)
+
+// Document types with revision history.
+const (
+	TypeSale     = "sale"
+	TypePurchase = "purchase"
+)
+
+var tables = map[string]struct {
+	Name  string
+	Table string
+}{
+	TypeSale:     {"Penjualan", "sales"},
+	TypePurchase: {"Pembelian", "purchases"},
+}
+
+// Revision is a single update of a document. Before is the document with its
+// items as it was prior to the update and After is the result of the update.
+type Revision struct {
+	common.BaseModel
+	Type       string         `json:"type" gorm:"type:varchar(50);index:idx_revision"`
+	DocumentID uint           `json:"document" gorm:"index:idx_revision"`
+	Number     int            `json:"number"`
+	Before     datatypes.JSON `json:"before" swaggertype:"object"`
+	After      datatypes.JSON `json:"after" swaggertype:"object"`
+	Changes    []Change       `json:"changes" gorm:"-"`
+
+	User   *user.User `json:"user,omitempty" gorm:"constraint:OnDelete:SET NULL;"`
+	UserID *uint      `json:"-"`
+}
+
+// Change is a field that differs between two versions of a document. Nested
+// fields are joined with dots, such as items.0.quantity.
+type Change struct {
+	Field  string      `json:"field"`
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
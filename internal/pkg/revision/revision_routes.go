@@ -0,0 +1,10 @@
+package revision
+
+import "abude-backend/internal/common"
+
+func LoadRoutes(r *common.Router) {
+	revisionService := NewService(r.DB)
+	revisionHandler := NewController(r.Controller, revisionService)
+
+	r.Router.Get("/revision/:type/:id", r.Auth(2), revisionHandler.All)
+}
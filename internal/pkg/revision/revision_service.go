@@ -0,0 +1,164 @@
+package revision
+
+import (
+	"abude-backend/pkg/exception"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"gorm.io/gorm"
+)
+
+// Fields that change on every update and would only add noise to a diff.
+var ignored = map[string]bool{
+	"updatedAt": true,
+	"editedAt":  true,
+}
+
+// Save stores a revision of a document from its versions before and after an
+// update. It must be called in the same transaction as the update.
+func Save(tx *gorm.DB, docType string, id uint, before interface{}, after interface{}, userId uint) error {
+	previous, err := json.Marshal(before)
+	if err != nil {
+		return err
+	}
+
+	next, err := json.Marshal(after)
+	if err != nil {
+		return err
+	}
+
+	var count int64
+	if err := tx.Model(&Revision{}).Where(&Revision{Type: docType, DocumentID: id}).Count(&count).Error; err != nil {
+		return err
+	}
+
+	revision := Revision{
+		Type:       docType,
+		DocumentID: id,
+		Number:     int(count) + 1,
+		Before:     previous,
+		After:      next,
+	}
+
+	if userId != 0 {
+		revision.UserID = &userId
+	}
+
+	return tx.Create(&revision).Error
+}
+
+// Diff returns the fields that differ between two JSON documents.
+func Diff(before []byte, after []byte) ([]Change, error) {
+	previous, next := make(map[string]interface{}), make(map[string]interface{})
+
+	var value interface{}
+	if err := json.Unmarshal(before, &value); err != nil {
+		return nil, err
+	}
+	flatten("", value, previous)
+
+	value = nil
+	if err := json.Unmarshal(after, &value); err != nil {
+		return nil, err
+	}
+	flatten("", value, next)
+
+	changes := make([]Change, 0)
+	for field, old := range previous {
+		if current, ok := next[field]; !ok || !reflect.DeepEqual(old, current) {
+			changes = append(changes, Change{Field: field, Before: old, After: current})
+		}
+	}
+
+	for field, current := range next {
+		if _, ok := previous[field]; !ok {
+			changes = append(changes, Change{Field: field, After: current})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+
+	return changes, nil
+}
+
+func flatten(prefix string, value interface{}, result map[string]interface{}) {
+	join := func(key string) string {
+		if prefix == "" {
+			return key
+		}
+
+		return prefix + "." + key
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if ignored[key] {
+				continue
+			}
+
+			flatten(join(key), child, result)
+		}
+	case []interface{}:
+		for i, child := range v {
+			flatten(join(fmt.Sprint(i)), child, result)
+		}
+	default:
+		result[prefix] = v
+	}
+}
+
+type RevisionService struct {
+	db *gorm.DB
+}
+
+func NewService(db *gorm.DB) *RevisionService {
+	return &RevisionService{db}
+}
+
+// FindAll returns the revisions of a document with the changes of each, newest
+// first.
+func (s *RevisionService) FindAll(docType string, id int) ([]Revision, error) {
+	document, ok := tables[docType]
+	if !ok {
+		return nil, exception.NotFound("Tipe dokumen")
+	}
+
+	var count int64
+	if err := s.db.Table(document.Table).Where("id = ?", id).Count(&count).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	if count == 0 {
+		return nil, exception.NotFound(document.Name)
+	}
+
+	var revisions []Revision
+	if err := s.db.Preload("User").Where(&Revision{Type: docType, DocumentID: uint(id)}).Order("number DESC").Find(&revisions).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	for i := range revisions {
+		changes, err := Diff(revisions[i].Before, revisions[i].After)
+		if err != nil {
+			return nil, exception.InternalError("Revisi tidak dapat dibaca")
+		}
+
+		revisions[i].Changes = changes
+	}
+
+	return revisions, nil
+}
+
+func (s *RevisionService) Using(tx *gorm.DB) *RevisionService {
+	return &RevisionService{tx}
+}
+
+func (s *RevisionService) WithContext(ctx context.Context) *RevisionService {
+	return &RevisionService{s.db.WithContext(ctx)}
+}
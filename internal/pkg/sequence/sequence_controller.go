@@ -0,0 +1,88 @@
+package sequence
+
+import (
+	"abude-backend/internal/common"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type SequenceController struct {
+	*common.BaseController
+	sequence *SequenceService
+}
+
+func NewController(ctrl *common.BaseController, sequence *SequenceService) *SequenceController {
+	return &SequenceController{ctrl, sequence}
+}
+
+// @Summary Get All Sequence Format
+// @Tags Sequences
+// @Accept json
+// @Produce json
+// @Param query query FormatQuery false "query"
+// @Success 200 {object} []Format
+// @Security JWT
+// @Router /api/sequence [get]
+func (ctrl *SequenceController) All(ctx *fiber.Ctx) error {
+	var query FormatQuery
+	if err := ctrl.Validation.Query(&query, ctx); err != nil {
+		return err
+	}
+
+	result, err := ctrl.sequence.FindAll(query)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(result)
+}
+
+// @Summary Save Sequence Format
+// @Tags Sequences
+// @Accept json
+// @Produce json
+// @Param request body FormatDTO true "Request Body"
+// @Success 200 {object} common.GeneralResponse{result=Format}
+// @Security JWT
+// @Router /api/sequence [put]
+func (ctrl *SequenceController) Save(ctx *fiber.Ctx) error {
+	var data FormatDTO
+	if err := ctrl.Validation.Body(&data, ctx); err != nil {
+		return err
+	}
+
+	format, err := ctrl.sequence.Save(data)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.GeneralResponse{
+		Message: "Format penomoran berhasil disimpan",
+		Result:  format,
+	})
+}
+
+// @Summary Preview Next Document Code
+// @Tags Sequences
+// @Accept json
+// @Produce json
+// @Param query query PreviewQuery true "query"
+// @Success 200 {object} common.GeneralResponse{result=string}
+// @Security JWT
+// @Router /api/sequence/preview [get]
+func (ctrl *SequenceController) Preview(ctx *fiber.Ctx) error {
+	var query PreviewQuery
+	if err := ctrl.Validation.Query(&query, ctx); err != nil {
+		return err
+	}
+
+	code, err := ctrl.sequence.Preview(query)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.GeneralResponse{
+		Message: "Kode berikutnya",
+		Result:  code,
+	})
+}
@@ -0,0 +1,21 @@
+package sequence
+
+type FormatDTO struct {
+	Type     string `json:"type" form:"type" validate:"required,oneof=sale sale_return purchase purchase_order goods_receipt expense wage recapitulation supplier_invoice supplier_payment customer_receipt employee_advance bank_transfer"`
+	Prefix   string `json:"prefix" form:"prefix" validate:"omitempty,max=20"`
+	Template string `json:"template" form:"template" validate:"required,max=100,contains={seq}"`
+	Padding  int    `json:"padding" form:"padding" validate:"omitempty,gte=1,lte=10"`
+	Reset    string `json:"reset" form:"reset" validate:"required,oneof=never daily monthly yearly" enums:"never,daily,monthly,yearly"`
+	Scope    string `json:"scope" form:"scope" validate:"required,oneof=global company outlet" enums:"global,company,outlet"`
+	Company  uint   `json:"company" form:"company" validate:"omitempty,exist=companies"` // Empty for the global format
+}
+
+type FormatQuery struct {
+	Company uint `query:"company"`
+}
+
+type PreviewQuery struct {
+	Type    string `query:"type" validate:"required"`
+	Company uint   `query:"company"`
+	Outlet  uint   `query:"outlet"`
+}
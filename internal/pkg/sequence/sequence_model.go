@@ -0,0 +1,123 @@
+package sequence
+
+import (
+	"abude-backend/internal/common"
+	"time"
+)
+
+// Document types with generated codes.
+const (
+	TypeSale           = "sale"
+	TypeSaleReturn     = "sale_return"
+	TypePurchase       = "purchase"
+	TypePurchaseOrder  = "purchase_order"
+	TypeGoodsReceipt   = "goods_receipt"
+	TypeExpense        = "expense"
+	TypeWage           = "wage"
+	TypeRecapitulation = "recapitulation"
+	TypeInvoice        = "supplier_invoice"
+	TypePayment        = "supplier_payment"
+	TypeReceipt        = "customer_receipt"
+	TypeAdvance        = "employee_advance"
+	TypeTransfer       = "bank_transfer"
+)
+
+const (
+	ResetNever   = "never"
+	ResetDaily   = "daily"
+	ResetMonthly = "monthly"
+	ResetYearly  = "yearly"
+)
+
+const (
+	ScopeGlobal  = "global"
+	ScopeCompany = "company"
+	ScopeOutlet  = "outlet"
+)
+
+// Counter is the last number issued for a document type within a scope and
+// period. Unused scope columns are stored as 0 so the unique index holds.
+type Counter struct {
+	common.BaseModel
+	Type      string `json:"type" gorm:"type:varchar(50);uniqueIndex:idx_sequence_counter"`
+	CompanyID uint   `json:"company" gorm:"uniqueIndex:idx_sequence_counter"`
+	OutletID  uint   `json:"outlet" gorm:"uniqueIndex:idx_sequence_counter"`
+	Period    string `json:"period" gorm:"type:varchar(20);uniqueIndex:idx_sequence_counter"`
+	Value     int64  `json:"value"`
+}
+
+func (Counter) TableName() string {
+	return "sequence_counters"
+}
+
+// Format describes how codes of a document type are rendered. A format with
+// CompanyID 0 applies to every company without its own format.
+//
+// The template understands {prefix}, {yyyy}, {yy}, {mm}, {dd}, {company},
+// {outlet} and {seq}, the latter padded with zeros to Padding digits.
+type Format struct {
+	common.BaseModel
+	Type      string `json:"type" gorm:"type:varchar(50);uniqueIndex:idx_sequence_format"`
+	Prefix    string `json:"prefix" gorm:"type:varchar(20)"`
+	Template  string `json:"template" gorm:"type:varchar(100)"`
+	Padding   int    `json:"padding"`
+	Reset     string `json:"reset" gorm:"type:enum('never','daily','monthly','yearly')" enums:"never,daily,monthly,yearly"`
+	Scope     string `json:"scope" gorm:"type:enum('global','company','outlet')" enums:"global,company,outlet"`
+	CompanyID uint   `json:"company" gorm:"uniqueIndex:idx_sequence_format"`
+}
+
+func (Format) TableName() string {
+	return "sequence_formats"
+}
+
+// Period returns the counter period of a date under the format's reset rule.
+func (f *Format) Period(date time.Time) string {
+	switch f.Reset {
+	case ResetDaily:
+		return date.Format("20060102")
+	case ResetMonthly:
+		return date.Format("200601")
+	case ResetYearly:
+		return date.Format("2006")
+	default:
+		return ""
+	}
+}
+
+const defaultTemplate = "{prefix}-{yyyy}{mm}{dd}{seq}"
+
+// defaults keeps the codes that were generated before formats existed.
+var defaults = map[string]string{
+	TypeSale:           "TXS",
+	TypeSaleReturn:     "TXR",
+	TypePurchase:       "TXP",
+	TypePurchaseOrder:  "PO",
+	TypeGoodsReceipt:   "GRN",
+	TypeExpense:        "EXP",
+	TypeWage:           "WGS",
+	TypeRecapitulation: "STX",
+	TypeInvoice:        "INV",
+	TypePayment:        "PAY",
+	TypeReceipt:        "RCV",
+	TypeAdvance:        "ADV",
+	TypeTransfer:       "TRF",
+}
+
+func defaultFormat(doc string) Format {
+	format := Format{
+		Type:     doc,
+		Prefix:   defaults[doc],
+		Template: defaultTemplate,
+		Padding:  3,
+		Reset:    ResetDaily,
+		Scope:    ScopeGlobal,
+	}
+
+	// Stock recaps are numbered per outlet
+	if doc == TypeRecapitulation {
+		format.Template = "{prefix}-{yyyy}{mm}{dd}{outlet}{seq}"
+		format.Scope = ScopeOutlet
+	}
+
+	return format
+}
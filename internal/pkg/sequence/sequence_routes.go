@@ -0,0 +1,12 @@
+package sequence
+
+import "abude-backend/internal/common"
+
+func LoadRoutes(r *common.Router) {
+	sequenceService := NewService(r.DB)
+	sequenceHandler := NewController(r.Controller, sequenceService)
+
+	r.Router.Get("/sequence", r.Auth(2), sequenceHandler.All)
+	r.Router.Get("/sequence/preview", r.Auth(1), sequenceHandler.Preview)
+	r.Router.Put("/sequence", r.Auth(2), sequenceHandler.Save)
+}
@@ -0,0 +1,224 @@
+package sequence
+
+import (
+	"abude-backend/pkg/exception"
+	"abude-backend/pkg/utils"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SequenceService struct {
+	db *gorm.DB
+}
+
+func NewService(db *gorm.DB) *SequenceService {
+	return &SequenceService{db}
+}
+
+// Next issues the next code of a document type. The counter row is locked
+// until the surrounding transaction ends, so concurrent callers are
+// serialized and never receive the same number. Call it with the
+// transaction that inserts the document.
+func Next(tx *gorm.DB, doc string, company uint, outlet uint, date time.Time) (string, error) {
+	format, err := NewService(tx).findFormat(doc, company)
+	if err != nil {
+		return "", err
+	}
+
+	counter := Counter{
+		Type:   doc,
+		Period: format.Period(date),
+	}
+
+	switch format.Scope {
+	case ScopeCompany:
+		counter.CompanyID = company
+	case ScopeOutlet:
+		counter.CompanyID = company
+		counter.OutletID = outlet
+	}
+
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&counter).Error; err != nil {
+		return "", err
+	}
+
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("type = ? AND company_id = ? AND outlet_id = ? AND period = ?", counter.Type, counter.CompanyID, counter.OutletID, counter.Period).
+		First(&counter).Error; err != nil {
+		return "", err
+	}
+
+	counter.Value++
+
+	if err := tx.Model(&counter).Update("value", counter.Value).Error; err != nil {
+		return "", err
+	}
+
+	return format.Render(counter.Value, company, outlet, date), nil
+}
+
+// NextForOutlet issues the next code for a document that only knows its
+// outlet, looking up the outlet's company.
+func NextForOutlet(tx *gorm.DB, doc string, outlet uint, date time.Time) (string, error) {
+	var company uint
+	if err := tx.Table("outlets").Select("company_id").Where("id = ?", outlet).Scan(&company).Error; err != nil {
+		return "", err
+	}
+
+	return Next(tx, doc, company, outlet, date)
+}
+
+// Render fills the template with the given number and date.
+func (f *Format) Render(value int64, company uint, outlet uint, date time.Time) string {
+	replacer := strings.NewReplacer(
+		"{prefix}", f.Prefix,
+		"{yyyy}", date.Format("2006"),
+		"{yy}", date.Format("06"),
+		"{mm}", date.Format("01"),
+		"{dd}", date.Format("02"),
+		"{company}", fmt.Sprint(company),
+		"{outlet}", fmt.Sprint(outlet),
+		"{seq}", utils.NumberToDigit(int(value), f.Padding),
+	)
+
+	return replacer.Replace(f.Template)
+}
+
+func (s *SequenceService) FindAll(query FormatQuery) ([]Format, error) {
+	var formats []Format
+	if err := s.db.Where("company_id IN (?)", []uint{0, query.Company}).Order("type ASC, company_id ASC").Find(&formats).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	stored := make(map[string]bool)
+	for _, format := range formats {
+		stored[format.Type] = true
+	}
+
+	// Types without a stored format are shown with their built-in default
+	for doc := range defaults {
+		if !stored[doc] {
+			formats = append(formats, defaultFormat(doc))
+		}
+	}
+
+	return formats, nil
+}
+
+// Save creates or replaces the format of a document type for a company, or
+// the global one when no company is given.
+func (s *SequenceService) Save(data FormatDTO) (*Format, error) {
+	if data.Scope == ScopeOutlet && !strings.Contains(data.Template, "{outlet}") {
+		return nil, exception.Validation(map[string]string{
+			"template": "Template wajib memuat {outlet} untuk penomoran per outlet",
+		})
+	}
+
+	if data.Scope == ScopeCompany && !strings.Contains(data.Template, "{company}") && !strings.Contains(data.Template, "{outlet}") {
+		return nil, exception.Validation(map[string]string{
+			"template": "Template wajib memuat {company} untuk penomoran per perusahaan",
+		})
+	}
+
+	var format Format
+	if err := s.db.Where("type = ? AND company_id = ?", data.Type, data.Company).First(&format).Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, exception.DB(err)
+	}
+
+	format.Type = data.Type
+	format.Prefix = data.Prefix
+	format.Template = data.Template
+	format.Padding = data.Padding
+	format.Reset = data.Reset
+	format.Scope = data.Scope
+	format.CompanyID = data.Company
+
+	if format.Padding == 0 {
+		format.Padding = 3
+	}
+
+	if err := s.db.Save(&format).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &format, nil
+}
+
+// Preview renders the code the next document would get, without issuing it.
+func (s *SequenceService) Preview(query PreviewQuery) (string, error) {
+	if _, ok := defaults[query.Type]; !ok {
+		return "", exception.BadRequest("Tipe dokumen tidak valid")
+	}
+
+	format, err := s.findFormat(query.Type, query.Company)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	counter := Counter{
+		Type:   query.Type,
+		Period: format.Period(now),
+	}
+
+	switch format.Scope {
+	case ScopeCompany:
+		counter.CompanyID = query.Company
+	case ScopeOutlet:
+		counter.CompanyID = query.Company
+		counter.OutletID = query.Outlet
+	}
+
+	if err := s.db.
+		Where("type = ? AND company_id = ? AND outlet_id = ? AND period = ?", counter.Type, counter.CompanyID, counter.OutletID, counter.Period).
+		Limit(1).
+		Find(&counter).Error; err != nil {
+		return "", exception.DB(err)
+	}
+
+	return format.Render(counter.Value+1, query.Company, query.Outlet, now), nil
+}
+
+// findFormat returns the company's format, then the global one, then the
+// built-in default.
+func (s *SequenceService) findFormat(doc string, company uint) (*Format, error) {
+	var formats []Format
+	if err := s.db.
+		Where("type = ? AND company_id IN (?)", doc, []uint{0, company}).
+		Order("company_id DESC").
+		Limit(1).
+		Find(&formats).Error; err != nil {
+		return nil, err
+	}
+
+	if len(formats) == 0 {
+		format := defaultFormat(doc)
+		return &format, nil
+	}
+
+	return &formats[0], nil
+}
+
+func (s *SequenceService) Using(tx *gorm.DB) *SequenceService {
+	db := s.db
+
+	defer func() {
+		s.db = db
+	}()
+
+	s.db = tx.WithContext(tx.Statement.Context)
+
+	return s
+}
+
+func (s *SequenceService) WithContext(ctx context.Context) *SequenceService {
+	s.db = s.db.WithContext(ctx)
+
+	return s
+}
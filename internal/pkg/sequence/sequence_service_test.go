@@ -0,0 +1,184 @@
+package sequence
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// open connects to the MySQL database in SEQUENCE_TEST_DSN, whose counter rows
+// are locked as in production, or else to a SQLite file that serializes the
+// transactions as a whole.
+func open(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	config := &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)}
+
+	var db *gorm.DB
+	var err error
+	if dsn := os.Getenv("SEQUENCE_TEST_DSN"); dsn != "" {
+		db, err = gorm.Open(mysql.Open(dsn), config)
+	} else {
+		path := filepath.Join(t.TempDir(), "sequence.db")
+		db, err = gorm.Open(sqlite.Open(path+"?_txlock=immediate&_busy_timeout=10000"), config)
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := db.Migrator().DropTable(&Counter{}, &Format{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := db.AutoMigrate(&Counter{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// SQLite has no enum columns
+	if db.Dialector.Name() == "sqlite" {
+		err = db.Exec("CREATE TABLE sequence_formats (id integer PRIMARY KEY AUTOINCREMENT, created_at datetime, updated_at datetime, " +
+			"type text, prefix text, template text, padding integer, reset text, scope text, company_id integer, " +
+			"UNIQUE (type, company_id))").Error
+	} else {
+		err = db.AutoMigrate(&Format{})
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return db
+}
+
+// hammer issues codes from many goroutines at once, each in its own
+// transaction, and returns every code issued.
+func hammer(t *testing.T, db *gorm.DB, workers int, each int, issue func(tx *gorm.DB, worker int) (string, error)) []string {
+	t.Helper()
+
+	var mu sync.Mutex
+	var codes []string
+	var errs []error
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+
+			for j := 0; j < each; j++ {
+				var code string
+				err := db.Transaction(func(tx *gorm.DB) error {
+					var err error
+					code, err = issue(tx, worker)
+
+					return err
+				})
+
+				mu.Lock()
+				if err != nil {
+					errs = append(errs, err)
+				} else {
+					codes = append(codes, code)
+				}
+				mu.Unlock()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		t.Error(err)
+	}
+
+	if t.Failed() {
+		t.FailNow()
+	}
+
+	return codes
+}
+
+func unique(t *testing.T, codes []string, want int) {
+	t.Helper()
+
+	seen := make(map[string]bool)
+	for _, code := range codes {
+		if seen[code] {
+			t.Errorf("code %s was issued twice", code)
+		}
+		seen[code] = true
+	}
+
+	if len(seen) != want {
+		t.Errorf("issued %d unique codes, want %d", len(seen), want)
+	}
+}
+
+func TestNextConcurrent(t *testing.T) {
+	db := open(t)
+	date := time.Now()
+
+	const workers, each = 16, 25
+	codes := hammer(t, db, workers, each, func(tx *gorm.DB, _ int) (string, error) {
+		return Next(tx, TypeSale, 1, 1, date)
+	})
+
+	unique(t, codes, workers*each)
+
+	var counter Counter
+	if err := db.Where("type = ?", TypeSale).First(&counter).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if counter.Value != workers*each {
+		t.Errorf("counter is at %d, want %d", counter.Value, workers*each)
+	}
+}
+
+func TestNextConcurrentPerOutlet(t *testing.T) {
+	db := open(t)
+	date := time.Date(2024, 3, 5, 0, 0, 0, 0, time.Local)
+
+	if err := db.Create(&Format{
+		Type:      TypeSale,
+		Prefix:    "TX",
+		Template:  "{prefix}-{outlet}-{yy}{mm}-{seq}",
+		Padding:   4,
+		Reset:     ResetMonthly,
+		Scope:     ScopeOutlet,
+		CompanyID: 1,
+	}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	// Workers share two outlets, each outlet counts on its own
+	const workers, each = 8, 25
+	codes := hammer(t, db, workers, each, func(tx *gorm.DB, worker int) (string, error) {
+		return Next(tx, TypeSale, 1, uint(worker%2+1), date)
+	})
+
+	unique(t, codes, workers*each)
+
+	for outlet := 1; outlet <= 2; outlet++ {
+		last := fmt.Sprintf("TX-%d-2403-%04d", outlet, workers*each/2)
+
+		found := false
+		for _, code := range codes {
+			if code == last {
+				found = true
+			}
+		}
+
+		if !found {
+			t.Errorf("outlet %d did not reach code %s", outlet, last)
+		}
+	}
+}
@@ -3,10 +3,14 @@ package supplier
 import (
 	"abude-backend/internal/common"
 	"abude-backend/internal/pkg/company"
+
+	"gorm.io/gorm"
 )
 
 type Supplier struct {
 	common.BaseModel
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
 	Name        string `json:"name" gorm:"type:varchar(100)"`
 	Description string `json:"description" gorm:"type:varchar(255)"`
 
@@ -0,0 +1,227 @@
+package expense
+
+import (
+	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/auth"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ApprovalController struct {
+	*common.BaseController
+	approval *ApprovalService
+}
+
+func NewApprovalController(ctrl *common.BaseController, approval *ApprovalService) *ApprovalController {
+	return &ApprovalController{ctrl, approval}
+}
+
+// @Summary Get Expense Approval Rules
+// @Tags Expense Approvals
+// @Accept json
+// @Produce json
+// @Param query query ApprovalRuleQuery false "query"
+// @Success 200 {object} []ApprovalRule
+// @Security JWT
+// @Router /api/expense/approval/rule [get]
+func (ctrl *ApprovalController) Rules(ctx *fiber.Ctx) error {
+	var query ApprovalRuleQuery
+	if err := ctrl.Validation.Query(&query, ctx); err != nil {
+		return err
+	}
+
+	rules, err := ctrl.approval.FindRules(query)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(rules)
+}
+
+// @Summary Create Expense Approval Rule
+// @Tags Expense Approvals
+// @Accept json
+// @Produce json
+// @Param request body ApprovalRuleDTO true "Request Body"
+// @Success 201 {object} common.GeneralResponse{result=ApprovalRule}
+// @Security JWT
+// @Router /api/expense/approval/rule [post]
+func (ctrl *ApprovalController) CreateRule(ctx *fiber.Ctx) error {
+	var data ApprovalRuleDTO
+	if err := ctrl.Validation.Body(&data, ctx); err != nil {
+		return err
+	}
+
+	rule, err := ctrl.approval.CreateRule(data)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(common.GeneralResponse{
+		Message: "Aturan persetujuan berhasil dibuat",
+		Result:  rule,
+	})
+}
+
+// @Summary Update Expense Approval Rule
+// @Tags Expense Approvals
+// @Accept json
+// @Produce json
+// @Param id path string true "Approval Rule ID"
+// @Param request body ApprovalRuleDTO true "Request Body"
+// @Success 200 {object} common.GeneralResponse{result=ApprovalRule}
+// @Security JWT
+// @Router /api/expense/approval/rule/{id} [put]
+func (ctrl *ApprovalController) UpdateRule(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	var data ApprovalRuleDTO
+	if err := ctrl.Validation.Body(&data, ctx); err != nil {
+		return err
+	}
+
+	rule, err := ctrl.approval.UpdateRule(id, data)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.GeneralResponse{
+		Message: "Aturan persetujuan berhasil diubah",
+		Result:  rule,
+	})
+}
+
+// @Summary Delete Expense Approval Rule
+// @Tags Expense Approvals
+// @Accept json
+// @Produce json
+// @Param id path string true "Approval Rule ID"
+// @Success 200 {object} common.GeneralResponse{result=ApprovalRule}
+// @Security JWT
+// @Router /api/expense/approval/rule/{id} [delete]
+func (ctrl *ApprovalController) DeleteRule(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	rule, err := ctrl.approval.DeleteRule(id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.GeneralResponse{
+		Message: "Aturan persetujuan berhasil dihapus",
+		Result:  rule,
+	})
+}
+
+// @Summary Get Approvals Inbox
+// @Description Lists the open approval steps the current user can decide
+// @Tags Expense Approvals
+// @Accept json
+// @Produce json
+// @Param query query ApprovalQuery false "query"
+// @Success 200 {object} common.PaginatedResponse{result=[]Approval}
+// @Security JWT
+// @Router /api/expense/approval [get]
+func (ctrl *ApprovalController) Inbox(ctx *fiber.Ctx) error {
+	var query ApprovalQuery
+	if err := ctrl.Validation.Query(&query, ctx); err != nil {
+		return err
+	}
+
+	creds := auth.GetCreds(ctx.Context())
+	result := ctrl.approval.Inbox(creds, query)
+
+	return ctx.Status(fiber.StatusOK).JSON(result)
+}
+
+// @Summary Get Expense Approval Chain
+// @Tags Expense Approvals
+// @Accept json
+// @Produce json
+// @Param id path string true "Expense ID"
+// @Success 200 {object} []Approval
+// @Security JWT
+// @Router /api/expense/{id}/approval [get]
+func (ctrl *ApprovalController) All(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	approvals, err := ctrl.approval.FindAll(id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(approvals)
+}
+
+// @Summary Approve Expense Approval Step
+// @Tags Expense Approvals
+// @Accept json
+// @Produce json
+// @Param id path string true "Approval ID"
+// @Param request body ApprovalDecisionDTO true "Request Body"
+// @Success 200 {object} common.GeneralResponse{result=Approval}
+// @Security JWT
+// @Router /api/expense/approval/{id}/approve [patch]
+func (ctrl *ApprovalController) Approve(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	var data ApprovalDecisionDTO
+	if err := ctrl.Validation.Body(&data, ctx); err != nil {
+		return err
+	}
+
+	creds := auth.GetCreds(ctx.Context())
+	approval, err := ctrl.approval.Approve(id, creds, data)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.GeneralResponse{
+		Message: "Pengeluaran berhasil disetujui",
+		Result:  approval,
+	})
+}
+
+// @Summary Reject Expense Approval Step
+// @Tags Expense Approvals
+// @Accept json
+// @Produce json
+// @Param id path string true "Approval ID"
+// @Param request body ApprovalDecisionDTO true "Request Body"
+// @Success 200 {object} common.GeneralResponse{result=Approval}
+// @Security JWT
+// @Router /api/expense/approval/{id}/reject [patch]
+func (ctrl *ApprovalController) Reject(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	var data ApprovalDecisionDTO
+	if err := ctrl.Validation.Body(&data, ctx); err != nil {
+		return err
+	}
+
+	creds := auth.GetCreds(ctx.Context())
+	approval, err := ctrl.approval.Reject(id, creds, data)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.GeneralResponse{
+		Message: "Pengeluaran berhasil ditolak",
+		Result:  approval,
+	})
+}
@@ -0,0 +1,23 @@
+package expense
+
+import "abude-backend/pkg/pagination"
+
+type ApprovalRuleDTO struct {
+	MinAmount float64 `json:"minAmount" form:"minAmount" validate:"min=0"`
+	Role      string  `json:"role" form:"role" validate:"required,oneof=admin owner" enums:"admin,owner"`
+	Company   uint    `json:"company" form:"company" validate:"required,exist=companies"`
+}
+
+type ApprovalRuleQuery struct {
+	Company uint `query:"company" validate:"required"`
+}
+
+type ApprovalDecisionDTO struct {
+	Comment string `json:"comment" form:"comment" validate:"omitempty,max=255"`
+}
+
+type ApprovalQuery struct {
+	pagination.Pagination
+	Company uint `query:"company"`
+	Outlet  uint `query:"outlet"`
+}
@@ -0,0 +1,57 @@
+package expense
+
+import (
+	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/company"
+	"abude-backend/internal/pkg/user"
+	"time"
+)
+
+const (
+	ApprovalPending  = "pending"
+	ApprovalApproved = "approved"
+	ApprovalRejected = "rejected"
+)
+
+const (
+	ApproverAdmin = "admin" // Admin of the expense's outlet
+	ApproverOwner = "owner" // Owner of the expense's company
+)
+
+// ApprovalRule requires an approval from the given role for expenses of the
+// company whose amount is over MinAmount. An expense needs the approvals of
+// every rule it exceeds, from the lowest amount up.
+type ApprovalRule struct {
+	common.BaseModel
+	MinAmount float64 `json:"minAmount"`
+	Role      string  `json:"role" gorm:"type:enum('admin','owner')" enums:"admin,owner"`
+
+	Company   *company.Company `json:"company,omitempty" gorm:"constraint:OnDelete:CASCADE;"`
+	CompanyID uint             `json:"-"`
+}
+
+func (ApprovalRule) TableName() string {
+	return "expense_approval_rules"
+}
+
+// Approval is a single step of an expense's approval chain. Steps are
+// decided in order, so a step is only open once all previous steps are
+// approved.
+type Approval struct {
+	common.BaseModel
+	Step      int        `json:"step"`
+	Role      string     `json:"role" gorm:"type:enum('admin','owner')" enums:"admin,owner"`
+	Status    string     `json:"status" gorm:"type:enum('pending','approved','rejected')" enums:"pending,approved,rejected"`
+	Comment   string     `json:"comment"`
+	DecidedAt *time.Time `json:"decidedAt"`
+
+	Expense   *Expense `json:"expense,omitempty" gorm:"constraint:OnDelete:CASCADE;"`
+	ExpenseID uint     `json:"-"`
+
+	User   *user.User `json:"user,omitempty" gorm:"constraint:OnDelete:SET NULL;"`
+	UserID *uint      `json:"-"`
+}
+
+func (Approval) TableName() string {
+	return "expense_approvals"
+}
@@ -0,0 +1,307 @@
+package expense
+
+import (
+	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/accounts/journal"
+	"abude-backend/internal/pkg/accounts/period"
+	"abude-backend/internal/pkg/user"
+	"abude-backend/pkg/exception"
+	"abude-backend/pkg/pagination"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ApprovalService struct {
+	db *gorm.DB
+}
+
+func NewApprovalService(db *gorm.DB) *ApprovalService {
+	return &ApprovalService{db}
+}
+
+func (s *ApprovalService) FindRules(query ApprovalRuleQuery) ([]ApprovalRule, error) {
+	rules := []ApprovalRule{}
+	if err := s.db.Where("company_id = ?", query.Company).Order("min_amount ASC").Find(&rules).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return rules, nil
+}
+
+func (s *ApprovalService) CreateRule(data ApprovalRuleDTO) (*ApprovalRule, error) {
+	rule := ApprovalRule{
+		MinAmount: data.MinAmount,
+		Role:      data.Role,
+		CompanyID: data.Company,
+	}
+
+	if err := s.db.Create(&rule).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &rule, nil
+}
+
+func (s *ApprovalService) UpdateRule(id int, data ApprovalRuleDTO) (*ApprovalRule, error) {
+	var rule ApprovalRule
+	if err := s.db.First(&rule, id).Error; err != nil {
+		return nil, exception.DB(err, "Aturan persetujuan")
+	}
+
+	rule.MinAmount = data.MinAmount
+	rule.Role = data.Role
+	rule.CompanyID = data.Company
+
+	if err := s.db.Save(&rule).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &rule, nil
+}
+
+func (s *ApprovalService) DeleteRule(id int) (*ApprovalRule, error) {
+	var rule ApprovalRule
+	if err := s.db.First(&rule, id).Error; err != nil {
+		return nil, exception.DB(err, "Aturan persetujuan")
+	}
+
+	if err := s.db.Delete(&rule).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &rule, nil
+}
+
+// FindAll returns the approval chain of an expense.
+func (s *ApprovalService) FindAll(expense int) ([]Approval, error) {
+	approvals := []Approval{}
+	if err := s.db.Preload("User").Where("expense_id = ?", expense).Order("step ASC").Find(&approvals).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return approvals, nil
+}
+
+// Inbox lists the open approval steps the user is allowed to decide.
+func (s *ApprovalService) Inbox(creds *common.Creds, query ApprovalQuery) *pagination.Result[Approval] {
+	result := pagination.New[Approval](query.Pagination)
+
+	db := s.db.Model(&Approval{}).Preload("Expense").Preload("Expense.Account").Preload("Expense.Outlet")
+	db.Joins("INNER JOIN expenses ON expenses.id = expense_approvals.expense_id AND expenses.deleted_at IS NULL")
+	db.Where("expense_approvals.status = ?", ApprovalPending)
+	db.Where("NOT EXISTS (?)", s.db.
+		Table("expense_approvals AS prior").
+		Select("1").
+		Where("prior.expense_id = expense_approvals.expense_id AND prior.step < expense_approvals.step AND prior.status <> ?", ApprovalApproved),
+	)
+
+	if creds.Role != user.RoleSuperadmin {
+		db.Where("(expenses.company_id IN (?) OR (expense_approvals.role = ? AND expenses.outlet_id IN (?)))",
+			ownedCompanies(s.db, creds.ID),
+			ApproverAdmin,
+			administeredOutlets(s.db, creds.ID),
+		)
+	}
+
+	if query.Company != 0 {
+		db.Where("expenses.company_id = ?", query.Company)
+	}
+
+	if query.Outlet != 0 {
+		db.Where("expenses.outlet_id = ?", query.Outlet)
+	}
+
+	db.Order("expense_approvals.created_at ASC")
+
+	return result.Paginate(db)
+}
+
+func (s *ApprovalService) Approve(id int, creds *common.Creds, data ApprovalDecisionDTO) (*Approval, error) {
+	return s.decide(id, creds, data, ApprovalApproved)
+}
+
+func (s *ApprovalService) Reject(id int, creds *common.Creds, data ApprovalDecisionDTO) (*Approval, error) {
+	return s.decide(id, creds, data, ApprovalRejected)
+}
+
+func (s *ApprovalService) decide(id int, creds *common.Creds, data ApprovalDecisionDTO, status string) (*Approval, error) {
+	var approval Approval
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&approval, id).Error; err != nil {
+			return exception.DB(err, "Persetujuan")
+		}
+
+		if approval.Status != ApprovalPending {
+			return exception.BadRequest("Persetujuan telah diputuskan")
+		}
+
+		var expense Expense
+		if err := tx.First(&expense, approval.ExpenseID).Error; err != nil {
+			return exception.DB(err, "Pengeluaran")
+		}
+
+		if expense.Status == StatusCanceled {
+			return exception.BadRequest("Pengeluaran telah dibatalkan")
+		}
+
+		var open int64
+		if err := tx.Model(&Approval{}).
+			Where("expense_id = ? AND step < ? AND status <> ?", approval.ExpenseID, approval.Step, ApprovalApproved).
+			Count(&open).Error; err != nil {
+			return err
+		}
+
+		if open > 0 {
+			return exception.BadRequest("Persetujuan sebelumnya belum selesai")
+		}
+
+		allowed, err := canDecide(tx, creds, &approval, &expense)
+		if err != nil {
+			return err
+		}
+
+		if !allowed {
+			return exception.Forbidden()
+		}
+
+		now := time.Now()
+		approval.Status = status
+		approval.Comment = data.Comment
+		approval.UserID = &creds.ID
+		approval.DecidedAt = &now
+
+		if err := tx.Save(&approval).Error; err != nil {
+			return err
+		}
+
+		var pending int64
+		if err := tx.Model(&Approval{}).Where("expense_id = ? AND status = ?", expense.ID, ApprovalPending).Count(&pending).Error; err != nil {
+			return err
+		}
+
+		if status == ApprovalApproved && pending > 0 {
+			return nil
+		}
+
+		// The decision changes what the journal holds for the expense
+		if err := period.Check(tx, expense.CompanyID, expense.Date); err != nil {
+			return err
+		}
+
+		if err := tx.Model(&expense).Update("approval_status", status).Error; err != nil {
+			return err
+		}
+
+		if status == ApprovalRejected {
+			return journal.Reverse(tx, journal.SourceExpense, expense.ID, time.Now())
+		}
+
+		expense.ApprovalStatus = status
+		if expense.Posted() {
+			return journal.Post(tx, expense.Posting())
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &approval, nil
+}
+
+// canDecide reports whether the user holds the role of the approval step.
+// Company owners may also decide the steps meant for outlet admins.
+func canDecide(tx *gorm.DB, creds *common.Creds, approval *Approval, expense *Expense) (bool, error) {
+	if creds.Role == user.RoleSuperadmin {
+		return true, nil
+	}
+
+	var count int64
+	if err := ownedCompanies(tx, creds.ID).Where("company_id = ?", expense.CompanyID).Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	if count > 0 {
+		return true, nil
+	}
+
+	if approval.Role != ApproverAdmin {
+		return false, nil
+	}
+
+	if err := administeredOutlets(tx, creds.ID).Where("outlet_employees.outlet_id = ?", expense.OutletID).Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func ownedCompanies(db *gorm.DB, userId uint) *gorm.DB {
+	return db.Table("company_owners").Select("company_id").Where("user_id = ?", userId)
+}
+
+func administeredOutlets(db *gorm.DB, userId uint) *gorm.DB {
+	return db.Table("outlet_employees").
+		Select("outlet_employees.outlet_id").
+		Joins("INNER JOIN employees ON employees.id = outlet_employees.employee_id AND employees.deleted_at IS NULL").
+		Where("outlet_employees.type = ? AND employees.user_id = ?", ApproverAdmin, userId)
+}
+
+// submit builds the approval chain of an expense from the rules of its
+// company, replacing any previous chain. Expenses that no rule applies to
+// are approved right away.
+func submit(tx *gorm.DB, expense *Expense) error {
+	if err := tx.Where("expense_id = ?", expense.ID).Delete(&Approval{}).Error; err != nil {
+		return err
+	}
+
+	var rules []ApprovalRule
+	if err := tx.Where("company_id = ? AND min_amount < ?", expense.CompanyID, expense.Amount).
+		Order("min_amount ASC").
+		Find(&rules).Error; err != nil {
+		return err
+	}
+
+	expense.ApprovalStatus = ApprovalApproved
+	if len(rules) > 0 {
+		expense.ApprovalStatus = ApprovalPending
+	}
+
+	for i, rule := range rules {
+		approval := Approval{
+			Step:      i + 1,
+			Role:      rule.Role,
+			Status:    ApprovalPending,
+			ExpenseID: expense.ID,
+		}
+
+		if err := tx.Create(&approval).Error; err != nil {
+			return err
+		}
+	}
+
+	return tx.Model(expense).Update("approval_status", expense.ApprovalStatus).Error
+}
+
+func (s *ApprovalService) Using(tx *gorm.DB) *ApprovalService {
+	db := s.db
+
+	defer func() {
+		s.db = db
+	}()
+
+	s.db = tx.WithContext(tx.Statement.Context)
+
+	return s
+}
+
+func (s *ApprovalService) WithContext(ctx context.Context) *ApprovalService {
+	s.db = s.db.WithContext(ctx)
+
+	return s
+}
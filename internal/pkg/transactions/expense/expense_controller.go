@@ -2,6 +2,8 @@ package expense
 
 import (
 	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/auth"
+	"abude-backend/internal/pkg/transition"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -57,6 +59,7 @@ func (ctrl *ExpenseController) All(ctx *fiber.Ctx) error {
 }
 
 // @Summary Create Expense
+// @Description Expenses over the budget of their account are still created, with the exceeded budgets listed in warnings
 // @Tags Expenses
 // @Accept json
 // @Produce json
@@ -164,6 +167,7 @@ func (ctrl *ExpenseController) GetSummary(ctx *fiber.Ctx) error {
 // @Accept json
 // @Produce json
 // @Param id path string true "Expense ID"
+// @Param request body transition.ReasonDTO false "Request Body"
 // @Success 200 {object} common.BasicResponse{}
 // @Security JWT
 // @Router /api/expense/{id}/cancel [patch]
@@ -173,7 +177,12 @@ func (ctrl *ExpenseController) Cancel(ctx *fiber.Ctx) error {
 		return err
 	}
 
-	if err := ctrl.expense.SetStatus(id, "canceled"); err != nil {
+	reason, err := transition.Reason(ctrl.Validation, ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := ctrl.expense.SetStatus(id, "canceled", auth.GetCreds(ctx.Context()), reason); err != nil {
 		return err
 	}
 
@@ -13,14 +13,18 @@ type ExpenseDTO struct {
 	Account uint      `json:"account" form:"account" validate:"required,exist=accounts"`
 	Company uint      `json:"company" form:"company" validate:"required,exist=companies"`
 	Outlet  uint      `json:"outlet" form:"outlet" validate:"required,exist=outlets"`
+
+	// Status of a new expense other than accepted, such as a payroll draft
+	Status string `json:"-" form:"-"`
 }
 
 type ExpenseQuery struct {
 	pagination.Pagination
-	Status  []string `query:"status"`
-	Account uint     `query:"account"`
-	Company uint     `query:"company"`
-	Outlet  uint     `query:"outlet"`
+	Status         []string `query:"status"`
+	ApprovalStatus []string `query:"approvalStatus" enums:"pending,approved,rejected"`
+	Account        uint     `query:"account"`
+	Company        uint     `query:"company"`
+	Outlet         uint     `query:"outlet"`
 }
 
 type ExpenseSummaryQuery struct {
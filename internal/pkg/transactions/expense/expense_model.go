@@ -3,11 +3,12 @@ package expense
 import (
 	"abude-backend/internal/common"
 	"abude-backend/internal/pkg/accounts/account"
+	"abude-backend/internal/pkg/accounts/journal"
 	"abude-backend/internal/pkg/company"
 	"abude-backend/internal/pkg/outlet"
+	"abude-backend/internal/pkg/sequence"
 	"abude-backend/internal/pkg/user"
-	"abude-backend/pkg/utils"
-	"fmt"
+	"abude-backend/pkg/file"
 	"time"
 
 	"gorm.io/gorm"
@@ -19,6 +20,7 @@ const (
 )
 
 const (
+	StatusDraft    = "draft" // Generated by payroll and not yet approved
 	StatusAccepted = "accepted"
 	StatusApproved = "approved"
 	StatusCanceled = "canceled"
@@ -26,14 +28,19 @@ const (
 
 type Expense struct {
 	common.BaseModel
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
 	user.WithEditor
 	Code   string    `json:"code" gorm:"type:varchar(100)"`
 	Amount float64   `json:"amount"`
 	Type   string    `json:"type" gorm:"type:enum('debit','credit')" enums:"debit,credit"`
-	Status string    `json:"status" gorm:"type:enum('accepted','approved','canceled')" enums:"approved,accepted,canceled"`
+	Status string    `json:"status" gorm:"type:enum('draft','accepted','approved','canceled')" enums:"draft,approved,accepted,canceled"`
 	Date   time.Time `json:"date"`
 	Notes  string    `json:"notes"`
 
+	// Outcome of the approval chain, see ApprovalRule
+	ApprovalStatus string `json:"approvalStatus" gorm:"type:enum('pending','approved','rejected');default:approved" enums:"pending,approved,rejected"`
+
 	Company   *company.Company `json:"company,omitempty" gorm:"constraint:OnDelete:CASCADE;"`
 	CompanyID uint             `json:"-"`
 
@@ -42,23 +49,58 @@ type Expense struct {
 
 	Account   *account.Account `json:"account,omitempty" gorm:"constraint:OnDelete:RESTRICT;"`
 	AccountID uint             `json:"-"`
+
+	Attachments []file.File `json:"attachments,omitempty" gorm:"many2many:expense_attachments;constraint:OnDelete:CASCADE;"`
+
+	// Budgets the expense exceeds, filled on create
+	Warnings []string `json:"warnings,omitempty" gorm:"-"`
 }
 
 func (expense *Expense) BeforeCreate(tx *gorm.DB) error {
-	if expense.Code == "" {
-		now := time.Now()
-
-		var count int64
-		tx.Model(&Expense{}).
-			Where("DATE(created_at) = ?", now.Format("2006-01-02")).
-			Count(&count)
+	if expense.Code != "" {
+		return nil
+	}
 
-		expense.Code = fmt.Sprintf("EXP-%s%s", now.Format("20060102"), utils.NumberToDigit(int(count+1), 3))
+	code, err := sequence.Next(tx, sequence.TypeExpense, expense.CompanyID, expense.OutletID, time.Now())
+	if err != nil {
+		return err
 	}
 
+	expense.Code = code
+
 	return nil
 }
 
+// Posted reports whether the expense belongs in the journal. Payroll drafts,
+// canceled expenses and expenses still waiting for or refused approval stay
+// out of it.
+func (expense *Expense) Posted() bool {
+	return (expense.Status == StatusAccepted || expense.Status == StatusApproved) && expense.ApprovalStatus == ApprovalApproved
+}
+
+// Posting books the expense against cash, or against payables when it is
+// owed. Draft expenses are posted once their payroll period is approved.
+func (expense *Expense) Posting() journal.Posting {
+	credit := journal.KeyCash
+	if expense.Type == TypeCredit {
+		credit = journal.KeyPayable
+	}
+
+	return journal.Posting{
+		Company:     expense.CompanyID,
+		Outlet:      &expense.OutletID,
+		User:        expense.EditorID,
+		Date:        expense.Date,
+		Source:      journal.SourceExpense,
+		SourceID:    expense.ID,
+		Description: "Pengeluaran " + expense.Code,
+		Lines: []journal.PostingLine{
+			{Account: expense.AccountID, Debit: expense.Amount},
+			{Key: credit, Credit: expense.Amount},
+		},
+	}
+}
+
 type ExpenseSummary struct {
 	ID       uint    `json:"id"`
 	Name     string  `json:"name"`
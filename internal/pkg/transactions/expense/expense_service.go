@@ -1,12 +1,19 @@
 package expense
 
 import (
+	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/accounts/budget"
+	"abude-backend/internal/pkg/accounts/journal"
+	"abude-backend/internal/pkg/accounts/period"
+	"abude-backend/internal/pkg/transition"
 	"abude-backend/pkg/exception"
 	"abude-backend/pkg/pagination"
 	"context"
+	"fmt"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ExpenseService struct {
@@ -19,7 +26,7 @@ func NewService(db *gorm.DB) *ExpenseService {
 
 func (s *ExpenseService) FindOne(id int) (*Expense, error) {
 	var expense Expense
-	if err := s.db.Preload("Account").Preload("Outlet").Preload("Company").First(&expense, id).Error; err != nil {
+	if err := s.db.Preload("Account").Preload("Outlet").Preload("Company").Preload("Attachments").First(&expense, id).Error; err != nil {
 		return nil, exception.DB(err)
 	}
 
@@ -30,6 +37,14 @@ func (s *ExpenseService) FindAll(query ExpenseQuery) *pagination.Result[Expense]
 	result := pagination.New[Expense](query.Pagination)
 
 	db := s.db.Model(&Expense{}).Preload("Account")
+	if len(query.Status) > 0 {
+		db.Where("status IN (?)", query.Status)
+	}
+
+	if len(query.ApprovalStatus) > 0 {
+		db.Where("approval_status IN (?)", query.ApprovalStatus)
+	}
+
 	if query.Account != 0 {
 		db.Where("account_id = ?", query.Account)
 	}
@@ -63,69 +78,179 @@ func (s *ExpenseService) Create(data ExpenseDTO) (*Expense, error) {
 		expense.Date = data.Date
 	}
 
-	if err := s.db.Create(&expense).Error; err != nil {
-		return nil, exception.DB(err)
+	if data.Status != "" {
+		expense.Status = data.Status
 	}
 
-	return &expense, nil
-}
-
-func (s *ExpenseService) Update(id int, data ExpenseDTO) (*Expense, error) {
-	var expense Expense
-	if err := s.db.First(&expense, id).Error; err != nil {
+	// Going over budget is allowed, the warnings are returned with the expense
+	warnings, err := budget.Warnings(s.db, expense.CompanyID, expense.OutletID, expense.AccountID, expense.Date, expense.Amount)
+	if err != nil {
 		return nil, exception.DB(err)
 	}
 
-	expense.Amount = data.Amount
-	expense.Type = data.Type
-	expense.Status = StatusAccepted
-	expense.Date = data.Date
-	expense.Notes = data.Notes
-	expense.CompanyID = data.Company
-	expense.OutletID = data.Outlet
-	expense.AccountID = data.Account
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := period.Check(tx, expense.CompanyID, expense.Date); err != nil {
+			return err
+		}
 
-	if !data.Date.IsZero() {
-		expense.Date = data.Date
-	}
+		if err := tx.Create(&expense).Error; err != nil {
+			return err
+		}
+
+		if err := submit(tx, &expense); err != nil {
+			return err
+		}
 
-	if err := s.db.Save(&expense).Error; err != nil {
+		// Posted once its approval chain and payroll period are through
+		if !expense.Posted() {
+			return nil
+		}
+
+		return journal.Post(tx, expense.Posting())
+	}); err != nil {
 		return nil, exception.DB(err)
 	}
 
+	expense.Warnings = warnings
+
 	return &expense, nil
 }
 
-func (s *ExpenseService) Delete(id int) (*Expense, error) {
+func (s *ExpenseService) Update(id int, data ExpenseDTO) (*Expense, error) {
 	var expense Expense
-	if err := s.db.First(&expense, id).Error; err != nil {
-		return nil, exception.DB(err)
-	}
-
-	if err := s.db.Delete(&expense).Error; err != nil {
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&expense, id).Error; err != nil {
+			return exception.DB(err, "Pengeluaran")
+		}
+
+		// Edits keep the status, which only changes through SetStatus
+		if expense.Status == StatusCanceled || expense.Status == StatusApproved {
+			return exception.BadRequest(fmt.Sprintf("Pengeluaran dengan status %s tidak dapat diubah", expense.Status))
+		}
+
+		if err := transition.Editable(tx, transition.TypeExpense, expense.ID); err != nil {
+			return err
+		}
+
+		if err := period.Check(tx, expense.CompanyID, expense.Date); err != nil {
+			return err
+		}
+
+		// A changed amount or owner goes through the approval chain again
+		resubmit := expense.Amount != data.Amount || expense.CompanyID != data.Company || expense.OutletID != data.Outlet
+
+		expense.Amount = data.Amount
+		expense.Type = data.Type
+		expense.Notes = data.Notes
+		expense.CompanyID = data.Company
+		expense.OutletID = data.Outlet
+		expense.AccountID = data.Account
+
+		if !data.Date.IsZero() {
+			expense.Date = data.Date
+		}
+
+		if err := period.Check(tx, expense.CompanyID, expense.Date); err != nil {
+			return err
+		}
+
+		if err := tx.Save(&expense).Error; err != nil {
+			return err
+		}
+
+		if resubmit {
+			if err := submit(tx, &expense); err != nil {
+				return err
+			}
+		}
+
+		// The entry is posted again with the edited amount and accounts,
+		// unless the edit sent the expense back for approval
+		if err := journal.Reverse(tx, journal.SourceExpense, expense.ID, time.Now()); err != nil {
+			return err
+		}
+
+		if !expense.Posted() {
+			return nil
+		}
+
+		return journal.Post(tx, expense.Posting())
+	}); err != nil {
 		return nil, exception.DB(err)
 	}
 
 	return &expense, nil
 }
 
-func (s *ExpenseService) SetStatus(id int, status string) error {
+func (s *ExpenseService) Delete(id int) (*Expense, error) {
 	var expense Expense
 	if err := s.db.First(&expense, id).Error; err != nil {
-		return exception.DB(err)
+		return nil, exception.DB(err)
 	}
 
-	if expense.Status == status {
-		return exception.BadRequest("Status tidak berubah")
+	if expense.Status == StatusApproved {
+		return nil, exception.BadRequest("Pengeluaran yang sudah masuk serah terima tidak dapat dihapus")
 	}
 
-	expense.Status = status
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := period.Check(tx, expense.CompanyID, expense.Date); err != nil {
+			return err
+		}
 
-	if err := s.db.Save(&expense).Error; err != nil {
-		return exception.DB(err)
+		if err := tx.Delete(&expense).Error; err != nil {
+			return err
+		}
+
+		return journal.Reverse(tx, journal.SourceExpense, expense.ID, time.Now())
+	}); err != nil {
+		return nil, exception.DB(err)
 	}
 
-	return nil
+	return &expense, nil
+}
+
+func (s *ExpenseService) SetStatus(id int, status string, creds *common.Creds, reason string) error {
+	return exception.DB(s.db.Transaction(func(tx *gorm.DB) error {
+		var expense Expense
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&expense, id).Error; err != nil {
+			return exception.DB(err, "Pengeluaran")
+		}
+
+		if err := period.Check(tx, expense.CompanyID, expense.Date); err != nil {
+			return err
+		}
+
+		if status == StatusApproved && expense.ApprovalStatus != ApprovalApproved {
+			return exception.BadRequest("Pengeluaran belum disetujui")
+		}
+
+		if err := transition.Apply(tx, transition.Change{
+			Type:   transition.TypeExpense,
+			ID:     expense.ID,
+			From:   expense.Status,
+			To:     status,
+			Creds:  creds,
+			Reason: reason,
+		}); err != nil {
+			return err
+		}
+
+		if err := tx.Model(&expense).Update("status", status).Error; err != nil {
+			return err
+		}
+
+		if status == StatusCanceled {
+			return journal.Reverse(tx, journal.SourceExpense, expense.ID, time.Now())
+		}
+
+		// An accepted draft is posted now, posting again does nothing
+		expense.Status = status
+		if expense.Posted() {
+			return journal.Post(tx, expense.Posting())
+		}
+
+		return nil
+	}))
 }
 
 func (s *ExpenseService) GetSummary(query ExpenseSummaryQuery) ([]ExpenseSummary, error) {
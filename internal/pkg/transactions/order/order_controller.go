@@ -0,0 +1,236 @@
+package order
+
+import (
+	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/auth"
+	"abude-backend/internal/pkg/transition"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type OrderController struct {
+	*common.BaseController
+	order *OrderService
+}
+
+func NewController(ctrl *common.BaseController, order *OrderService) *OrderController {
+	return &OrderController{ctrl, order}
+}
+
+// @Summary Get One Purchase Order
+// @Tags Purchase Orders
+// @Accept json
+// @Produce json
+// @Param id path string true "Purchase Order ID"
+// @Success 200 {object} PurchaseOrder{}
+// @Security JWT
+// @Router /api/purchase-order/{id} [get]
+func (ctrl *OrderController) One(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	order, err := ctrl.order.FindOne(id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(order)
+}
+
+// @Summary Get All Purchase Order
+// @Tags Purchase Orders
+// @Accept json
+// @Produce json
+// @Param query query PurchaseOrderQuery false "query"
+// @Success 200 {object} common.PaginatedResponse{result=[]PurchaseOrder}
+// @Security JWT
+// @Router /api/purchase-order [get]
+func (ctrl *OrderController) All(ctx *fiber.Ctx) error {
+	var query PurchaseOrderQuery
+	if err := ctrl.Validation.Query(&query, ctx); err != nil {
+		return err
+	}
+
+	result := ctrl.order.FindAll(query)
+
+	return ctx.Status(fiber.StatusOK).JSON(result)
+}
+
+// @Summary Create Purchase Order
+// @Tags Purchase Orders
+// @Accept json
+// @Produce json
+// @Param request body PurchaseOrderDTO true "Request Body"
+// @Success 201 {object} common.GeneralResponse{result=PurchaseOrder}
+// @Security JWT
+// @Router /api/purchase-order [post]
+func (ctrl *OrderController) Create(ctx *fiber.Ctx) error {
+	var data PurchaseOrderDTO
+	if err := ctrl.Validation.Body(&data, ctx); err != nil {
+		return err
+	}
+
+	creds := auth.GetCreds(ctx.Context())
+	data.User = creds.ID
+
+	order, err := ctrl.order.Create(data)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(common.GeneralResponse{
+		Message: "Pesanan pembelian berhasil dibuat",
+		Result:  order,
+	})
+}
+
+// @Summary Update Purchase Order
+// @Tags Purchase Orders
+// @Accept json
+// @Produce json
+// @Param id path string true "Purchase Order ID"
+// @Param request body PurchaseOrderDTO true "Request Body"
+// @Success 200 {object} common.GeneralResponse{result=PurchaseOrder}
+// @Security JWT
+// @Router /api/purchase-order/{id} [put]
+func (ctrl *OrderController) Update(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	var data PurchaseOrderDTO
+	if err := ctrl.Validation.Body(&data, ctx); err != nil {
+		return err
+	}
+
+	order, err := ctrl.order.Update(id, data)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.GeneralResponse{
+		Message: "Pesanan pembelian berhasil diubah",
+		Result:  order,
+	})
+}
+
+// @Summary Delete Purchase Order
+// @Tags Purchase Orders
+// @Accept json
+// @Produce json
+// @Param id path string true "Purchase Order ID"
+// @Success 200 {object} common.GeneralResponse{result=PurchaseOrder}
+// @Security JWT
+// @Router /api/purchase-order/{id} [delete]
+func (ctrl *OrderController) Delete(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	order, err := ctrl.order.Delete(id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.GeneralResponse{
+		Message: "Pesanan pembelian berhasil dihapus",
+		Result:  order,
+	})
+}
+
+// @Summary Send Purchase Order
+// @Tags Purchase Orders
+// @Accept json
+// @Produce json
+// @Param id path string true "Purchase Order ID"
+// @Param request body transition.ReasonDTO false "Request Body"
+// @Success 200 {object} common.BasicResponse{}
+// @Security JWT
+// @Router /api/purchase-order/{id}/order [patch]
+func (ctrl *OrderController) Order(ctx *fiber.Ctx) error {
+	return ctrl.setStatus(ctx, StatusOrdered, "Pesanan pembelian berhasil dikirim")
+}
+
+// @Summary Close Purchase Order
+// @Tags Purchase Orders
+// @Accept json
+// @Produce json
+// @Param id path string true "Purchase Order ID"
+// @Param request body transition.ReasonDTO false "Request Body"
+// @Success 200 {object} common.BasicResponse{}
+// @Security JWT
+// @Router /api/purchase-order/{id}/close [patch]
+func (ctrl *OrderController) Close(ctx *fiber.Ctx) error {
+	return ctrl.setStatus(ctx, StatusClosed, "Pesanan pembelian berhasil ditutup")
+}
+
+// @Summary Cancel Purchase Order
+// @Tags Purchase Orders
+// @Accept json
+// @Produce json
+// @Param id path string true "Purchase Order ID"
+// @Param request body transition.ReasonDTO false "Request Body"
+// @Success 200 {object} common.BasicResponse{}
+// @Security JWT
+// @Router /api/purchase-order/{id}/cancel [patch]
+func (ctrl *OrderController) Cancel(ctx *fiber.Ctx) error {
+	return ctrl.setStatus(ctx, StatusCanceled, "Pesanan pembelian berhasil dibatalkan")
+}
+
+// @Summary Receive Purchase Order
+// @Tags Purchase Orders
+// @Accept json
+// @Produce json
+// @Param id path string true "Purchase Order ID"
+// @Param request body GoodsReceiptDTO true "Request Body"
+// @Success 201 {object} common.GeneralResponse{result=GoodsReceipt}
+// @Security JWT
+// @Router /api/purchase-order/{id}/receipt [post]
+func (ctrl *OrderController) Receive(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	var data GoodsReceiptDTO
+	if err := ctrl.Validation.Body(&data, ctx); err != nil {
+		return err
+	}
+
+	creds := auth.GetCreds(ctx.Context())
+	data.User = creds.ID
+
+	receipt, err := ctrl.order.Receive(id, data)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(common.GeneralResponse{
+		Message: "Penerimaan barang berhasil dicatat",
+		Result:  receipt,
+	})
+}
+
+func (ctrl *OrderController) setStatus(ctx *fiber.Ctx, status string, message string) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	reason, err := transition.Reason(ctrl.Validation, ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := ctrl.order.SetStatus(id, status, auth.GetCreds(ctx.Context()), reason); err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.BasicResponse{
+		Message: message,
+	})
+}
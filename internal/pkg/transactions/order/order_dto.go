@@ -0,0 +1,46 @@
+package order
+
+import (
+	"abude-backend/pkg/pagination"
+	"time"
+)
+
+type OrderItemDTO struct {
+	Price    float64 `json:"price" form:"price" validate:"required,min=0"`
+	Quantity float64 `json:"quantity" form:"quantity" validate:"required,gt=0"`
+	Product  uint    `json:"product" form:"product" validate:"required,exist=products"`
+}
+
+type PurchaseOrderDTO struct {
+	Note         string         `json:"note" form:"note" validate:"omitempty"`
+	Items        []OrderItemDTO `json:"items" form:"items" validate:"required,min=1,dive,required"`
+	Supplier     uint           `json:"supplier" form:"supplier" validate:"required,exist=suppliers"`
+	Outlet       uint           `json:"outlet" form:"outlet" validate:"required,exist=outlets"`
+	Type         string         `json:"type" form:"type" validate:"required,oneof=debit credit" enums:"debit,credit"`
+	Date         time.Time      `json:"date" form:"date" validate:"omitempty" format:"date-time"`
+	ExpectedDate *time.Time     `json:"expectedDate" form:"expectedDate" validate:"omitempty" format:"date-time"`
+
+	User uint `json:"-" form:"-"`
+}
+
+type ReceiptItemDTO struct {
+	Item     uint    `json:"item" form:"item" validate:"required"` // Purchase Order Item ID
+	Quantity float64 `json:"quantity" form:"quantity" validate:"required,gt=0"`
+}
+
+type GoodsReceiptDTO struct {
+	Note  string           `json:"note" form:"note" validate:"omitempty"`
+	Date  time.Time        `json:"date" form:"date" validate:"omitempty" format:"date-time"`
+	Items []ReceiptItemDTO `json:"items" form:"items" validate:"required,min=1,dive,required"`
+
+	User uint `json:"-" form:"-"`
+}
+
+type PurchaseOrderQuery struct {
+	pagination.Pagination
+	Supplier  uint      `query:"supplier"` // Supplier ID
+	Outlet    uint      `query:"outlet"`   // Outlet ID
+	Status    []string  `query:"status" enums:"draft,ordered,partial,received,closed,canceled"`
+	StartDate time.Time `query:"startDate" format:"date-time"`
+	EndDate   time.Time `query:"endDate" format:"date-time"`
+}
@@ -0,0 +1,142 @@
+package order
+
+import (
+	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/inventories/product"
+	"abude-backend/internal/pkg/outlet"
+	"abude-backend/internal/pkg/sequence"
+	"abude-backend/internal/pkg/supplier"
+	"abude-backend/internal/pkg/transactions/purchase"
+	"abude-backend/internal/pkg/user"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	StatusDraft    = "draft"
+	StatusOrdered  = "ordered"
+	StatusPartial  = "partial"
+	StatusReceived = "received"
+	StatusClosed   = "closed"
+	StatusCanceled = "canceled"
+)
+
+type OrderItem struct {
+	common.BaseModel
+	Price    float64 `json:"price"`
+	Quantity float64 `json:"quantity"`
+	Received float64 `json:"received"`
+	Total    float64 `json:"total"`
+
+	Product   *product.Product `json:"product,omitempty" gorm:"constraint:OnDelete:RESTRICT;"`
+	ProductID uint             `json:"-"`
+
+	PurchaseOrder   *PurchaseOrder `json:"-" gorm:"constraint:OnDelete:CASCADE;"`
+	PurchaseOrderID uint           `json:"-"`
+}
+
+func (OrderItem) TableName() string {
+	return "purchase_order_items"
+}
+
+// Remaining returns the quantity that has not been delivered yet.
+func (item *OrderItem) Remaining() float64 {
+	return item.Quantity - item.Received
+}
+
+type PurchaseOrder struct {
+	common.BaseModel
+	user.WithEditor
+	Code         string     `json:"code" gorm:"type:varchar(50)"`
+	Note         string     `json:"note" gorm:"type:varchar(150)"`
+	Total        float64    `json:"total"`
+	Status       string     `json:"status" gorm:"type:enum('draft','ordered','partial','received','closed','canceled')" enums:"draft,ordered,partial,received,closed,canceled"`
+	Type         string     `json:"type" gorm:"type:enum('debit','credit')" enums:"debit,credit"`
+	Date         time.Time  `json:"date"`
+	ExpectedDate *time.Time `json:"expectedDate"`
+
+	Items    []OrderItem    `json:"items" gorm:"constraint:OnDelete:CASCADE;"`
+	Receipts []GoodsReceipt `json:"receipts,omitempty" gorm:"constraint:OnDelete:RESTRICT;"`
+
+	Supplier   *supplier.Supplier `json:"supplier,omitempty" gorm:"constraint:OnDelete:RESTRICT;"`
+	SupplierID uint               `json:"-"`
+
+	Outlet   *outlet.Outlet `json:"outlet,omitempty" gorm:"constraint:OnDelete:CASCADE;"`
+	OutletID uint           `json:"-"`
+
+	User   *user.User `json:"user,omitempty" gorm:"constraint:OnDelete:SET NULL;"`
+	UserID *uint      `json:"-"`
+}
+
+func (order *PurchaseOrder) BeforeCreate(tx *gorm.DB) error {
+	if order.Code != "" {
+		return nil
+	}
+
+	code, err := sequence.NextForOutlet(tx, sequence.TypePurchaseOrder, order.OutletID, time.Now())
+	if err != nil {
+		return err
+	}
+
+	order.Code = code
+
+	return nil
+}
+
+type ReceiptItem struct {
+	common.BaseModel
+	Price    float64 `json:"price"`
+	Quantity float64 `json:"quantity"`
+	Total    float64 `json:"total"`
+
+	Product   *product.Product `json:"product,omitempty" gorm:"constraint:OnDelete:RESTRICT;"`
+	ProductID uint             `json:"-"`
+
+	OrderItem   *OrderItem `json:"-" gorm:"constraint:OnDelete:RESTRICT;"`
+	OrderItemID uint       `json:"orderItem"`
+
+	GoodsReceipt   *GoodsReceipt `json:"-" gorm:"constraint:OnDelete:CASCADE;"`
+	GoodsReceiptID uint          `json:"-"`
+}
+
+func (ReceiptItem) TableName() string {
+	return "goods_receipt_items"
+}
+
+type GoodsReceipt struct {
+	common.BaseModel
+	user.WithEditor
+	Code  string    `json:"code" gorm:"type:varchar(50)"`
+	Note  string    `json:"note" gorm:"type:varchar(150)"`
+	Total float64   `json:"total"`
+	Date  time.Time `json:"date"`
+
+	Items []ReceiptItem `json:"items" gorm:"constraint:OnDelete:CASCADE;"`
+
+	PurchaseOrder   *PurchaseOrder `json:"-" gorm:"constraint:OnDelete:RESTRICT;"`
+	PurchaseOrderID uint           `json:"-"`
+
+	Purchase   *purchase.Purchase `json:"purchase,omitempty" gorm:"constraint:OnDelete:SET NULL;"`
+	PurchaseID *uint              `json:"-"`
+}
+
+func (receipt *GoodsReceipt) BeforeCreate(tx *gorm.DB) error {
+	if receipt.Code != "" {
+		return nil
+	}
+
+	var outlet uint
+	if err := tx.Table("purchase_orders").Select("outlet_id").Where("id = ?", receipt.PurchaseOrderID).Scan(&outlet).Error; err != nil {
+		return err
+	}
+
+	code, err := sequence.NextForOutlet(tx, sequence.TypeGoodsReceipt, outlet, time.Now())
+	if err != nil {
+		return err
+	}
+
+	receipt.Code = code
+
+	return nil
+}
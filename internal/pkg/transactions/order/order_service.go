@@ -0,0 +1,360 @@
+package order
+
+import (
+	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/inventories/inventory"
+	"abude-backend/internal/pkg/transactions/purchase"
+	"abude-backend/internal/pkg/transition"
+	"abude-backend/pkg/exception"
+	"abude-backend/pkg/pagination"
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OrderService struct {
+	db *gorm.DB
+}
+
+func NewService(db *gorm.DB) *OrderService {
+	return &OrderService{db}
+}
+
+func (s *OrderService) FindOne(id int) (*PurchaseOrder, error) {
+	var order PurchaseOrder
+	if err := s.db.
+		Preload("User").Preload("Supplier").Preload("Outlet").
+		Preload("Items").Preload("Items.Product").
+		Preload("Receipts").Preload("Receipts.Items").Preload("Receipts.Items.Product").
+		First(&order, id).Error; err != nil {
+		return nil, exception.DB(err, "Pesanan pembelian")
+	}
+
+	return &order, nil
+}
+
+func (s *OrderService) FindAll(query PurchaseOrderQuery) *pagination.Result[PurchaseOrder] {
+	result := pagination.New[PurchaseOrder](query.Pagination)
+
+	db := s.db.Model(&PurchaseOrder{}).Preload("User").Preload("Supplier").Preload("Outlet")
+	if query.Supplier != 0 {
+		db.Where("supplier_id = ?", query.Supplier)
+	}
+
+	if query.Outlet != 0 {
+		db.Where("outlet_id = ?", query.Outlet)
+	}
+
+	if len(query.Status) > 0 {
+		db.Where("status IN (?)", query.Status)
+	}
+
+	if !query.StartDate.IsZero() {
+		db.Where("date >= ?", query.StartDate)
+	}
+
+	if !query.EndDate.IsZero() {
+		db.Where("date <= ?", query.EndDate)
+	}
+
+	db.Order("date DESC")
+
+	return result.Paginate(db)
+}
+
+func (s *OrderService) Create(data PurchaseOrderDTO) (*PurchaseOrder, error) {
+	order := PurchaseOrder{
+		Note:         data.Note,
+		Status:       StatusDraft,
+		Type:         data.Type,
+		Date:         time.Now(),
+		ExpectedDate: data.ExpectedDate,
+		SupplierID:   data.Supplier,
+		OutletID:     data.Outlet,
+		UserID:       &data.User,
+	}
+
+	if !data.Date.IsZero() {
+		order.Date = data.Date
+	}
+
+	order.Items = s.buildItems(data.Items, &order)
+
+	if err := s.db.Create(&order).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &order, nil
+}
+
+func (s *OrderService) Update(id int, data PurchaseOrderDTO) (*PurchaseOrder, error) {
+	var order PurchaseOrder
+	if err := s.db.First(&order, id).Error; err != nil {
+		return nil, exception.DB(err, "Pesanan pembelian")
+	}
+
+	if order.Status != StatusDraft {
+		return nil, exception.BadRequest("Hanya pesanan draft yang dapat diubah")
+	}
+
+	order.Note = data.Note
+	order.Type = data.Type
+	order.ExpectedDate = data.ExpectedDate
+	order.SupplierID = data.Supplier
+	order.OutletID = data.Outlet
+	order.Total = 0
+
+	if !data.Date.IsZero() {
+		order.Date = data.Date
+	}
+
+	items := s.buildItems(data.Items, &order)
+	for i := range items {
+		items[i].PurchaseOrderID = order.ID
+	}
+
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Items").Save(&order).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("purchase_order_id = ?", order.ID).Delete(&OrderItem{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Create(&items).Error; err != nil {
+			return err
+		}
+
+		return nil
+	}); err != nil {
+		return nil, exception.DB(err)
+	}
+
+	order.Items = items
+
+	return &order, nil
+}
+
+func (s *OrderService) Delete(id int) (*PurchaseOrder, error) {
+	var order PurchaseOrder
+	if err := s.db.First(&order, id).Error; err != nil {
+		return nil, exception.DB(err, "Pesanan pembelian")
+	}
+
+	if order.Status != StatusDraft && order.Status != StatusCanceled {
+		return nil, exception.BadRequest("Pesanan yang sudah dikirim tidak dapat dihapus")
+	}
+
+	if err := s.db.Delete(&order).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &order, nil
+}
+
+// SetStatus moves an order along draft -> ordered -> closed, or cancels it
+// while nothing has been received yet.
+func (s *OrderService) SetStatus(id int, status string, creds *common.Creds, reason string) error {
+	return exception.DB(s.db.Transaction(func(tx *gorm.DB) error {
+		var order PurchaseOrder
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&order, id).Error; err != nil {
+			return exception.DB(err, "Pesanan pembelian")
+		}
+
+		if err := transition.Apply(tx, transition.Change{
+			Type:   transition.TypeOrder,
+			ID:     order.ID,
+			From:   order.Status,
+			To:     status,
+			Creds:  creds,
+			Reason: reason,
+		}); err != nil {
+			return err
+		}
+
+		return tx.Model(&order).Update("status", status).Error
+	}))
+}
+
+// Receive records a goods receipt against the order. The delivered quantities
+// are booked as a purchase and stocked in as inventory lots right away.
+func (s *OrderService) Receive(id int, data GoodsReceiptDTO) (*GoodsReceipt, error) {
+	receipt := GoodsReceipt{
+		Note: data.Note,
+		Date: time.Now(),
+	}
+
+	if !data.Date.IsZero() {
+		receipt.Date = data.Date
+	}
+
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		var order PurchaseOrder
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&order, id).Error; err != nil {
+			return exception.DB(err, "Pesanan pembelian")
+		}
+
+		if order.Status != StatusOrdered && order.Status != StatusPartial {
+			return exception.BadRequest("Pesanan belum dikirim atau sudah selesai")
+		}
+
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Product").Where("purchase_order_id = ?", order.ID).Find(&order.Items).Error; err != nil {
+			return err
+		}
+
+		items := make(map[uint]*OrderItem)
+		for i := range order.Items {
+			items[order.Items[i].ID] = &order.Items[i]
+		}
+
+		var purchaseItems []purchase.PurchaseItemDTO
+		for _, v := range data.Items {
+			item, ok := items[v.Item]
+			if !ok {
+				return exception.NotFound("Item pesanan")
+			}
+
+			if v.Quantity > item.Remaining() {
+				return exception.BadRequest(fmt.Sprintf("Jumlah diterima melebihi sisa pesanan '%s' (%.2f)", item.Product.Name, item.Remaining()))
+			}
+
+			item.Received += v.Quantity
+
+			receiptItem := ReceiptItem{
+				Price:       item.Price,
+				Quantity:    v.Quantity,
+				Total:       item.Price * v.Quantity,
+				ProductID:   item.ProductID,
+				OrderItemID: item.ID,
+			}
+
+			receipt.Total += receiptItem.Total
+			receipt.Items = append(receipt.Items, receiptItem)
+
+			price := item.Price
+			purchaseItems = append(purchaseItems, purchase.PurchaseItemDTO{
+				Price:    &price,
+				Quantity: v.Quantity,
+				Product:  item.ProductID,
+			})
+		}
+
+		from := order.Status
+		order.Status = StatusReceived
+		for _, item := range order.Items {
+			if item.Remaining() > 0 {
+				order.Status = StatusPartial
+				break
+			}
+		}
+
+		supplierId := order.SupplierID
+		record, err := purchase.NewService(tx).Create(purchase.PurchaseDTO{
+			Note:     fmt.Sprintf("Penerimaan %s", order.Code),
+			Items:    purchaseItems,
+			Source:   "outlet",
+			SourceID: order.OutletID,
+			Supplier: &supplierId,
+			Date:     receipt.Date,
+			Type:     order.Type,
+			User:     data.User,
+		})
+		if err != nil {
+			return err
+		}
+
+		// Stock is booked here, so the recapitulation must skip these items
+		if err := tx.Table("purchase_items").Where("purchase_id = ?", record.ID).Update("status", 1).Error; err != nil {
+			return err
+		}
+
+		inventoryService := inventory.NewService(tx)
+		for _, v := range receipt.Items {
+			if err := inventoryService.StockIn(inventory.InventoryDTO{
+				Source:   "outlet",
+				SourceID: order.OutletID,
+				Date:     datatypes.Date(receipt.Date),
+				Product:  v.ProductID,
+				Price:    v.Price,
+				Quantity: v.Quantity,
+			}); err != nil {
+				return err
+			}
+		}
+
+		receipt.PurchaseOrderID = order.ID
+		receipt.PurchaseID = &record.ID
+		if err := tx.Create(&receipt).Error; err != nil {
+			return err
+		}
+
+		for _, v := range receipt.Items {
+			if err := tx.Model(&OrderItem{}).Where("id = ?", v.OrderItemID).Update("received", gorm.Expr("received + ?", v.Quantity)).Error; err != nil {
+				return err
+			}
+		}
+
+		if from == order.Status {
+			return nil
+		}
+
+		if err := transition.Apply(tx, transition.Change{
+			Type: transition.TypeOrder,
+			ID:   order.ID,
+			From: from,
+			To:   order.Status,
+		}); err != nil {
+			return err
+		}
+
+		if err := tx.Model(&PurchaseOrder{}).Where("id = ?", order.ID).Update("status", order.Status).Error; err != nil {
+			return err
+		}
+
+		return nil
+	}); err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &receipt, nil
+}
+
+func (s *OrderService) buildItems(data []OrderItemDTO, order *PurchaseOrder) []OrderItem {
+	var items []OrderItem
+	for _, v := range data {
+		item := OrderItem{
+			Price:     v.Price,
+			Quantity:  v.Quantity,
+			Total:     v.Price * v.Quantity,
+			ProductID: v.Product,
+		}
+
+		order.Total += item.Total
+		items = append(items, item)
+	}
+
+	return items
+}
+
+func (s *OrderService) Using(tx *gorm.DB) *OrderService {
+	db := s.db
+
+	defer func() {
+		s.db = db
+	}()
+
+	s.db = tx.WithContext(tx.Statement.Context)
+
+	return s
+}
+
+func (s *OrderService) WithContext(ctx context.Context) *OrderService {
+	s.db = s.db.WithContext(ctx)
+
+	return s
+}
@@ -3,6 +3,7 @@ package purchase
 import (
 	"abude-backend/internal/common"
 	"abude-backend/internal/pkg/auth"
+	"abude-backend/internal/pkg/transition"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -105,6 +106,9 @@ func (ctrl *PurchaseController) Update(ctx *fiber.Ctx) error {
 		return err
 	}
 
+	creds := auth.GetCreds(ctx.Context())
+	data.User = creds.ID
+
 	purchase, err := ctrl.purchase.Update(id, data)
 	if err != nil {
 		return err
@@ -168,6 +172,7 @@ func (ctrl *PurchaseController) GetSummary(ctx *fiber.Ctx) error {
 // @Accept json
 // @Produce json
 // @Param id path string true "Purchase ID"
+// @Param request body transition.ReasonDTO false "Request Body"
 // @Success 200 {object} common.BasicResponse{}
 // @Security JWT
 // @Router /api/purchase/{id}/cancel [patch]
@@ -177,7 +182,12 @@ func (ctrl *PurchaseController) Cancel(ctx *fiber.Ctx) error {
 		return err
 	}
 
-	if err := ctrl.purchase.SetStatus(id, "canceled"); err != nil {
+	reason, err := transition.Reason(ctrl.Validation, ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := ctrl.purchase.SetStatus(id, "canceled", auth.GetCreds(ctx.Context()), reason); err != nil {
 		return err
 	}
 
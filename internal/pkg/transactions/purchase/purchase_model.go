@@ -2,12 +2,13 @@ package purchase
 
 import (
 	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/accounts/journal"
 	"abude-backend/internal/pkg/inventories/product"
 	"abude-backend/internal/pkg/outlet"
+	"abude-backend/internal/pkg/sequence"
 	"abude-backend/internal/pkg/supplier"
 	"abude-backend/internal/pkg/user"
-	"abude-backend/pkg/utils"
-	"fmt"
+	"abude-backend/pkg/file"
 	"time"
 
 	"gorm.io/gorm"
@@ -40,6 +41,8 @@ type PurchaseItem struct {
 
 type Purchase struct {
 	common.BaseModel
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
 	Code   string    `json:"code" gorm:"type:varchar(50)"`
 	Note   string    `json:"note" gorm:"type:varchar(150)"`
 	Total  float64   `json:"total"`
@@ -47,6 +50,8 @@ type Purchase struct {
 	Type   string    `json:"type" gorm:"type:enum('debit','credit')" enums:"debit,credit"`
 	Date   time.Time `json:"date"`
 
+	EditedAt *time.Time `json:"editedAt"` // Last update, see the revision history
+
 	Items []PurchaseItem `json:"items" gorm:"constraint:OnDelete:CASCADE;"`
 
 	Supplier   *supplier.Supplier `json:"supplier,omitempty" gorm:"constraint:OnDelete:RESTRICT;"`
@@ -54,6 +59,11 @@ type Purchase struct {
 
 	User   *user.User `json:"user,omitempty" gorm:"constraint:OnDelete:SET NULL;"`
 	UserID uint       `json:"-"`
+
+	Attachments []file.File `json:"attachments,omitempty" gorm:"many2many:purchase_attachments;constraint:OnDelete:CASCADE;"`
+
+	// Outlet the purchase is numbered for, it is linked through OutletPurchase
+	OutletID uint `json:"-" gorm:"-"`
 }
 
 type PurchaseSummary struct {
@@ -73,18 +83,39 @@ type OutletPurchase struct {
 }
 
 func (purchase *Purchase) BeforeCreate(tx *gorm.DB) error {
-	now := time.Now()
-
-	var count int64
-	err := tx.Model(&Purchase{}).
-		Where("DATE(created_at) = ?", now.Format("2006-01-02")).
-		Count(&count).Error
+	if purchase.Code != "" {
+		return nil
+	}
 
+	code, err := sequence.NextForOutlet(tx, sequence.TypePurchase, purchase.OutletID, time.Now())
 	if err != nil {
 		return err
 	}
 
-	purchase.Code = fmt.Sprintf("TXP-%s%s", now.Format("20060102"), utils.NumberToDigit(int(count+1), 3))
+	purchase.Code = code
 
 	return nil
 }
+
+// posting books the purchased goods as inventory, paid in cash or owed to the
+// supplier on a credit purchase.
+func (purchase *Purchase) posting(outlet *outlet.Outlet) journal.Posting {
+	credit := journal.KeyCash
+	if purchase.Type == TypeCredit {
+		credit = journal.KeyPayable
+	}
+
+	return journal.Posting{
+		Company:     outlet.CompanyID,
+		Outlet:      &outlet.ID,
+		User:        &purchase.UserID,
+		Date:        purchase.Date,
+		Source:      journal.SourcePurchase,
+		SourceID:    purchase.ID,
+		Description: "Pembelian " + purchase.Code,
+		Lines: []journal.PostingLine{
+			{Key: journal.KeyInventory, Debit: purchase.Total},
+			{Key: credit, Credit: purchase.Total},
+		},
+	}
+}
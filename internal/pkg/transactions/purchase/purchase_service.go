@@ -1,14 +1,20 @@
 package purchase
 
 import (
+	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/accounts/journal"
+	"abude-backend/internal/pkg/accounts/period"
 	"abude-backend/internal/pkg/inventories/product"
 	"abude-backend/internal/pkg/outlet"
+	"abude-backend/internal/pkg/revision"
+	"abude-backend/internal/pkg/transition"
 	"abude-backend/pkg/exception"
 	"abude-backend/pkg/pagination"
 	"context"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type PurchaseService struct {
@@ -21,7 +27,7 @@ func NewService(db *gorm.DB) *PurchaseService {
 
 func (s *PurchaseService) FindOne(id int) (*Purchase, error) {
 	var purchase Purchase
-	if err := s.db.Preload("User").Preload("Supplier").Preload("Items").Preload("Items.Product").First(&purchase, id).Error; err != nil {
+	if err := s.db.Preload("User").Preload("Supplier").Preload("Items").Preload("Items.Product").Preload("Attachments").First(&purchase, id).Error; err != nil {
 		return nil, exception.DB(err)
 	}
 
@@ -96,17 +102,29 @@ func (s *PurchaseService) Create(data PurchaseDTO) (*Purchase, error) {
 	}
 
 	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var outlet outlet.Outlet
+		if data.Source == "outlet" {
+			if err := s.db.First(&outlet, data.SourceID).Error; err != nil {
+				return err
+			}
+
+			if err := period.Check(tx, outlet.CompanyID, purchase.Date); err != nil {
+				return err
+			}
+
+			purchase.OutletID = outlet.ID
+		}
+
 		if err := tx.Create(&purchase).Error; err != nil {
 			return err
 		}
 
 		if data.Source == "outlet" {
-			var outlet outlet.Outlet
-			if err := s.db.First(&outlet, data.SourceID).Error; err != nil {
+			if err := tx.Create(&OutletPurchase{Purchase: &purchase, Outlet: &outlet}).Error; err != nil {
 				return err
 			}
 
-			if err := tx.Create(&OutletPurchase{Purchase: &purchase, Outlet: &outlet}).Error; err != nil {
+			if err := journal.Post(tx, purchase.posting(&outlet)); err != nil {
 				return err
 			}
 		}
@@ -134,50 +152,155 @@ func (s *PurchaseService) Create(data PurchaseDTO) (*Purchase, error) {
 
 func (s *PurchaseService) Update(id int, data PurchaseDTO) (*Purchase, error) {
 	var purchase Purchase
-	if err := s.db.First(&purchase, id).Error; err != nil {
-		return nil, exception.DB(err)
-	}
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Items").Preload("Items.Product").First(&purchase, id).Error; err != nil {
+			return exception.DB(err, "Pembelian")
+		}
+
+		if err := checkPeriod(tx, purchase.ID, purchase.Date, data.Date); err != nil {
+			return err
+		}
 
-	purchase.Date = data.Date
-	purchase.Note = data.Note
+		before := purchase
 
-	if err := s.db.Save(&purchase).Error; err != nil {
+		now := time.Now()
+		purchase.Date = data.Date
+		purchase.Note = data.Note
+		purchase.EditedAt = &now
+
+		if err := tx.Omit(clause.Associations).Save(&purchase).Error; err != nil {
+			return err
+		}
+
+		// The entry follows the purchase to its new date
+		if err := journal.Redate(tx, journal.SourcePurchase, purchase.ID, purchase.Date); err != nil {
+			return err
+		}
+
+		return revision.Save(tx, revision.TypePurchase, purchase.ID, before, purchase, data.User)
+	}); err != nil {
 		return nil, exception.DB(err)
 	}
 
 	return &purchase, nil
 }
 
-func (s *PurchaseService) SetStatus(id int, status string) error {
+func (s *PurchaseService) SetStatus(id int, status string, creds *common.Creds, reason string) error {
+	return exception.DB(s.db.Transaction(func(tx *gorm.DB) error {
+		var purchase Purchase
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&purchase, id).Error; err != nil {
+			return exception.DB(err, "Pembelian")
+		}
+
+		if err := checkPeriod(tx, purchase.ID, purchase.Date); err != nil {
+			return err
+		}
+
+		if status == StatusCanceled {
+			if err := checkHeld(tx, purchase.ID); err != nil {
+				return err
+			}
+		}
+
+		if err := transition.Apply(tx, transition.Change{
+			Type:   transition.TypePurchase,
+			ID:     purchase.ID,
+			From:   purchase.Status,
+			To:     status,
+			Creds:  creds,
+			Reason: reason,
+		}); err != nil {
+			return err
+		}
+
+		if err := tx.Model(&purchase).Update("status", status).Error; err != nil {
+			return err
+		}
+
+		if status == StatusCanceled {
+			return journal.Reverse(tx, journal.SourcePurchase, purchase.ID, time.Now())
+		}
+
+		return nil
+	}))
+}
+
+func (s *PurchaseService) Delete(id int) (*Purchase, error) {
 	var purchase Purchase
-	if err := s.db.First(&purchase, id).Error; err != nil {
-		return exception.DB(err)
-	}
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&purchase, id).Error; err != nil {
+			return exception.DB(err, "Pembelian")
+		}
+
+		if purchase.Status == StatusApproved {
+			return exception.BadRequest("Pembelian yang sudah masuk serah terima tidak dapat dihapus")
+		}
+
+		if err := checkPeriod(tx, purchase.ID, purchase.Date); err != nil {
+			return err
+		}
+
+		if err := checkHeld(tx, purchase.ID); err != nil {
+			return err
+		}
 
-	if purchase.Status == status {
-		return exception.BadRequest("Status tidak berubah")
+		if err := tx.Delete(&purchase).Error; err != nil {
+			return err
+		}
+
+		return journal.Reverse(tx, journal.SourcePurchase, purchase.ID, time.Now())
+	}); err != nil {
+		return nil, exception.DB(err)
 	}
 
-	purchase.Status = status
+	return &purchase, nil
+}
 
-	if err := s.db.Save(&purchase).Error; err != nil {
-		return exception.DB(err)
+// Documents booked from a purchase that keep it from being canceled or
+// deleted, as their effects would be left behind.
+var holds = []struct {
+	query   string
+	message string
+}{
+	{
+		query:   "SELECT 1 FROM goods_receipts WHERE purchase_id = ?",
+		message: "Pembelian dari penerimaan barang tidak dapat dibatalkan atau dihapus karena stoknya sudah dicatat",
+	},
+	{
+		query:   "SELECT 1 FROM supplier_invoice_purchases INNER JOIN supplier_invoices ON supplier_invoices.id = supplier_invoice_purchases.invoice_id WHERE supplier_invoice_purchases.purchase_id = ? AND supplier_invoices.status != 'canceled'",
+		message: "Pembelian yang sudah memiliki faktur tidak dapat dibatalkan atau dihapus, batalkan fakturnya terlebih dahulu",
+	},
+}
+
+// checkHeld fails when another document was booked from the purchase.
+func checkHeld(tx *gorm.DB, id uint) error {
+	for _, hold := range holds {
+		var found int
+		if err := tx.Raw(hold.query+" LIMIT 1", id).Scan(&found).Error; err != nil {
+			return err
+		}
+
+		if found > 0 {
+			return exception.BadRequest(hold.message)
+		}
 	}
 
 	return nil
 }
 
-func (s *PurchaseService) Delete(id int) (*Purchase, error) {
-	var purchase Purchase
-	if err := s.db.First(&purchase, id).Error; err != nil {
-		return nil, exception.DB(err)
+// checkPeriod fails when any of the dates falls in a closed period of the
+// company the purchase was made for.
+func checkPeriod(tx *gorm.DB, id uint, dates ...time.Time) error {
+	var outlet OutletPurchase
+	if err := tx.Where("purchase_id = ?", id).Limit(1).Find(&outlet).Error; err != nil {
+		return err
 	}
 
-	if err := s.db.Delete(&purchase).Error; err != nil {
-		return nil, exception.DB(err)
+	if outlet.OutletID == 0 {
+		return nil
 	}
 
-	return &purchase, nil
+	return period.CheckOutlet(tx, outlet.OutletID, dates...)
 }
 
 func (s *PurchaseService) GetSummary(query PurchaseSummaryQuery) ([]PurchaseSummary, error) {
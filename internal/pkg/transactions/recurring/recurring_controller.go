@@ -0,0 +1,190 @@
+package recurring
+
+import (
+	"abude-backend/internal/common"
+	"abude-backend/pkg/exception"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type RecurringController struct {
+	*common.BaseController
+	recurring *RecurringService
+}
+
+func NewController(ctrl *common.BaseController, recurring *RecurringService) *RecurringController {
+	return &RecurringController{ctrl, recurring}
+}
+
+// @Summary Get One Recurring Expense
+// @Tags Recurring Expenses
+// @Accept json
+// @Produce json
+// @Param id path string true "Recurring Expense ID"
+// @Success 200 {object} Template{}
+// @Security JWT
+// @Router /api/expense/recurring/{id} [get]
+func (ctrl *RecurringController) One(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	template, err := ctrl.recurring.FindOne(id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(template)
+}
+
+// @Summary Get All Recurring Expense
+// @Tags Recurring Expenses
+// @Accept json
+// @Produce json
+// @Param query query TemplateQuery false "query"
+// @Success 200 {object} common.PaginatedResponse{result=[]Template}
+// @Security JWT
+// @Router /api/expense/recurring [get]
+func (ctrl *RecurringController) All(ctx *fiber.Ctx) error {
+	var query TemplateQuery
+	if err := ctrl.Validation.Query(&query, ctx); err != nil {
+		return err
+	}
+
+	result := ctrl.recurring.FindAll(query)
+
+	return ctx.Status(fiber.StatusOK).JSON(result)
+}
+
+// @Summary Create Recurring Expense
+// @Tags Recurring Expenses
+// @Accept json
+// @Produce json
+// @Param request body TemplateDTO true "Request Body"
+// @Success 201 {object} common.GeneralResponse{result=Template}
+// @Security JWT
+// @Router /api/expense/recurring [post]
+func (ctrl *RecurringController) Create(ctx *fiber.Ctx) error {
+	var data TemplateDTO
+	if err := ctrl.Validation.Body(&data, ctx); err != nil {
+		return err
+	}
+
+	template, err := ctrl.recurring.Create(data)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(common.GeneralResponse{
+		Message: "Pengeluaran berulang berhasil dibuat",
+		Result:  template,
+	})
+}
+
+// @Summary Update Recurring Expense
+// @Tags Recurring Expenses
+// @Accept json
+// @Produce json
+// @Param id path string true "Recurring Expense ID"
+// @Param request body TemplateDTO true "Request Body"
+// @Success 200 {object} common.GeneralResponse{result=Template}
+// @Security JWT
+// @Router /api/expense/recurring/{id} [put]
+func (ctrl *RecurringController) Update(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	var data TemplateDTO
+	if err := ctrl.Validation.Body(&data, ctx); err != nil {
+		return err
+	}
+
+	template, err := ctrl.recurring.Update(id, data)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.GeneralResponse{
+		Message: "Pengeluaran berulang berhasil diubah",
+		Result:  template,
+	})
+}
+
+// @Summary Delete Recurring Expense
+// @Tags Recurring Expenses
+// @Accept json
+// @Produce json
+// @Param id path string true "Recurring Expense ID"
+// @Success 200 {object} common.GeneralResponse{result=Template}
+// @Security JWT
+// @Router /api/expense/recurring/{id} [delete]
+func (ctrl *RecurringController) Delete(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	template, err := ctrl.recurring.Delete(id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.GeneralResponse{
+		Message: "Pengeluaran berulang berhasil dihapus",
+		Result:  template,
+	})
+}
+
+// @Summary Preview Recurring Expense Occurrences
+// @Tags Recurring Expenses
+// @Accept json
+// @Produce json
+// @Param id path string true "Recurring Expense ID"
+// @Param query query PreviewQuery false "query"
+// @Success 200 {object} []string
+// @Security JWT
+// @Router /api/expense/recurring/{id}/preview [get]
+func (ctrl *RecurringController) Preview(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	var query PreviewQuery
+	if err := ctrl.Validation.Query(&query, ctx); err != nil {
+		return err
+	}
+
+	dates, err := ctrl.recurring.Preview(id, query)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dates)
+}
+
+// @Summary Run Recurring Expenses
+// @Description Creates every recurring expense that is due, without waiting for the scheduler. Failures are kept on their template as lastError.
+// @Tags Recurring Expenses
+// @Accept json
+// @Produce json
+// @Success 200 {object} common.GeneralResponse{result=int}
+// @Security JWT
+// @Router /api/expense/recurring/run [post]
+func (ctrl *RecurringController) Run(ctx *fiber.Ctx) error {
+	created, err := ctrl.recurring.Run(time.Now())
+	if err != nil {
+		return exception.Http(fiber.StatusUnprocessableEntity, fmt.Sprintf("%d pengeluaran dibuat, sebagian gagal: %s", created, strings.ReplaceAll(err.Error(), "\n", "; ")))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.GeneralResponse{
+		Message: "Pengeluaran berulang berhasil dijalankan",
+		Result:  created,
+	})
+}
@@ -0,0 +1,33 @@
+package recurring
+
+import (
+	"abude-backend/pkg/pagination"
+	"time"
+)
+
+type TemplateDTO struct {
+	Name      string     `json:"name" form:"name" validate:"required"`
+	Notes     string     `json:"notes" form:"notes" validate:"omitempty"`
+	Amount    float64    `json:"amount" form:"amount" validate:"required,gt=0"`
+	Type      string     `json:"type" form:"type" validate:"required,oneof=debit credit" enums:"debit,credit"`
+	Frequency string     `json:"frequency" form:"frequency" validate:"required,oneof=weekly monthly" enums:"weekly,monthly"`
+	Interval  int        `json:"interval" form:"interval" validate:"omitempty,gte=1"`
+	Day       int        `json:"day" form:"day" validate:"gte=0,lte=31"`
+	StartDate time.Time  `json:"startDate" form:"startDate" validate:"required" format:"date"`
+	EndDate   *time.Time `json:"endDate" form:"endDate" validate:"omitempty" format:"date"`
+	Status    bool       `json:"status" form:"status" validate:"omitempty"`
+	Account   uint       `json:"account" form:"account" validate:"required,exist=accounts"`
+	Company   uint       `json:"company" form:"company" validate:"required,exist=companies"`
+	Outlet    uint       `json:"outlet" form:"outlet" validate:"required,exist=outlets"`
+}
+
+type TemplateQuery struct {
+	pagination.Pagination
+	Company uint `query:"company"`
+	Outlet  uint `query:"outlet"`
+	Account uint `query:"account"`
+}
+
+type PreviewQuery struct {
+	Count int `query:"count" validate:"omitempty,gte=1,lte=100"` // Defaults to 12
+}
@@ -0,0 +1,127 @@
+package recurring
+
+import (
+	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/accounts/account"
+	"abude-backend/internal/pkg/company"
+	"abude-backend/internal/pkg/outlet"
+	"abude-backend/internal/pkg/transactions/expense"
+	"time"
+)
+
+const (
+	FrequencyWeekly  = "weekly"
+	FrequencyMonthly = "monthly"
+)
+
+// Template describes an expense that is entered on a fixed schedule. For a
+// weekly schedule Day is the weekday (0 = Sunday), for a monthly schedule it
+// is the day of the month, moved to the last day in shorter months.
+type Template struct {
+	common.BaseModel
+	Name      string     `json:"name" gorm:"type:varchar(100)"`
+	Notes     string     `json:"notes"`
+	Amount    float64    `json:"amount"`
+	Type      string     `json:"type" gorm:"type:enum('debit','credit')" enums:"debit,credit"`
+	Frequency string     `json:"frequency" gorm:"type:enum('weekly','monthly')" enums:"weekly,monthly"`
+	Interval  int        `json:"interval"` // Every N weeks or months
+	Day       int        `json:"day"`
+	StartDate time.Time  `json:"startDate" gorm:"type:date"`
+	EndDate   *time.Time `json:"endDate" gorm:"type:date"`
+	Status    bool       `json:"status"`
+	LastError string     `json:"lastError" gorm:"type:varchar(255)"` // Why the last run failed, empty once it succeeds again
+	FailedAt  *time.Time `json:"failedAt"`
+
+	Account   *account.Account `json:"account,omitempty" gorm:"constraint:OnDelete:RESTRICT;"`
+	AccountID uint             `json:"-"`
+
+	Company   *company.Company `json:"company,omitempty" gorm:"constraint:OnDelete:CASCADE;"`
+	CompanyID uint             `json:"-"`
+
+	Outlet   *outlet.Outlet `json:"outlet,omitempty" gorm:"constraint:OnDelete:CASCADE;"`
+	OutletID uint           `json:"-"`
+}
+
+func (Template) TableName() string {
+	return "recurring_expenses"
+}
+
+// Occurrence records that a template was materialised for a date. The
+// unique index keeps the runner from creating the same expense twice. An
+// occurrence whose expense was refused, such as in a closed period, keeps the
+// reason and has no expense.
+type Occurrence struct {
+	common.BaseModel
+	Date  time.Time `json:"date" gorm:"type:date;uniqueIndex:idx_recurring_occurrence"`
+	Error string    `json:"error" gorm:"type:varchar(255)"`
+
+	Template   *Template `json:"-" gorm:"constraint:OnDelete:CASCADE;"`
+	TemplateID uint      `json:"-" gorm:"uniqueIndex:idx_recurring_occurrence"`
+
+	Expense   *expense.Expense `json:"expense,omitempty" gorm:"constraint:OnDelete:SET NULL;"`
+	ExpenseID *uint            `json:"-"`
+}
+
+func (Occurrence) TableName() string {
+	return "recurring_expense_occurrences"
+}
+
+// Dates returns the scheduled dates of the template from one date up to and
+// including another, limited to max dates.
+func (t *Template) Dates(from time.Time, to time.Time, max int) []time.Time {
+	from = truncate(from)
+	to = truncate(to)
+
+	if start := truncate(t.StartDate); from.Before(start) {
+		from = start
+	}
+
+	if t.EndDate != nil && truncate(*t.EndDate).Before(to) {
+		to = truncate(*t.EndDate)
+	}
+
+	interval := t.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	var dates []time.Time
+	switch t.Frequency {
+	case FrequencyWeekly:
+		start := truncate(t.StartDate)
+		date := start.AddDate(0, 0, (t.Day-int(start.Weekday())+7)%7)
+		for ; !date.After(to) && len(dates) < max; date = date.AddDate(0, 0, 7*interval) {
+			if !date.Before(from) {
+				dates = append(dates, date)
+			}
+		}
+	case FrequencyMonthly:
+		start := truncate(t.StartDate)
+		for month := 0; len(dates) < max; month += interval {
+			date := dayOfMonth(start.Year(), start.Month()+time.Month(month), t.Day, start.Location())
+			if date.After(to) {
+				break
+			}
+
+			if !date.Before(from) && !date.Before(start) {
+				dates = append(dates, date)
+			}
+		}
+	}
+
+	return dates
+}
+
+func truncate(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+}
+
+// dayOfMonth returns the day in the given month, clamped to its last day.
+func dayOfMonth(year int, month time.Month, day int, loc *time.Location) time.Time {
+	last := time.Date(year, month+1, 0, 0, 0, 0, 0, loc).Day()
+	if day > last {
+		day = last
+	}
+
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
@@ -0,0 +1,36 @@
+package recurring
+
+import (
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// StartRunner materialises due recurring expenses right away and then on
+// every tick of the interval, in the background.
+func StartRunner(db *gorm.DB, interval time.Duration) {
+	service := NewService(db)
+
+	run := func() {
+		created, err := service.Run(time.Now())
+		if err != nil {
+			log.Printf("recurring expense runner: %v", err)
+		}
+
+		if created > 0 {
+			log.Printf("recurring expense runner: %d expense(s) created", created)
+		}
+	}
+
+	go func() {
+		run()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			run()
+		}
+	}()
+}
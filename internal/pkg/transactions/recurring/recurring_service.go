@@ -0,0 +1,365 @@
+package recurring
+
+import (
+	"abude-backend/internal/pkg/transactions/expense"
+	"abude-backend/pkg/exception"
+	"abude-backend/pkg/pagination"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// catchUp is the most missed dates of a template created in one run. Older
+// missed dates are recorded as skipped rather than flooding the books.
+const catchUp = 12
+
+type RecurringService struct {
+	db *gorm.DB
+}
+
+func NewService(db *gorm.DB) *RecurringService {
+	return &RecurringService{db}
+}
+
+func (s *RecurringService) FindOne(id int) (*Template, error) {
+	var template Template
+	if err := s.db.Preload("Account").Preload("Company").Preload("Outlet").First(&template, id).Error; err != nil {
+		return nil, exception.DB(err, "Pengeluaran berulang")
+	}
+
+	return &template, nil
+}
+
+func (s *RecurringService) FindAll(query TemplateQuery) *pagination.Result[Template] {
+	result := pagination.New[Template](query.Pagination)
+
+	db := s.db.Model(&Template{}).Preload("Account").Preload("Outlet")
+	if query.Company != 0 {
+		db.Where("company_id = ?", query.Company)
+	}
+
+	if query.Outlet != 0 {
+		db.Where("outlet_id = ?", query.Outlet)
+	}
+
+	if query.Account != 0 {
+		db.Where("account_id = ?", query.Account)
+	}
+
+	db.Order("created_at DESC")
+
+	return result.Paginate(db)
+}
+
+func (s *RecurringService) Create(data TemplateDTO) (*Template, error) {
+	var template Template
+	if err := s.fill(&template, data); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Create(&template).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &template, nil
+}
+
+func (s *RecurringService) Update(id int, data TemplateDTO) (*Template, error) {
+	var template Template
+	if err := s.db.First(&template, id).Error; err != nil {
+		return nil, exception.DB(err, "Pengeluaran berulang")
+	}
+
+	if err := s.fill(&template, data); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Save(&template).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &template, nil
+}
+
+func (s *RecurringService) Delete(id int) (*Template, error) {
+	var template Template
+	if err := s.db.First(&template, id).Error; err != nil {
+		return nil, exception.DB(err, "Pengeluaran berulang")
+	}
+
+	if err := s.db.Delete(&template).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &template, nil
+}
+
+// Preview lists the next dates the template will create an expense on.
+func (s *RecurringService) Preview(id int, query PreviewQuery) ([]time.Time, error) {
+	template, err := s.FindOne(id)
+	if err != nil {
+		return nil, err
+	}
+
+	count := query.Count
+	if count == 0 {
+		count = 12
+	}
+
+	from := time.Now()
+	if last, err := s.lastDate(template.ID); err != nil {
+		return nil, err
+	} else if last != nil && !last.Before(truncate(from)) {
+		from = last.AddDate(0, 0, 1)
+	}
+
+	dates := template.Dates(from, from.AddDate(10, 0, 0), count)
+	if dates == nil {
+		dates = []time.Time{}
+	}
+
+	return dates, nil
+}
+
+// Run creates the expenses of every active template that are due up to the
+// given date, catching up on at most the last catchUp dates missed while the
+// runner was down. Dates that already have an occurrence are skipped, so
+// running it again is safe.
+//
+// A date whose expense is refused is recorded as a failed occurrence and
+// skipped, so the template moves on. Other errors stop the template until the
+// next run. Either way the failure is kept on the template and returned once
+// every template was tried.
+func (s *RecurringService) Run(now time.Time) (int, error) {
+	var templates []Template
+	if err := s.db.Where("status = ? AND start_date <= ?", true, now).Find(&templates).Error; err != nil {
+		return 0, err
+	}
+
+	created := 0
+	var errs []error
+	for _, template := range templates {
+		from := template.StartDate
+		last, err := s.lastDate(template.ID)
+		if err != nil {
+			return created, err
+		}
+
+		if last != nil {
+			from = last.AddDate(0, 0, 1)
+		}
+
+		dates := template.Dates(from, now, math.MaxInt)
+		if len(dates) > catchUp {
+			if err := s.expire(&template, dates[:len(dates)-catchUp]); err != nil {
+				return created, err
+			}
+
+			dates = dates[len(dates)-catchUp:]
+		}
+
+		var failure error
+		for _, date := range dates {
+			ok, err := s.materialise(&template, date)
+			if err != nil {
+				failure = fmt.Errorf("pengeluaran berulang '%s' tanggal %s: %w", template.Name, date.Format("2006-01-02"), err)
+				errs = append(errs, failure)
+
+				if !refused(err) {
+					break
+				}
+
+				if err := s.skip(&template, date, err); err != nil {
+					return created, err
+				}
+
+				continue
+			}
+
+			if ok {
+				created++
+			}
+		}
+
+		if err := s.report(&template, failure); err != nil {
+			return created, err
+		}
+	}
+
+	return created, errors.Join(errs...)
+}
+
+// refused reports whether the expense was rejected for a reason that running
+// again will not change, rather than a database failure.
+func refused(err error) bool {
+	var httpErr exception.HttpError
+
+	return errors.As(err, &httpErr) && httpErr.Code < 500
+}
+
+// skip records a refused date as a failed occurrence so later runs move past
+// it.
+func (s *RecurringService) skip(template *Template, date time.Time, reason error) error {
+	return s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&Occurrence{
+		Date:       date,
+		Error:      clip(reason.Error()),
+		TemplateID: template.ID,
+	}).Error
+}
+
+// expire records dates missed too long ago as skipped occurrences without an
+// expense.
+func (s *RecurringService) expire(template *Template, dates []time.Time) error {
+	occurrences := make([]Occurrence, len(dates))
+	for i, date := range dates {
+		occurrences[i] = Occurrence{
+			Date:       date,
+			Error:      fmt.Sprintf("Terlewat lebih dari %d jadwal, tidak dibuat otomatis", catchUp),
+			TemplateID: template.ID,
+		}
+	}
+
+	return s.db.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(&occurrences, 100).Error
+}
+
+// report keeps the failure of a run on the template, or clears the previous
+// one after a clean run.
+func (s *RecurringService) report(template *Template, failure error) error {
+	if failure == nil && template.FailedAt == nil {
+		return nil
+	}
+
+	values := map[string]interface{}{"last_error": "", "failed_at": nil}
+	if failure != nil {
+		values["last_error"] = clip(failure.Error())
+		values["failed_at"] = time.Now()
+	}
+
+	return s.db.Model(&Template{}).Where("id = ?", template.ID).Updates(values).Error
+}
+
+// clip cuts a message to the length of its column.
+func clip(text string) string {
+	if runes := []rune(text); len(runes) > 255 {
+		return string(runes[:255])
+	}
+
+	return text
+}
+
+// materialise creates the expense of one occurrence. It reports false when
+// the occurrence already existed.
+func (s *RecurringService) materialise(template *Template, date time.Time) (bool, error) {
+	created := false
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		occurrence := Occurrence{
+			Date:       date,
+			TemplateID: template.ID,
+		}
+
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&occurrence)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		record, err := expense.NewService(tx).Create(expense.ExpenseDTO{
+			Amount:  template.Amount,
+			Type:    template.Type,
+			Date:    date,
+			Notes:   template.Name,
+			Account: template.AccountID,
+			Company: template.CompanyID,
+			Outlet:  template.OutletID,
+		})
+		if err != nil {
+			return err
+		}
+
+		created = true
+
+		return tx.Model(&occurrence).Update("expense_id", record.ID).Error
+	})
+
+	return created, err
+}
+
+func (s *RecurringService) lastDate(template uint) (*time.Time, error) {
+	var occurrence Occurrence
+	if err := s.db.Where("template_id = ?", template).Order("date DESC").First(&occurrence).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, exception.DB(err)
+	}
+
+	return &occurrence.Date, nil
+}
+
+func (s *RecurringService) fill(template *Template, data TemplateDTO) error {
+	if data.Frequency == FrequencyWeekly && data.Day > 6 {
+		return exception.Validation(map[string]string{
+			"day": "Hari harus antara 0 (Minggu) dan 6 (Sabtu)",
+		})
+	}
+
+	if data.Frequency == FrequencyMonthly && data.Day < 1 {
+		return exception.Validation(map[string]string{
+			"day": "Tanggal harus antara 1 dan 31",
+		})
+	}
+
+	if data.EndDate != nil && data.EndDate.Before(data.StartDate) {
+		return exception.Validation(map[string]string{
+			"endDate": "Tanggal selesai tidak boleh sebelum tanggal mulai",
+		})
+	}
+
+	template.Name = data.Name
+	template.Notes = data.Notes
+	template.Amount = data.Amount
+	template.Type = data.Type
+	template.Frequency = data.Frequency
+	template.Interval = data.Interval
+	template.Day = data.Day
+	template.StartDate = data.StartDate
+	template.EndDate = data.EndDate
+	template.Status = data.Status
+	template.AccountID = data.Account
+	template.CompanyID = data.Company
+	template.OutletID = data.Outlet
+
+	if template.Interval == 0 {
+		template.Interval = 1
+	}
+
+	return nil
+}
+
+func (s *RecurringService) Using(tx *gorm.DB) *RecurringService {
+	db := s.db
+
+	defer func() {
+		s.db = db
+	}()
+
+	s.db = tx.WithContext(tx.Statement.Context)
+
+	return s
+}
+
+func (s *RecurringService) WithContext(ctx context.Context) *RecurringService {
+	s.db = s.db.WithContext(ctx)
+
+	return s
+}
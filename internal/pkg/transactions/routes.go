@@ -2,23 +2,39 @@ package transactions
 
 import (
 	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/idempotency"
 	"abude-backend/internal/pkg/transactions/expense"
+	"abude-backend/internal/pkg/transactions/order"
 	"abude-backend/internal/pkg/transactions/purchase"
+	"abude-backend/internal/pkg/transactions/recurring"
 	"abude-backend/internal/pkg/transactions/sale"
 	"abude-backend/internal/pkg/transactions/wage"
 )
 
 func LoadRoutes(r *common.Router) {
 	saleService := sale.NewService(r.DB)
+	returnService := sale.NewReturnService(r.DB)
 	purchaseService := purchase.NewService(r.DB)
+	orderService := order.NewService(r.DB)
 	expenseService := expense.NewService(r.DB)
+	approvalService := expense.NewApprovalService(r.DB)
+	recurringService := recurring.NewService(r.DB)
 	wageService := wage.NewService(r.DB)
+	idempotent := idempotency.NewMiddleware(r.DB)
 
 	saleHandler := sale.NewController(r.Controller, saleService)
 	r.Router.Get("/sale", r.Auth(1), saleHandler.All)
 	r.Router.Get("/sale/summary", r.Auth(1), saleHandler.GetSummary)
+
+	returnHandler := sale.NewReturnController(r.Controller, returnService)
+	r.Router.Get("/sale/return", r.Auth(1), returnHandler.All)
+	r.Router.Get("/sale/return/:id", r.Auth(1), returnHandler.One)
+	r.Router.Patch("/sale/return/:id/cancel", r.Auth(1), returnHandler.Cancel)
+	r.Router.Post("/sale/:id/return", r.Auth(1), idempotent, returnHandler.Create)
+
 	r.Router.Get("/sale/:id", r.Auth(1), saleHandler.One)
-	r.Router.Post("/sale", r.Auth(1), saleHandler.Create)
+	r.Router.Get("/sale/:id/receipt", r.Auth(1), saleHandler.Receipt)
+	r.Router.Post("/sale", r.Auth(1), idempotent, saleHandler.Create)
 	r.Router.Put("/sale/:id", r.Auth(2), saleHandler.Update)
 	r.Router.Delete("/sale/:id", r.Auth(2), saleHandler.Delete)
 	r.Router.Patch("/sale/:id/cancel", r.Auth(1), saleHandler.Cancel)
@@ -27,16 +43,47 @@ func LoadRoutes(r *common.Router) {
 	r.Router.Get("/purchase", r.Auth(1), purchaseHandler.All)
 	r.Router.Get("/purchase/summary", r.Auth(1), purchaseHandler.GetSummary)
 	r.Router.Get("/purchase/:id", r.Auth(1), purchaseHandler.One)
-	r.Router.Post("/purchase", r.Auth(1), purchaseHandler.Create)
+	r.Router.Post("/purchase", r.Auth(1), idempotent, purchaseHandler.Create)
 	r.Router.Put("/purchase/:id", r.Auth(2), purchaseHandler.Update)
 	r.Router.Delete("/purchase/:id", r.Auth(2), purchaseHandler.Delete)
 	r.Router.Patch("/purchase/:id/cancel", r.Auth(1), purchaseHandler.Cancel)
 
+	orderHandler := order.NewController(r.Controller, orderService)
+	r.Router.Get("/purchase-order", r.Auth(1), orderHandler.All)
+	r.Router.Get("/purchase-order/:id", r.Auth(1), orderHandler.One)
+	r.Router.Post("/purchase-order", r.Auth(1), idempotent, orderHandler.Create)
+	r.Router.Put("/purchase-order/:id", r.Auth(1), orderHandler.Update)
+	r.Router.Delete("/purchase-order/:id", r.Auth(2), orderHandler.Delete)
+	r.Router.Patch("/purchase-order/:id/order", r.Auth(1), orderHandler.Order)
+	r.Router.Patch("/purchase-order/:id/close", r.Auth(1), orderHandler.Close)
+	r.Router.Patch("/purchase-order/:id/cancel", r.Auth(1), orderHandler.Cancel)
+	r.Router.Post("/purchase-order/:id/receipt", r.Auth(1), idempotent, orderHandler.Receive)
+
 	expenseHandler := expense.NewController(r.Controller, expenseService)
 	r.Router.Get("/expense", r.Auth(1), expenseHandler.All)
 	r.Router.Get("/expense/summary", r.Auth(1), expenseHandler.GetSummary)
+
+	approvalHandler := expense.NewApprovalController(r.Controller, approvalService)
+	r.Router.Get("/expense/approval", r.Auth(2), approvalHandler.Inbox)
+	r.Router.Get("/expense/approval/rule", r.Auth(2), approvalHandler.Rules)
+	r.Router.Post("/expense/approval/rule", r.Auth(3), approvalHandler.CreateRule)
+	r.Router.Put("/expense/approval/rule/:id", r.Auth(3), approvalHandler.UpdateRule)
+	r.Router.Delete("/expense/approval/rule/:id", r.Auth(3), approvalHandler.DeleteRule)
+	r.Router.Patch("/expense/approval/:id/approve", r.Auth(2), approvalHandler.Approve)
+	r.Router.Patch("/expense/approval/:id/reject", r.Auth(2), approvalHandler.Reject)
+
+	recurringHandler := recurring.NewController(r.Controller, recurringService)
+	r.Router.Get("/expense/recurring", r.Auth(1), recurringHandler.All)
+	r.Router.Post("/expense/recurring/run", r.Auth(2), recurringHandler.Run)
+	r.Router.Get("/expense/recurring/:id", r.Auth(1), recurringHandler.One)
+	r.Router.Get("/expense/recurring/:id/preview", r.Auth(1), recurringHandler.Preview)
+	r.Router.Post("/expense/recurring", r.Auth(2), recurringHandler.Create)
+	r.Router.Put("/expense/recurring/:id", r.Auth(2), recurringHandler.Update)
+	r.Router.Delete("/expense/recurring/:id", r.Auth(2), recurringHandler.Delete)
+
 	r.Router.Get("/expense/:id", r.Auth(1), expenseHandler.One)
-	r.Router.Post("/expense", r.Auth(1), expenseHandler.Create)
+	r.Router.Get("/expense/:id/approval", r.Auth(1), approvalHandler.All)
+	r.Router.Post("/expense", r.Auth(1), idempotent, expenseHandler.Create)
 	r.Router.Put("/expense/:id", r.Auth(2), expenseHandler.Update)
 	r.Router.Delete("/expense/:id", r.Auth(2), expenseHandler.Delete)
 	r.Router.Patch("/expense/:id/cancel", r.Auth(1), expenseHandler.Cancel)
@@ -45,7 +92,7 @@ func LoadRoutes(r *common.Router) {
 	r.Router.Get("/wage", r.Auth(1), wageHandler.All)
 	r.Router.Get("/wage/summary", r.Auth(1), wageHandler.GetSummary)
 	r.Router.Get("/wage/:id", r.Auth(1), wageHandler.One)
-	r.Router.Post("/wage", r.Auth(1), wageHandler.Create)
+	r.Router.Post("/wage", r.Auth(1), idempotent, wageHandler.Create)
 	r.Router.Put("/wage/:id", r.Auth(2), wageHandler.Update)
 	r.Router.Delete("/wage/:id", r.Auth(2), wageHandler.Delete)
 	r.Router.Patch("/wage/:id/cancel", r.Auth(1), wageHandler.Cancel)
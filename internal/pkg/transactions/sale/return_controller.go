@@ -0,0 +1,123 @@
+package sale
+
+import (
+	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/auth"
+	"abude-backend/internal/pkg/transition"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ReturnController struct {
+	*common.BaseController
+	ret *ReturnService
+}
+
+func NewReturnController(ctrl *common.BaseController, ret *ReturnService) *ReturnController {
+	return &ReturnController{ctrl, ret}
+}
+
+// @Summary Get One Sale Return
+// @Tags Sales
+// @Accept json
+// @Produce json
+// @Param id path string true "Sale Return ID"
+// @Success 200 {object} SaleReturn{}
+// @Security JWT
+// @Router /api/sale/return/{id} [get]
+func (ctrl *ReturnController) One(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	ret, err := ctrl.ret.FindOne(id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(ret)
+}
+
+// @Summary Get All Sale Return
+// @Tags Sales
+// @Accept json
+// @Produce json
+// @Param query query SaleReturnQuery false "query"
+// @Success 200 {object} common.PaginatedResponse{result=[]SaleReturn}
+// @Security JWT
+// @Router /api/sale/return [get]
+func (ctrl *ReturnController) All(ctx *fiber.Ctx) error {
+	var query SaleReturnQuery
+	if err := ctrl.Validation.Query(&query, ctx); err != nil {
+		return err
+	}
+
+	result := ctrl.ret.FindAll(query)
+
+	return ctx.Status(fiber.StatusOK).JSON(result)
+}
+
+// @Summary Create Sale Return
+// @Tags Sales
+// @Accept json
+// @Produce json
+// @Param id path string true "Sale ID"
+// @Param request body SaleReturnDTO true "Request Body"
+// @Success 201 {object} common.GeneralResponse{result=SaleReturn}
+// @Security JWT
+// @Router /api/sale/{id}/return [post]
+func (ctrl *ReturnController) Create(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	var data SaleReturnDTO
+	if err := ctrl.Validation.Body(&data, ctx); err != nil {
+		return err
+	}
+
+	creds := auth.GetCreds(ctx.Context())
+	data.User = creds.ID
+	data.Sale = uint(id)
+
+	ret, err := ctrl.ret.Create(data)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(common.GeneralResponse{
+		Message: "Retur penjualan berhasil dibuat",
+		Result:  ret,
+	})
+}
+
+// @Summary Cancel Sale Return
+// @Tags Sales
+// @Accept json
+// @Produce json
+// @Param id path string true "Sale Return ID"
+// @Param request body transition.ReasonDTO false "Request Body"
+// @Success 200 {object} common.BasicResponse{}
+// @Security JWT
+// @Router /api/sale/return/{id}/cancel [patch]
+func (ctrl *ReturnController) Cancel(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	reason, err := transition.Reason(ctrl.Validation, ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := ctrl.ret.SetStatus(id, StatusCanceled, auth.GetCreds(ctx.Context()), reason); err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.BasicResponse{
+		Message: "Retur penjualan berhasil dibatalkan",
+	})
+}
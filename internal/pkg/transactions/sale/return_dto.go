@@ -0,0 +1,32 @@
+package sale
+
+import (
+	"abude-backend/pkg/pagination"
+	"time"
+)
+
+type SaleReturnItemDTO struct {
+	Item     uint    `json:"item" form:"item" validate:"required"` // Sale Item ID
+	Quantity float64 `json:"quantity" form:"quantity" validate:"required,gt=0"`
+	Restock  bool    `json:"restock" form:"restock" validate:"omitempty"`
+}
+
+type SaleReturnDTO struct {
+	Reason string              `json:"reason" form:"reason" validate:"required"`
+	Method string              `json:"method" form:"method" validate:"required,oneof=cash transfer qris" enums:"cash,transfer,qris"`
+	Date   time.Time           `json:"date" form:"date" validate:"omitempty" format:"date-time"`
+	Items  []SaleReturnItemDTO `json:"items" form:"items" validate:"required,min=1,dive,required"`
+
+	Sale uint `json:"-" form:"-"`
+	User uint `json:"-" form:"-"`
+}
+
+type SaleReturnQuery struct {
+	pagination.Pagination
+	Sale      uint      `query:"sale"`   // Sale ID
+	Outlet    uint      `query:"outlet"` // Outlet ID
+	Method    string    `query:"method" enums:"cash,transfer,qris"`
+	Status    []string  `query:"status" enums:"accepted,approved,canceled"`
+	StartDate time.Time `query:"startDate" format:"date-time"`
+	EndDate   time.Time `query:"endDate" format:"date-time"`
+}
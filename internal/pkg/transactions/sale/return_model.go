@@ -0,0 +1,94 @@
+package sale
+
+import (
+	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/accounts/journal"
+	"abude-backend/internal/pkg/inventories/product"
+	"abude-backend/internal/pkg/sequence"
+	"abude-backend/internal/pkg/user"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	RefundCash     = "cash"
+	RefundTransfer = "transfer"
+	RefundQris     = "qris"
+)
+
+type SaleReturnItem struct {
+	common.BaseModel
+	Price    float64 `json:"price"`
+	Quantity float64 `json:"quantity"`
+	Total    float64 `json:"total"`
+	Restock  bool    `json:"restock"`
+	Status   bool    `json:"status"`
+
+	Product   *product.Product `json:"product,omitempty" gorm:"constraint:OnDelete:RESTRICT;"`
+	ProductID uint             `json:"-"`
+
+	SaleItem   *SaleItem `json:"-" gorm:"constraint:OnDelete:CASCADE;"`
+	SaleItemID uint      `json:"saleItem"`
+
+	SaleReturn   *SaleReturn `json:"-" gorm:"constraint:OnDelete:CASCADE;"`
+	SaleReturnID uint        `json:"-"`
+}
+
+type SaleReturn struct {
+	common.BaseModel
+	Code     string    `json:"code" gorm:"type:varchar(50)"`
+	Reason   string    `json:"reason" gorm:"type:varchar(150)"`
+	Method   string    `json:"method" gorm:"type:enum('cash','transfer','qris')" enums:"cash,transfer,qris"`
+	Total    float64   `json:"total"`
+	Credited float64   `json:"credited"` // Part of the total taken off the receivable of a credit sale, the rest is refunded
+	Status   string    `json:"status" gorm:"type:enum('accepted','approved','canceled')" enums:"approved,accepted,canceled"`
+	Date     time.Time `json:"date"`
+
+	Items []SaleReturnItem `json:"items" gorm:"constraint:OnDelete:CASCADE;"`
+
+	Sale   *Sale `json:"sale,omitempty" gorm:"constraint:OnDelete:CASCADE;"`
+	SaleID uint  `json:"-"`
+
+	User   *user.User `json:"user,omitempty"`
+	UserID uint       `json:"-"`
+}
+
+// posting takes the returned goods off the revenue against the refund paid
+// out and the receivable credited.
+func (ret *SaleReturn) posting(company uint, outlet uint) journal.Posting {
+	return journal.Posting{
+		Company:     company,
+		Outlet:      &outlet,
+		User:        &ret.UserID,
+		Date:        ret.Date,
+		Source:      journal.SourceReturn,
+		SourceID:    ret.ID,
+		Description: "Retur penjualan " + ret.Code,
+		Lines: []journal.PostingLine{
+			{Key: journal.KeyRevenue, Debit: ret.Total},
+			{Key: journal.KeyCash, Credit: ret.Total - ret.Credited},
+			{Key: journal.KeyReceivable, Credit: ret.Credited},
+		},
+	}
+}
+
+func (ret *SaleReturn) BeforeCreate(tx *gorm.DB) error {
+	if ret.Code != "" {
+		return nil
+	}
+
+	var outlet uint
+	if err := tx.Table("outlet_sales").Select("outlet_id").Where("sale_id = ?", ret.SaleID).Scan(&outlet).Error; err != nil {
+		return err
+	}
+
+	code, err := sequence.NextForOutlet(tx, sequence.TypeSaleReturn, outlet, time.Now())
+	if err != nil {
+		return err
+	}
+
+	ret.Code = code
+
+	return nil
+}
@@ -0,0 +1,291 @@
+package sale
+
+import (
+	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/accounts/journal"
+	"abude-backend/internal/pkg/inventories/product"
+	"abude-backend/internal/pkg/loyalty"
+	"abude-backend/internal/pkg/outlet"
+	"abude-backend/internal/pkg/transition"
+	"abude-backend/pkg/exception"
+	"abude-backend/pkg/pagination"
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReturnService struct {
+	db *gorm.DB
+}
+
+func NewReturnService(db *gorm.DB) *ReturnService {
+	return &ReturnService{db}
+}
+
+func (s *ReturnService) FindOne(id int) (*SaleReturn, error) {
+	var ret SaleReturn
+	if err := s.db.Preload("User").Preload("Sale").Preload("Items").Preload("Items.Product").First(&ret, id).Error; err != nil {
+		return nil, exception.DB(err, "Retur")
+	}
+
+	return &ret, nil
+}
+
+func (s *ReturnService) FindAll(query SaleReturnQuery) *pagination.Result[SaleReturn] {
+	result := pagination.New[SaleReturn](query.Pagination)
+
+	db := s.db.Model(&SaleReturn{}).Preload("User").Preload("Sale")
+	if query.Sale != 0 {
+		db.Where("sale_id = ?", query.Sale)
+	}
+
+	if query.Outlet != 0 {
+		db.Where("sale_id IN (?)", s.db.
+			Table("outlet_sales").
+			Select("sale_id").
+			Where("outlet_id = ?", query.Outlet))
+	}
+
+	if query.Method != "" {
+		db.Where("method = ?", query.Method)
+	}
+
+	if len(query.Status) > 0 {
+		db.Where("status IN (?)", query.Status)
+	}
+
+	if !query.StartDate.IsZero() {
+		db.Where("date >= ?", query.StartDate)
+	}
+
+	if !query.EndDate.IsZero() {
+		db.Where("date <= ?", query.EndDate)
+	}
+
+	db.Order("date DESC")
+
+	return result.Paginate(db)
+}
+
+// Create records a return of sold items. The refund is taken off the
+// receivable of a credit sale first and the rest is paid out.
+func (s *ReturnService) Create(data SaleReturnDTO) (*SaleReturn, error) {
+	ret := SaleReturn{
+		Reason: data.Reason,
+		Method: data.Method,
+		Status: StatusAccepted,
+		Date:   time.Now(),
+		UserID: data.User,
+	}
+
+	if !data.Date.IsZero() {
+		ret.Date = data.Date
+	}
+
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		var sale Sale
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&sale, data.Sale).Error; err != nil {
+			return exception.DB(err, "Penjualan")
+		}
+
+		if sale.Status == StatusCanceled {
+			return exception.BadRequest("Penjualan telah dibatalkan")
+		}
+
+		var link OutletSale
+		if err := tx.Where("sale_id = ?", sale.ID).Limit(1).Find(&link).Error; err != nil {
+			return err
+		}
+
+		if err := checkPeriod(tx, sale.ID, ret.Date); err != nil {
+			return err
+		}
+
+		var saleItems []SaleItem
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("sale_id = ?", sale.ID).Find(&saleItems).Error; err != nil {
+			return err
+		}
+
+		items := make(map[uint]SaleItem)
+		ids := make([]uint, len(saleItems))
+		for i, item := range saleItems {
+			items[item.ID] = item
+			ids[i] = item.ProductID
+		}
+
+		// Deleted products may still be returned
+		var sold []product.Product
+		if err := tx.Unscoped().Where("id IN (?)", ids).Find(&sold).Error; err != nil {
+			return err
+		}
+
+		products := make(map[uint]product.Product)
+		for _, v := range sold {
+			products[v.ID] = v
+		}
+
+		returned, err := returnedQuantities(tx, sale.ID)
+		if err != nil {
+			return err
+		}
+
+		for _, v := range data.Items {
+			item, ok := items[v.Item]
+			if !ok {
+				return exception.NotFound("Item penjualan")
+			}
+
+			if returned[item.ID]+v.Quantity > item.Quantity {
+				return exception.BadRequest(fmt.Sprintf("Jumlah retur melebihi jumlah penjualan (maksimal %.2f)", item.Quantity-returned[item.ID]))
+			}
+			returned[item.ID] += v.Quantity
+
+			// Only stocked products go back to stock as they were sold, the
+			// ingredients of prepared ones are used up
+			if v.Restock && !products[item.ProductID].Stock {
+				return exception.BadRequest(fmt.Sprintf("%s tidak disimpan sebagai stok sehingga tidak dapat dikembalikan ke stok", products[item.ProductID].Name))
+			}
+
+			retItem := SaleReturnItem{
+				Price:      item.Price,
+				Quantity:   v.Quantity,
+				Total:      item.Price * v.Quantity,
+				Restock:    v.Restock,
+				Status:     false,
+				ProductID:  item.ProductID,
+				SaleItemID: item.ID,
+			}
+
+			ret.Total += retItem.Total
+			ret.Items = append(ret.Items, retItem)
+		}
+
+		ret.SaleID = sale.ID
+		if sale.Type == TypeCredit {
+			ret.Credited = math.Max(math.Min(ret.Total, sale.Outstanding()), 0)
+		}
+
+		if err := tx.Create(&ret).Error; err != nil {
+			return err
+		}
+
+		if ret.Credited > 0 {
+			if err := tx.Model(&Sale{}).Where("id = ?", sale.ID).Update("credited", gorm.Expr("credited + ?", ret.Credited)).Error; err != nil {
+				return err
+			}
+		}
+
+		if link.OutletID != 0 {
+			var outlet outlet.Outlet
+			if err := tx.First(&outlet, link.OutletID).Error; err != nil {
+				return err
+			}
+
+			if err := journal.Post(tx, ret.posting(outlet.CompanyID, outlet.ID)); err != nil {
+				return err
+			}
+		}
+
+		if sale.MemberID != nil {
+			return loyalty.NewPointService(tx).Return(sale.ID, ret.ID, ret.Total)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &ret, nil
+}
+
+func (s *ReturnService) SetStatus(id int, status string, creds *common.Creds, reason string) error {
+	return exception.DB(s.db.Transaction(func(tx *gorm.DB) error {
+		var ret SaleReturn
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&ret, id).Error; err != nil {
+			return exception.DB(err, "Retur")
+		}
+
+		if err := transition.Apply(tx, transition.Change{
+			Type:   transition.TypeReturn,
+			ID:     ret.ID,
+			From:   ret.Status,
+			To:     status,
+			Creds:  creds,
+			Reason: reason,
+		}); err != nil {
+			return err
+		}
+
+		if err := tx.Model(&ret).Update("status", status).Error; err != nil {
+			return err
+		}
+
+		if status != StatusCanceled {
+			return nil
+		}
+
+		if err := checkPeriod(tx, ret.SaleID, ret.Date); err != nil {
+			return err
+		}
+
+		// The receivable and points taken off by the return are given back
+		if ret.Credited > 0 {
+			if err := tx.Model(&Sale{}).Where("id = ?", ret.SaleID).Update("credited", gorm.Expr("credited - ?", ret.Credited)).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := journal.Reverse(tx, journal.SourceReturn, ret.ID, time.Now()); err != nil {
+			return err
+		}
+
+		return loyalty.NewPointService(tx).Restore(ret.ID)
+	}))
+}
+
+// returnedQuantities sums the quantity already returned per sale item,
+// ignoring canceled returns.
+func returnedQuantities(tx *gorm.DB, saleId uint) (map[uint]float64, error) {
+	var rows []struct {
+		SaleItemID uint
+		Quantity   float64
+	}
+
+	if err := tx.Table("sale_return_items").
+		Select("sale_return_items.sale_item_id, SUM(sale_return_items.quantity) AS quantity").
+		Joins("INNER JOIN sale_returns ON sale_returns.id = sale_return_items.sale_return_id").
+		Where("sale_returns.sale_id = ? AND sale_returns.status != ?", saleId, StatusCanceled).
+		Group("sale_return_items.sale_item_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	result := make(map[uint]float64)
+	for _, row := range rows {
+		result[row.SaleItemID] = row.Quantity
+	}
+
+	return result, nil
+}
+
+func (s *ReturnService) Using(tx *gorm.DB) *ReturnService {
+	db := s.db
+
+	defer func() {
+		s.db = db
+	}()
+
+	s.db = tx.WithContext(tx.Statement.Context)
+
+	return s
+}
+
+func (s *ReturnService) WithContext(ctx context.Context) *ReturnService {
+	s.db = s.db.WithContext(ctx)
+
+	return s
+}
@@ -3,6 +3,9 @@ package sale
 import (
 	"abude-backend/internal/common"
 	"abude-backend/internal/pkg/auth"
+	"abude-backend/internal/pkg/receipt"
+	"abude-backend/internal/pkg/transition"
+	"fmt"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -38,6 +41,49 @@ func (ctrl *SaleController) One(ctx *fiber.Ctx) error {
 	return ctx.Status(fiber.StatusOK).JSON(sale)
 }
 
+// @Summary Print Sale Receipt
+// @Tags Sales
+// @Accept json
+// @Produce application/pdf
+// @Produce application/octet-stream
+// @Param id path string true "Sale ID"
+// @Param query query SaleReceiptQuery false "query"
+// @Success 200 {file} file
+// @Security JWT
+// @Router /api/sale/{id}/receipt [get]
+func (ctrl *SaleController) Receipt(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	var query SaleReceiptQuery
+	if err := ctrl.Validation.Query(&query, ctx); err != nil {
+		return err
+	}
+
+	if query.Width == 0 {
+		query.Width = receipt.Paper58
+	}
+
+	document, err := ctrl.sale.GetReceipt(id)
+	if err != nil {
+		return err
+	}
+
+	if query.Format == "escpos" {
+		ctx.Set(fiber.HeaderContentType, fiber.MIMEOctetStream)
+		ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s.bin"`, document.Code))
+
+		return ctx.Status(fiber.StatusOK).Send(document.ESCPOS(query.Width))
+	}
+
+	ctx.Set(fiber.HeaderContentType, "application/pdf")
+	ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`inline; filename="%s.pdf"`, document.Code))
+
+	return ctx.Status(fiber.StatusOK).Send(document.PDF(query.Width))
+}
+
 // @Summary Get All Sale
 // @Tags Sales
 // @Accept json
@@ -105,6 +151,9 @@ func (ctrl *SaleController) Update(ctx *fiber.Ctx) error {
 		return err
 	}
 
+	creds := auth.GetCreds(ctx.Context())
+	data.User = creds.ID
+
 	sale, err := ctrl.sale.Update(id, data)
 	if err != nil {
 		return err
@@ -168,6 +217,7 @@ func (ctrl *SaleController) GetSummary(ctx *fiber.Ctx) error {
 // @Accept json
 // @Produce json
 // @Param id path string true "Sale ID"
+// @Param request body transition.ReasonDTO false "Request Body"
 // @Success 200 {object} common.BasicResponse{}
 // @Security JWT
 // @Router /api/sale/{id}/cancel [patch]
@@ -177,7 +227,12 @@ func (ctrl *SaleController) Cancel(ctx *fiber.Ctx) error {
 		return err
 	}
 
-	if err := ctrl.sale.SetStatus(id, "canceled"); err != nil {
+	reason, err := transition.Reason(ctrl.Validation, ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := ctrl.sale.SetStatus(id, "canceled", auth.GetCreds(ctx.Context()), reason); err != nil {
 		return err
 	}
 
@@ -11,14 +11,26 @@ type SaleItemDTO struct {
 	Product  uint     `json:"product" form:"product" validate:"required,exist=products"`
 }
 
+type SalePaymentDTO struct {
+	Method string  `json:"method" form:"method" validate:"required,oneof=cash transfer qris" enums:"cash,transfer,qris"`
+	Amount float64 `json:"amount" form:"amount" validate:"required,gt=0"`
+}
+
 type SaleDTO struct {
-	Customer string        `json:"customer" form:"customer" validate:"required"`
-	Note     string        `json:"note" form:"note" validate:"omitempty"`
-	Items    []SaleItemDTO `json:"items" form:"items" validate:"required,dive,required"`
-	Source   string        `json:"source" form:"source" validate:"required,oneof=outlet warehouse"`
-	SourceID uint          `json:"sourceId" form:"sourceId" validate:"required"`
-	Date     time.Time     `json:"date" form:"date" validate:"omitempty" format:"date-time"`
-	Status   *string       `json:"status" form:"status" validate:"omitempty,oneof=accepted canceled approved" enums:"accepted,canceled,approved"`
+	Customer string           `json:"customer" form:"customer" validate:"required_without=Client"`
+	Client   *uint            `json:"client" form:"client" validate:"omitempty,exist=customers"` // Customer ID
+	Type     string           `json:"type" form:"type" validate:"omitempty,oneof=cash credit" enums:"cash,credit"`
+	DueDate  *time.Time       `json:"dueDate" form:"dueDate" validate:"omitempty" format:"date-time"`
+	Member   *uint            `json:"member" form:"member" validate:"omitempty,exist=loyalty_members"` // Loyalty Member ID
+	Points   float64          `json:"points" form:"points" validate:"omitempty,gt=0"`                  // Points to redeem
+	Note     string           `json:"note" form:"note" validate:"omitempty"`
+	Items    []SaleItemDTO    `json:"items" form:"items" validate:"required,dive,required"`
+	Tax      float64          `json:"tax" form:"tax" validate:"omitempty,gte=0"`
+	Payments []SalePaymentDTO `json:"payments" form:"payments" validate:"omitempty,dive,required"`
+	Source   string           `json:"source" form:"source" validate:"required,oneof=outlet warehouse"`
+	SourceID uint             `json:"sourceId" form:"sourceId" validate:"required"`
+	Date     time.Time        `json:"date" form:"date" validate:"omitempty" format:"date-time"`
+	Status   *string          `json:"status" form:"status" validate:"omitempty,oneof=accepted canceled approved" enums:"accepted,canceled,approved"`
 
 	User uint `json:"-" form:"-"`
 }
@@ -27,6 +39,8 @@ type SaleQuery struct {
 	pagination.Pagination
 	User      string    `query:"user"`   // User ID
 	Outlet    uint      `query:"outlet"` // Outlet ID
+	Client    uint      `query:"client"` // Customer ID
+	Type      string    `query:"type" enums:"cash,credit"`
 	Status    []string  `query:"status" enums:"accepted,approved,canceled"`
 	StartDate time.Time `query:"startDate" format:"date-time"`
 	EndDate   time.Time `query:"endDate" format:"date-time"`
@@ -38,3 +52,8 @@ type SaleSummaryQuery struct {
 	StartDate string   `query:"startDate" format:"date-time"`
 	EndDate   string   `query:"endDate" format:"date-time"`
 }
+
+type SaleReceiptQuery struct {
+	Format string `query:"format" validate:"omitempty,oneof=pdf escpos" enums:"pdf,escpos"`
+	Width  int    `query:"width" validate:"omitempty,oneof=58 80" enums:"58,80"` // Paper width in millimeters
+}
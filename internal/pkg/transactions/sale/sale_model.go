@@ -2,11 +2,13 @@ package sale
 
 import (
 	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/accounts/journal"
+	"abude-backend/internal/pkg/customer"
 	"abude-backend/internal/pkg/inventories/product"
+	"abude-backend/internal/pkg/loyalty"
 	"abude-backend/internal/pkg/outlet"
+	"abude-backend/internal/pkg/sequence"
 	"abude-backend/internal/pkg/user"
-	"abude-backend/pkg/utils"
-	"fmt"
 	"time"
 
 	"gorm.io/gorm"
@@ -18,6 +20,17 @@ const (
 	StatusCanceled = "canceled"
 )
 
+const (
+	TypeCash   = "cash"
+	TypeCredit = "credit"
+)
+
+const (
+	PaymentCash     = "cash"
+	PaymentTransfer = "transfer"
+	PaymentQris     = "qris"
+)
+
 type SaleItem struct {
 	common.BaseModel
 	Price    float64 `json:"price"`
@@ -32,19 +45,79 @@ type SaleItem struct {
 	SaleID uint  `json:"-"`
 }
 
-type Sale struct {
+// SalePayment is a tender handed over at the counter.
+type SalePayment struct {
 	common.BaseModel
-	Code     string    `json:"code" gorm:"type:varchar(50)"`
-	Note     string    `json:"note" gorm:"type:varchar(150)"`
-	Customer string    `json:"customer"`
-	Total    float64   `json:"total"`
-	Status   string    `json:"status" gorm:"type:enum('accepted','approved','canceled')" enums:"approved,accepted,canceled"`
-	Date     time.Time `json:"date"`
+	Method string  `json:"method" gorm:"type:enum('cash','transfer','qris')" enums:"cash,transfer,qris"`
+	Amount float64 `json:"amount"`
+
+	Sale   *Sale `json:"-" gorm:"constraint:OnDelete:CASCADE;"`
+	SaleID uint  `json:"-"`
+}
 
-	Items []SaleItem `json:"items" gorm:"constraint:OnDelete:CASCADE;"`
+type Sale struct {
+	common.BaseModel
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
+	Code     string     `json:"code" gorm:"type:varchar(50)"`
+	Note     string     `json:"note" gorm:"type:varchar(150)"`
+	Customer string     `json:"customer"`
+	Discount float64    `json:"discount"` // Value of redeemed loyalty points
+	Tax      float64    `json:"tax"`
+	Total    float64    `json:"total"`
+	Paid     float64    `json:"paid"`
+	Credited float64    `json:"credited"` // Returns credited against the receivable
+	Change   float64    `json:"change"`
+	Status   string     `json:"status" gorm:"type:enum('accepted','approved','canceled')" enums:"approved,accepted,canceled"`
+	Type     string     `json:"type" gorm:"type:enum('cash','credit');default:cash" enums:"cash,credit"`
+	Date     time.Time  `json:"date"`
+	DueDate  *time.Time `json:"dueDate"`
+	EditedAt *time.Time `json:"editedAt"` // Last update, see the revision history
+
+	Items    []SaleItem    `json:"items" gorm:"constraint:OnDelete:CASCADE;"`
+	Payments []SalePayment `json:"payments" gorm:"constraint:OnDelete:CASCADE;"`
+
+	Client     *customer.Customer `json:"client,omitempty" gorm:"foreignKey:CustomerID;constraint:OnDelete:RESTRICT;"`
+	CustomerID *uint              `json:"-"`
+
+	Member   *loyalty.Member `json:"member,omitempty" gorm:"constraint:OnDelete:SET NULL;"`
+	MemberID *uint           `json:"-"`
 
 	User   *user.User `json:"user,omitempty"`
 	UserID uint       `json:"-"`
+
+	// Outlet the sale is numbered for, it is linked through OutletSale
+	OutletID uint `json:"-" gorm:"-"`
+}
+
+// Outstanding returns the unpaid amount of a credit sale.
+func (sale *Sale) Outstanding() float64 {
+	return sale.Total - sale.Paid - sale.Credited
+}
+
+// posting books the revenue and tax of the sale against the cash received
+// and the receivable left.
+func (sale *Sale) posting(outlet *outlet.Outlet) journal.Posting {
+	cash := sale.Total
+	if sale.Type == TypeCredit {
+		cash = sale.Paid
+	}
+
+	return journal.Posting{
+		Company:     outlet.CompanyID,
+		Outlet:      &outlet.ID,
+		User:        &sale.UserID,
+		Date:        sale.Date,
+		Source:      journal.SourceSale,
+		SourceID:    sale.ID,
+		Description: "Penjualan " + sale.Code,
+		Lines: []journal.PostingLine{
+			{Key: journal.KeyCash, Debit: cash},
+			{Key: journal.KeyReceivable, Debit: sale.Total - cash},
+			{Key: journal.KeyRevenue, Credit: sale.Total - sale.Tax},
+			{Key: journal.KeyTax, Credit: sale.Tax},
+		},
+	}
 }
 
 type SaleSummary struct {
@@ -69,14 +142,16 @@ type OutletSale struct {
 }
 
 func (sale *Sale) BeforeCreate(tx *gorm.DB) error {
-	now := time.Now()
+	if sale.Code != "" {
+		return nil
+	}
 
-	var count int64
-	tx.Model(&Sale{}).
-		Where("DATE(created_at) = ?", now.Format("2006-01-02")).
-		Count(&count)
+	code, err := sequence.NextForOutlet(tx, sequence.TypeSale, sale.OutletID, time.Now())
+	if err != nil {
+		return err
+	}
 
-	sale.Code = fmt.Sprintf("TXS-%s%s", now.Format("20060102"), utils.NumberToDigit(int(count+1), 3))
+	sale.Code = code
 
 	return nil
 }
@@ -1,15 +1,25 @@
 package sale
 
 import (
+	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/accounts/journal"
+	"abude-backend/internal/pkg/accounts/period"
+	"abude-backend/internal/pkg/customer"
 	"abude-backend/internal/pkg/inventories/product"
+	"abude-backend/internal/pkg/loyalty"
 	"abude-backend/internal/pkg/outlet"
+	"abude-backend/internal/pkg/receipt"
+	"abude-backend/internal/pkg/revision"
+	"abude-backend/internal/pkg/transition"
 	"abude-backend/pkg/exception"
 	"abude-backend/pkg/pagination"
 	"context"
 	"fmt"
+	"math"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type SaleService struct {
@@ -27,7 +37,7 @@ func (s *SaleService) FindOne(id int) (*Sale, error) {
 	fmt.Println(awe[0].Product.Name)
 
 	var sale Sale
-	if err := s.db.Preload("User").Preload("Items").Preload("Items.Product").First(&sale, id).Error; err != nil {
+	if err := s.db.Preload("User").Preload("Client").Preload("Member").Preload("Items").Preload("Items.Product").First(&sale, id).Error; err != nil {
 		return nil, exception.DB(err)
 	}
 
@@ -37,7 +47,7 @@ func (s *SaleService) FindOne(id int) (*Sale, error) {
 func (s *SaleService) FindAll(query SaleQuery) *pagination.Result[Sale] {
 	result := pagination.New[Sale](query.Pagination)
 
-	db := s.db.Model(&Sale{}).Preload("User")
+	db := s.db.Model(&Sale{}).Preload("User").Preload("Client")
 	if query.Outlet != 0 {
 		db.Where("id IN (?)", s.db.
 			Table("outlet_sales").
@@ -53,6 +63,14 @@ func (s *SaleService) FindAll(query SaleQuery) *pagination.Result[Sale] {
 		db.Where("user_id = ?", query.User)
 	}
 
+	if query.Client != 0 {
+		db.Where("customer_id = ?", query.Client)
+	}
+
+	if query.Type != "" {
+		db.Where("type = ?", query.Type)
+	}
+
 	if !query.StartDate.IsZero() {
 		db.Where("date >= ?", query.StartDate)
 	}
@@ -68,11 +86,19 @@ func (s *SaleService) FindAll(query SaleQuery) *pagination.Result[Sale] {
 
 func (s *SaleService) Create(data SaleDTO) (*Sale, error) {
 	sale := Sale{
-		Customer: data.Customer,
-		Note:     data.Note,
-		Status:   StatusAccepted,
-		Date:     time.Now(),
-		UserID:   data.User,
+		Customer:   data.Customer,
+		Note:       data.Note,
+		Status:     StatusAccepted,
+		Type:       TypeCash,
+		Date:       time.Now(),
+		DueDate:    data.DueDate,
+		CustomerID: data.Client,
+		MemberID:   data.Member,
+		UserID:     data.User,
+	}
+
+	if data.Type != "" {
+		sale.Type = data.Type
 	}
 
 	if !data.Date.IsZero() {
@@ -104,20 +130,127 @@ func (s *SaleService) Create(data SaleDTO) (*Sale, error) {
 		sale.Items = append(sale.Items, saleItem)
 	}
 
+	var member loyalty.Member
+	if sale.MemberID != nil {
+		if err := s.db.First(&member, *sale.MemberID).Error; err != nil {
+			return nil, exception.DB(err, "Member")
+		}
+
+		if data.Points > 0 {
+			discount, err := loyalty.NewPointService(s.db).Quote(member.ID, data.Points)
+			if err != nil {
+				return nil, err
+			}
+
+			if discount > sale.Total {
+				return nil, exception.BadRequest("Nilai poin melebihi total penjualan")
+			}
+
+			sale.Discount = discount
+			sale.Total -= discount
+		}
+	} else if data.Points > 0 {
+		return nil, exception.Validation(map[string]string{
+			"member": "Penukaran poin wajib memilih member",
+		})
+	}
+
+	sale.Tax = data.Tax
+	sale.Total += data.Tax
+
+	var tendered float64
+	for _, v := range data.Payments {
+		tendered += v.Amount
+		sale.Payments = append(sale.Payments, SalePayment{
+			Method: v.Method,
+			Amount: v.Amount,
+		})
+	}
+
+	if len(sale.Payments) > 0 {
+		if sale.Type != TypeCredit && tendered < sale.Total {
+			return nil, exception.BadRequest("Pembayaran kurang dari total penjualan")
+		}
+
+		if tendered > sale.Total {
+			sale.Change = tendered - sale.Total
+		}
+
+		// A down payment on a credit sale lowers what the customer owes
+		if sale.Type == TypeCredit {
+			sale.Paid = math.Min(tendered, sale.Total)
+		}
+	}
+
+	if sale.CustomerID != nil {
+		var customer customer.Customer
+		if err := s.db.First(&customer, *sale.CustomerID).Error; err != nil {
+			return nil, exception.DB(err, "Pelanggan")
+		}
+
+		if sale.Customer == "" {
+			sale.Customer = customer.Name
+		}
+	} else if sale.Type == TypeCredit {
+		return nil, exception.Validation(map[string]string{
+			"client": "Penjualan kredit wajib memilih pelanggan",
+		})
+	}
+
 	err := s.db.Transaction(func(tx *gorm.DB) error {
-		if err := tx.Create(&sale).Error; err != nil {
-			return err
+		if sale.Type == TypeCredit {
+			if err := checkCredit(tx, *sale.CustomerID, sale.Outstanding()); err != nil {
+				return err
+			}
 		}
 
+		var outlet outlet.Outlet
 		if data.Source == "outlet" {
-			var outlet outlet.Outlet
 			if err := s.db.First(&outlet, data.SourceID).Error; err != nil {
 				return err
 			}
 
+			if sale.MemberID != nil && member.CompanyID != outlet.CompanyID {
+				return exception.BadRequest("Member tidak terdaftar di perusahaan ini")
+			}
+
+			if err := period.Check(tx, outlet.CompanyID, sale.Date); err != nil {
+				return err
+			}
+
+			sale.OutletID = outlet.ID
+		}
+
+		if err := tx.Create(&sale).Error; err != nil {
+			return err
+		}
+
+		if data.Source == "outlet" {
 			if err := tx.Create(&OutletSale{Sale: &sale, Outlet: &outlet}).Error; err != nil {
 				return err
 			}
+
+			if sale.Status != StatusCanceled {
+				if err := journal.Post(tx, sale.posting(&outlet)); err != nil {
+					return err
+				}
+			}
+		}
+
+		if sale.MemberID != nil {
+			points := loyalty.NewPointService(tx)
+			if data.Points > 0 {
+				if err := points.Redeem(member.ID, sale.ID, data.Points); err != nil {
+					return err
+				}
+			}
+
+			// Points are earned on the amount before tax of sales that stand
+			if sale.Status != StatusCanceled {
+				if err := points.Earn(member.ID, sale.ID, sale.Total-sale.Tax); err != nil {
+					return err
+				}
+			}
 		}
 
 		// if data.Source == "warehouse" {
@@ -141,36 +274,191 @@ func (s *SaleService) Create(data SaleDTO) (*Sale, error) {
 	return &sale, nil
 }
 
-func (s *SaleService) Update(id int, data SaleDTO) (*Sale, error) {
-	var sale Sale
-	if err := s.db.First(&sale, id).Error; err != nil {
-		return nil, exception.DB(err)
+// checkPeriod fails when any of the dates falls in a closed period of the
+// company the sale was made in.
+func checkPeriod(tx *gorm.DB, id uint, dates ...time.Time) error {
+	var outlet OutletSale
+	if err := tx.Where("sale_id = ?", id).Limit(1).Find(&outlet).Error; err != nil {
+		return err
 	}
 
-	sale.Customer = data.Customer
-	sale.Date = data.Date
-	sale.Note = data.Note
+	if outlet.OutletID == 0 {
+		return nil
+	}
 
-	if err := s.db.Save(&sale).Error; err != nil {
-		return nil, exception.DB(err)
+	return period.CheckOutlet(tx, outlet.OutletID, dates...)
+}
+
+// checkCredit refuses a credit sale that would push the customer's unpaid
+// balance over their credit limit. The customer stays locked until the sale
+// is saved so concurrent sales see each other.
+func checkCredit(tx *gorm.DB, customerId uint, amount float64) error {
+	var customer customer.Customer
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&customer, customerId).Error; err != nil {
+		return exception.DB(err, "Pelanggan")
 	}
 
-	return &sale, nil
+	var outstanding float64
+	if err := tx.Model(&Sale{}).
+		Select("COALESCE(SUM(total - paid - credited), 0)").
+		Where("customer_id = ? AND type = ? AND status != ?", customer.ID, TypeCredit, StatusCanceled).
+		Scan(&outstanding).Error; err != nil {
+		return err
+	}
+
+	if outstanding+amount > customer.CreditLimit {
+		return exception.BadRequest(fmt.Sprintf("Melebihi batas kredit pelanggan (sisa %.2f)", customer.CreditLimit-outstanding))
+	}
+
+	return nil
 }
 
-func (s *SaleService) SetStatus(id int, status string) error {
+// GetReceipt collects everything printed on the sale's receipt, including the
+// header and footer configured for its outlet or company.
+func (s *SaleService) GetReceipt(id int) (*receipt.Document, error) {
 	var sale Sale
-	if err := s.db.First(&sale, id).Error; err != nil {
-		return exception.DB(err)
+	if err := s.db.Preload("User").Preload("Items").Preload("Items.Product").Preload("Payments").First(&sale, id).Error; err != nil {
+		return nil, exception.DB(err, "Penjualan")
 	}
 
-	if sale.Status == status {
-		return exception.BadRequest("Status tidak berubah")
+	var outlet outlet.Outlet
+	if err := s.db.
+		Where("id IN (?)", s.db.Table("outlet_sales").Select("outlet_id").Where("sale_id = ?", sale.ID)).
+		First(&outlet).Error; err != nil {
+		return nil, exception.DB(err, "Outlet")
 	}
 
-	sale.Status = status
+	setting, err := receipt.NewService(s.db).FindSetting(outlet.CompanyID, outlet.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	document := receipt.Document{
+		Header:   setting.Header,
+		Footer:   setting.Footer,
+		Code:     sale.Code,
+		Customer: sale.Customer,
+		Date:     sale.Date,
+		Discount: sale.Discount,
+		Tax:      sale.Tax,
+		Total:    sale.Total,
+		Change:   sale.Change,
+	}
+
+	if document.Header == "" {
+		document.Header = outlet.Name + "\n" + outlet.Address
+	}
 
-	if err := s.db.Save(&sale).Error; err != nil {
+	if sale.User != nil {
+		document.Cashier = sale.User.Name
+	}
+
+	for _, item := range sale.Items {
+		name := ""
+		if item.Product != nil {
+			name = item.Product.Name
+		}
+
+		document.Subtotal += item.Total
+		document.Items = append(document.Items, receipt.Item{
+			Name:     name,
+			Quantity: item.Quantity,
+			Price:    item.Price,
+			Total:    item.Total,
+		})
+	}
+
+	for _, payment := range sale.Payments {
+		document.Payments = append(document.Payments, receipt.Payment{
+			Method: payment.Method,
+			Amount: payment.Amount,
+		})
+	}
+
+	return &document, nil
+}
+
+func (s *SaleService) Update(id int, data SaleDTO) (*Sale, error) {
+	var sale Sale
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Items").Preload("Items.Product").Preload("Payments").First(&sale, id).Error; err != nil {
+			return exception.DB(err, "Penjualan")
+		}
+
+		if err := checkPeriod(tx, sale.ID, sale.Date, data.Date); err != nil {
+			return err
+		}
+
+		before := sale
+
+		now := time.Now()
+		sale.Customer = data.Customer
+		sale.Date = data.Date
+		sale.Note = data.Note
+		sale.EditedAt = &now
+
+		if err := tx.Omit(clause.Associations).Save(&sale).Error; err != nil {
+			return err
+		}
+
+		// The entry follows the sale to its new date
+		if err := journal.Redate(tx, journal.SourceSale, sale.ID, sale.Date); err != nil {
+			return err
+		}
+
+		return revision.Save(tx, revision.TypeSale, sale.ID, before, sale, data.User)
+	}); err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return &sale, nil
+}
+
+func (s *SaleService) SetStatus(id int, status string, creds *common.Creds, reason string) error {
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		var sale Sale
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&sale, id).Error; err != nil {
+			return exception.DB(err, "Penjualan")
+		}
+
+		if err := checkPeriod(tx, sale.ID, sale.Date); err != nil {
+			return err
+		}
+
+		if status == StatusCanceled && sale.Paid > 0 {
+			return exception.BadRequest("Penjualan yang sudah dibayar tidak dapat dibatalkan")
+		}
+
+		if err := transition.Apply(tx, transition.Change{
+			Type:   transition.TypeSale,
+			ID:     sale.ID,
+			From:   sale.Status,
+			To:     status,
+			Creds:  creds,
+			Reason: reason,
+		}); err != nil {
+			return err
+		}
+
+		if err := tx.Model(&sale).Update("status", status).Error; err != nil {
+			return err
+		}
+
+		if status == StatusCanceled {
+			if err := journal.Reverse(tx, journal.SourceSale, sale.ID, time.Now()); err != nil {
+				return err
+			}
+		}
+
+		// Points earned or redeemed on a canceled sale are given back
+		if status == StatusCanceled && sale.MemberID != nil {
+			if err := loyalty.NewPointService(tx).Reverse(sale.ID); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
 		return exception.DB(err)
 	}
 
@@ -179,11 +467,39 @@ func (s *SaleService) SetStatus(id int, status string) error {
 
 func (s *SaleService) Delete(id int) (*Sale, error) {
 	var sale Sale
-	if err := s.db.First(&sale, id).Error; err != nil {
-		return nil, exception.DB(err)
-	}
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&sale, id).Error; err != nil {
+			return exception.DB(err, "Penjualan")
+		}
+
+		if sale.Status == StatusApproved {
+			return exception.BadRequest("Penjualan yang sudah masuk serah terima tidak dapat dihapus")
+		}
+
+		// Receipts allocated to the sale would be left without it
+		if sale.Paid > 0 {
+			return exception.BadRequest("Penjualan yang sudah dibayar tidak dapat dihapus")
+		}
+
+		if err := checkPeriod(tx, sale.ID, sale.Date); err != nil {
+			return err
+		}
 
-	if err := s.db.Delete(&sale).Error; err != nil {
+		if err := tx.Delete(&sale).Error; err != nil {
+			return err
+		}
+
+		if err := journal.Reverse(tx, journal.SourceSale, sale.ID, time.Now()); err != nil {
+			return err
+		}
+
+		// Points earned or redeemed on a deleted sale are given back
+		if sale.MemberID != nil {
+			return loyalty.NewPointService(tx).Reverse(sale.ID)
+		}
+
+		return nil
+	}); err != nil {
 		return nil, exception.DB(err)
 	}
 
@@ -2,6 +2,8 @@ package wage
 
 import (
 	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/auth"
+	"abude-backend/internal/pkg/transition"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -164,6 +166,7 @@ func (ctrl *WageController) GetSummary(ctx *fiber.Ctx) error {
 // @Accept json
 // @Produce json
 // @Param id path string true "Wage ID"
+// @Param request body transition.ReasonDTO false "Request Body"
 // @Success 200 {object} common.BasicResponse{}
 // @Security JWT
 // @Router /api/wage/{id}/cancel [patch]
@@ -173,7 +176,12 @@ func (ctrl *WageController) Cancel(ctx *fiber.Ctx) error {
 		return err
 	}
 
-	if err := ctrl.wage.SetStatus(id, "canceled"); err != nil {
+	reason, err := transition.Reason(ctrl.Validation, ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := ctrl.wage.SetStatus(id, "canceled", auth.GetCreds(ctx.Context()), reason); err != nil {
 		return err
 	}
 
@@ -2,12 +2,14 @@ package wage
 
 import (
 	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/accounts/journal"
 	"abude-backend/internal/pkg/company"
 	"abude-backend/internal/pkg/employee"
 	"abude-backend/internal/pkg/outlet"
+	"abude-backend/internal/pkg/sequence"
+	"abude-backend/internal/pkg/transactions/expense"
 	"abude-backend/internal/pkg/user"
-	"abude-backend/pkg/utils"
-	"fmt"
+	"abude-backend/pkg/file"
 	"time"
 
 	"gorm.io/gorm"
@@ -19,6 +21,7 @@ const (
 )
 
 const (
+	StatusDraft    = "draft" // Generated by payroll and not yet approved
 	StatusAccepted = "accepted"
 	StatusApproved = "approved"
 	StatusCanceled = "canceled"
@@ -26,14 +29,19 @@ const (
 
 type Wage struct {
 	common.BaseModel
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
 	user.WithEditor
 	Code   string    `json:"code" gorm:"type:varchar(100)"`
 	Amount float64   `json:"amount"`
 	Type   string    `json:"type" gorm:"type:enum('debit','credit')" enums:"debit,credit"`
-	Status string    `json:"status" gorm:"type:enum('accepted','approved','canceled')" enums:"approved,accepted,canceled"`
+	Status string    `json:"status" gorm:"type:enum('draft','accepted','approved','canceled')" enums:"draft,approved,accepted,canceled"`
 	Date   time.Time `json:"date"`
 	Notes  string    `json:"notes"`
 
+	// Advances (kasbon) repaid from the wage, the rest is paid out
+	Deduction float64 `json:"deduction"`
+
 	Company   *company.Company `json:"company,omitempty" gorm:"constraint:OnDelete:CASCADE;"`
 	CompanyID uint             `json:"-"`
 
@@ -42,23 +50,46 @@ type Wage struct {
 
 	Employee   *employee.Employee `json:"employee,omitempty" gorm:"constraint:OnDelete:RESTRICT;"`
 	EmployeeID uint               `json:"-"`
+
+	Expense   *expense.Expense `json:"expense,omitempty" gorm:"constraint:OnDelete:SET NULL;"`
+	ExpenseID *uint            `json:"-"`
+
+	Attachments []file.File `json:"attachments,omitempty" gorm:"many2many:wage_attachments;constraint:OnDelete:CASCADE;"`
 }
 
 func (wage *Wage) BeforeCreate(tx *gorm.DB) error {
-	if wage.Code == "" {
-		now := time.Now()
-
-		var count int64
-		tx.Model(&Wage{}).
-			Where("DATE(created_at) = ?", now.Format("2006-01-02")).
-			Count(&count)
+	if wage.Code != "" {
+		return nil
+	}
 
-		wage.Code = fmt.Sprintf("EXP-%s%s", now.Format("20060102"), utils.NumberToDigit(int(count+1), 3))
+	code, err := sequence.Next(tx, sequence.TypeWage, wage.CompanyID, wage.OutletID, time.Now())
+	if err != nil {
+		return err
 	}
 
+	wage.Code = code
+
 	return nil
 }
 
+// Posting books the advances deducted from the wage. The amount paid out is
+// posted by the expense of the wage.
+func (wage *Wage) Posting() journal.Posting {
+	return journal.Posting{
+		Company:     wage.CompanyID,
+		Outlet:      &wage.OutletID,
+		User:        wage.EditorID,
+		Date:        wage.Date,
+		Source:      journal.SourceWage,
+		SourceID:    wage.ID,
+		Description: "Potongan kasbon gaji " + wage.Code,
+		Lines: []journal.PostingLine{
+			{Key: journal.KeyWage, Debit: wage.Deduction},
+			{Key: journal.KeyEmployeeAdvance, Credit: wage.Deduction},
+		},
+	}
+}
+
 type WageSummary struct {
 	ID       uint    `json:"id"`
 	Name     string  `json:"name"`
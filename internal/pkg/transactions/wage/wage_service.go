@@ -1,14 +1,20 @@
 package wage
 
 import (
-	"abude-backend/internal/pkg/accounts/account"
+	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/accounts/journal"
+	"abude-backend/internal/pkg/accounts/period"
+	"abude-backend/internal/pkg/advance"
 	"abude-backend/internal/pkg/transactions/expense"
+	"abude-backend/internal/pkg/transition"
 	"abude-backend/pkg/exception"
 	"abude-backend/pkg/pagination"
 	"context"
+	"fmt"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type WageService struct {
@@ -21,7 +27,7 @@ func NewService(db *gorm.DB) *WageService {
 
 func (s *WageService) FindOne(id int) (*Wage, error) {
 	var wage Wage
-	if err := s.db.Preload("Employee").Preload("Outlet").Preload("Company").First(&wage, id).Error; err != nil {
+	if err := s.db.Preload("Employee").Preload("Outlet").Preload("Company").Preload("Expense").Preload("Attachments").First(&wage, id).Error; err != nil {
 		return nil, exception.DB(err)
 	}
 
@@ -50,10 +56,23 @@ func (s *WageService) FindAll(query WageQuery) *pagination.Result[Wage] {
 }
 
 func (s *WageService) Create(data WageDTO) (*Wage, error) {
+	return s.create(data, StatusAccepted, -1)
+}
+
+// Draft creates a wage and its expense that stay out of handovers until
+// they are accepted, as done when a payroll period is approved. The advance
+// deduction is only recorded on approval, so it is given here as computed.
+func (s *WageService) Draft(data WageDTO, deduction float64) (*Wage, error) {
+	return s.create(data, StatusDraft, deduction)
+}
+
+// create records a wage along with the expense of the amount paid out. A
+// negative deduction deducts the due advances of the employee right away.
+func (s *WageService) create(data WageDTO, status string, deduction float64) (*Wage, error) {
 	wage := Wage{
 		Amount:     data.Amount,
 		Type:       data.Type,
-		Status:     StatusAccepted,
+		Status:     status,
 		Date:       time.Now(),
 		Notes:      data.Notes,
 		CompanyID:  data.Company,
@@ -66,10 +85,15 @@ func (s *WageService) Create(data WageDTO) (*Wage, error) {
 	}
 
 	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := period.Check(tx, wage.CompanyID, wage.Date); err != nil {
+			return err
+		}
+
 		expenseService := expense.NewService(tx)
 
-		var acc account.Account
-		if err := tx.Where("code = ? AND (company_id = ? OR company_id IS NULL)", account.CodeExpenseWage, data.Company).First(&acc).Error; err != nil {
+		// Wages are booked to the account the company maps wages to
+		acc, err := journal.Resolve(tx, data.Company, journal.KeyWage)
+		if err != nil {
 			return err
 		}
 
@@ -77,17 +101,51 @@ func (s *WageService) Create(data WageDTO) (*Wage, error) {
 			return err
 		}
 
-		expenseService.Create(expense.ExpenseDTO{
-			Amount:  wage.Amount,
-			Type:    wage.Type,
-			Date:    data.Date,
-			Notes:   data.Notes,
-			Account: acc.ID,
-			Company: data.Company,
-			Outlet:  data.Outlet,
-		})
+		wage.Deduction = deduction
+		if deduction < 0 {
+			deducted, err := advance.Deduct(tx, wage.EmployeeID, wage.CompanyID, wage.Amount, wage.ID, wage.Date)
+			if err != nil {
+				return err
+			}
 
-		return nil
+			wage.Deduction = deducted
+		}
+
+		if net := wage.Amount - wage.Deduction; net > 0 {
+			dto := expense.ExpenseDTO{
+				Amount:  net,
+				Type:    wage.Type,
+				Date:    wage.Date,
+				Notes:   data.Notes,
+				Account: acc,
+				Company: data.Company,
+				Outlet:  data.Outlet,
+			}
+
+			if status == StatusDraft {
+				dto.Status = expense.StatusDraft
+			}
+
+			record, err := expenseService.Create(dto)
+			if err != nil {
+				return err
+			}
+
+			wage.ExpenseID = &record.ID
+		}
+
+		if err := tx.Model(&wage).Updates(map[string]interface{}{
+			"deduction":  wage.Deduction,
+			"expense_id": wage.ExpenseID,
+		}).Error; err != nil {
+			return err
+		}
+
+		if status == StatusDraft {
+			return nil
+		}
+
+		return journal.Post(tx, wage.Posting())
 	})
 
 	if err != nil {
@@ -97,58 +155,256 @@ func (s *WageService) Create(data WageDTO) (*Wage, error) {
 	return &wage, nil
 }
 
+// Update edits an accepted wage. Its advance deduction is taken again from the
+// new amount and its expense and journal entry follow the edit.
 func (s *WageService) Update(id int, data WageDTO) (*Wage, error) {
 	var wage Wage
-	if err := s.db.First(&wage, id).Error; err != nil {
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&wage, id).Error; err != nil {
+			return exception.DB(err, "Gaji")
+		}
+
+		// Edits keep the status, which only changes through SetStatus
+		if wage.Status == StatusCanceled || wage.Status == StatusApproved {
+			return exception.BadRequest(fmt.Sprintf("Gaji dengan status %s tidak dapat diubah", wage.Status))
+		}
+
+		if wage.Status == StatusDraft {
+			return exception.BadRequest("Gaji draf diubah dengan menghitung ulang periode penggajian")
+		}
+
+		if err := transition.Editable(tx, transition.TypeWage, wage.ID); err != nil {
+			return err
+		}
+
+		if err := period.Check(tx, wage.CompanyID, wage.Date); err != nil {
+			return err
+		}
+
+		// The old deduction is owed again until it is taken from the new amount
+		if err := advance.Reverse(tx, wage.ID); err != nil {
+			return err
+		}
+
+		if err := journal.Reverse(tx, journal.SourceWage, wage.ID, time.Now()); err != nil {
+			return err
+		}
+
+		wage.Amount = data.Amount
+		wage.Type = data.Type
+		wage.Notes = data.Notes
+		wage.CompanyID = data.Company
+		wage.OutletID = data.Outlet
+		wage.EmployeeID = data.Employee
+
+		if !data.Date.IsZero() {
+			wage.Date = data.Date
+		}
+
+		if err := period.Check(tx, wage.CompanyID, wage.Date); err != nil {
+			return err
+		}
+
+		deducted, err := advance.Deduct(tx, wage.EmployeeID, wage.CompanyID, wage.Amount, wage.ID, wage.Date)
+		if err != nil {
+			return err
+		}
+
+		wage.Deduction = deducted
+
+		if err := s.spend(tx, &wage); err != nil {
+			return err
+		}
+
+		if err := tx.Save(&wage).Error; err != nil {
+			return err
+		}
+
+		return journal.Post(tx, wage.Posting())
+	}); err != nil {
 		return nil, exception.DB(err)
 	}
 
-	wage.Amount = data.Amount
-	wage.Type = data.Type
-	wage.Status = StatusAccepted
-	wage.Date = data.Date
-	wage.Notes = data.Notes
-	wage.CompanyID = data.Company
-	wage.OutletID = data.Outlet
-	wage.EmployeeID = data.Employee
+	return &wage, nil
+}
 
-	if !data.Date.IsZero() {
-		wage.Date = data.Date
+// spend brings the expense of an edited wage in line with the net amount paid
+// out, creating it when there was none and removing it when nothing is paid.
+func (s *WageService) spend(tx *gorm.DB, wage *Wage) error {
+	expenseService := expense.NewService(tx)
+
+	net := wage.Amount - wage.Deduction
+	if net <= 0 {
+		if wage.ExpenseID == nil {
+			return nil
+		}
+
+		if _, err := expenseService.Delete(int(*wage.ExpenseID)); err != nil {
+			return err
+		}
+
+		wage.ExpenseID = nil
+
+		return nil
 	}
 
-	if err := s.db.Save(&wage).Error; err != nil {
-		return nil, exception.DB(err)
+	acc, err := journal.Resolve(tx, wage.CompanyID, journal.KeyWage)
+	if err != nil {
+		return err
 	}
 
-	return &wage, nil
+	dto := expense.ExpenseDTO{
+		Amount:  net,
+		Type:    wage.Type,
+		Date:    wage.Date,
+		Notes:   wage.Notes,
+		Account: acc,
+		Company: wage.CompanyID,
+		Outlet:  wage.OutletID,
+	}
+
+	if wage.ExpenseID != nil {
+		_, err := expenseService.Update(int(*wage.ExpenseID), dto)
+
+		return err
+	}
+
+	record, err := expenseService.Create(dto)
+	if err != nil {
+		return err
+	}
+
+	wage.ExpenseID = &record.ID
+
+	return nil
 }
 
 func (s *WageService) Delete(id int) (*Wage, error) {
 	var wage Wage
-	if err := s.db.First(&wage, id).Error; err != nil {
-		return nil, exception.DB(err)
-	}
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&wage, id).Error; err != nil {
+			return exception.DB(err, "Gaji")
+		}
 
-	if err := s.db.Delete(&wage).Error; err != nil {
+		if wage.Status == StatusApproved {
+			return exception.BadRequest("Gaji yang sudah masuk serah terima tidak dapat dihapus")
+		}
+
+		if err := transition.Editable(tx, transition.TypeWage, wage.ID); err != nil {
+			return err
+		}
+
+		if err := period.Check(tx, wage.CompanyID, wage.Date); err != nil {
+			return err
+		}
+
+		if err := advance.Reverse(tx, wage.ID); err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&wage).Error; err != nil {
+			return err
+		}
+
+		if err := journal.Reverse(tx, journal.SourceWage, wage.ID, time.Now()); err != nil {
+			return err
+		}
+
+		if wage.ExpenseID == nil {
+			return nil
+		}
+
+		// The wage cost booked by its expense goes along with it
+		var record expense.Expense
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Limit(1).Find(&record, *wage.ExpenseID).Error; err != nil {
+			return err
+		}
+
+		if record.ID == 0 {
+			return nil
+		}
+
+		if err := tx.Delete(&record).Error; err != nil {
+			return err
+		}
+
+		return journal.Reverse(tx, journal.SourceExpense, record.ID, time.Now())
+	}); err != nil {
 		return nil, exception.DB(err)
 	}
 
 	return &wage, nil
 }
 
-func (s *WageService) SetStatus(id int, status string) error {
-	var wage Wage
-	if err := s.db.First(&wage, id).Error; err != nil {
-		return exception.DB(err)
-	}
+func (s *WageService) SetStatus(id int, status string, creds *common.Creds, reason string) error {
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		var wage Wage
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&wage, id).Error; err != nil {
+			return exception.DB(err, "Gaji")
+		}
 
-	if wage.Status == status {
-		return exception.BadRequest("Status tidak berubah")
-	}
+		if err := period.Check(tx, wage.CompanyID, wage.Date); err != nil {
+			return err
+		}
+
+		if err := transition.Apply(tx, transition.Change{
+			Type:   transition.TypeWage,
+			ID:     wage.ID,
+			From:   wage.Status,
+			To:     status,
+			Creds:  creds,
+			Reason: reason,
+		}); err != nil {
+			return err
+		}
+
+		if err := tx.Model(&wage).Update("status", status).Error; err != nil {
+			return err
+		}
+
+		if status != StatusCanceled {
+			return nil
+		}
+
+		// The advances deducted from a canceled wage are owed again
+		if err := advance.Reverse(tx, wage.ID); err != nil {
+			return err
+		}
+
+		if err := journal.Reverse(tx, journal.SourceWage, wage.ID, time.Now()); err != nil {
+			return err
+		}
 
-	wage.Status = status
+		if wage.ExpenseID == nil {
+			return nil
+		}
+
+		var record expense.Expense
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&record, *wage.ExpenseID).Error; err != nil {
+			return err
+		}
+
+		if record.Status == expense.StatusCanceled {
+			return nil
+		}
+
+		if err := transition.Apply(tx, transition.Change{
+			Type:   transition.TypeExpense,
+			ID:     record.ID,
+			From:   record.Status,
+			To:     expense.StatusCanceled,
+			Creds:  creds,
+			Reason: reason,
+		}); err != nil {
+			return err
+		}
+
+		if err := tx.Model(&record).Update("status", expense.StatusCanceled).Error; err != nil {
+			return err
+		}
 
-	if err := s.db.Save(&wage).Error; err != nil {
+		return journal.Reverse(tx, journal.SourceExpense, record.ID, time.Now())
+	}); err != nil {
 		return exception.DB(err)
 	}
 
@@ -0,0 +1,55 @@
+package transition
+
+import (
+	"abude-backend/internal/common"
+	"abude-backend/pkg/validation"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type HistoryController struct {
+	*common.BaseController
+	history *HistoryService
+}
+
+func NewController(ctrl *common.BaseController, history *HistoryService) *HistoryController {
+	return &HistoryController{ctrl, history}
+}
+
+// @Summary Get Status History
+// @Tags Status History
+// @Accept json
+// @Produce json
+// @Param type path string true "Document Type" Enums(sale, purchase, expense, wage, sale_return, purchase_order, employee_advance, accounting_period)
+// @Param id path string true "Document ID"
+// @Success 200 {object} []History
+// @Security JWT
+// @Router /api/history/{type}/{id} [get]
+func (ctrl *HistoryController) All(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := ctrl.history.WithContext(ctx.Context()).FindAll(ctx.Params("type"), id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(result)
+}
+
+// Reason reads the optional reason of a status change. The body may be empty
+// so older clients can keep cancelling without one.
+func Reason(v *validation.Validation, ctx *fiber.Ctx) (string, error) {
+	if len(ctx.Body()) == 0 {
+		return "", nil
+	}
+
+	var data ReasonDTO
+	if err := v.Body(&data, ctx); err != nil {
+		return "", err
+	}
+
+	return data.Reason, nil
+}
@@ -0,0 +1,6 @@
+package transition
+
+// ReasonDTO is the optional body of a status change.
+type ReasonDTO struct {
+	Reason string `json:"reason" validate:"omitempty,max=255"`
+}
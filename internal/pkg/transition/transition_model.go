@@ -0,0 +1,158 @@
+package transition
+
+import (
+	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/user"
+)
+
+// Document types with status transitions.
+const (
+	TypeSale     = "sale"
+	TypePurchase = "purchase"
+	TypeExpense  = "expense"
+	TypeWage     = "wage"
+	TypeReturn   = "sale_return"
+	TypeOrder    = "purchase_order"
+	TypeAdvance  = "employee_advance"
+	TypePeriod   = "accounting_period"
+)
+
+// History is a single status change of a document. A nil user means the
+// change was made by the system, such as a handover sweeping documents in.
+type History struct {
+	common.BaseModel
+	Type       string `json:"type" gorm:"type:varchar(50);index:idx_status_history"`
+	DocumentID uint   `json:"document" gorm:"index:idx_status_history"`
+	From       string `json:"from" gorm:"type:varchar(20)"`
+	To         string `json:"to" gorm:"type:varchar(20)"`
+	Reason     string `json:"reason" gorm:"type:varchar(255)"`
+
+	User   *user.User `json:"user,omitempty" gorm:"constraint:OnDelete:SET NULL;"`
+	UserID *uint      `json:"-"`
+}
+
+func (History) TableName() string {
+	return "status_histories"
+}
+
+// Rule allows a document to move between two statuses. Roles lists who may
+// make the move; without roles only the system may.
+type Rule struct {
+	From  string
+	To    string
+	Roles []string
+}
+
+// Machine is the status rules of a document type. Locks are queries that
+// return a row when the document with the @id is part of a handover or a
+// stock recap, after which its status is final.
+type Machine struct {
+	Name  string
+	Table string
+	Rules []Rule
+	Locks []string
+}
+
+var anyone = []string{user.RoleSuperadmin, user.RoleOwner, user.RoleEmployee}
+
+var owners = []string{user.RoleSuperadmin, user.RoleOwner}
+
+var superadmin = []string{user.RoleSuperadmin}
+
+var machines = map[string]Machine{
+	TypeSale: {
+		Name:  "Penjualan",
+		Table: "sales",
+		Rules: []Rule{
+			{From: "accepted", To: "canceled", Roles: anyone},
+			{From: "accepted", To: "approved"},
+		},
+		Locks: []string{
+			"SELECT 1 FROM handover_sales WHERE sale_id = @id",
+			"SELECT 1 FROM inventory_recaps INNER JOIN outlet_sales ON outlet_sales.outlet_id = inventory_recaps.outlet_id INNER JOIN sales ON sales.id = outlet_sales.sale_id WHERE sales.id = @id AND inventory_recaps.date >= sales.date",
+		},
+	},
+	TypePurchase: {
+		Name:  "Pembelian",
+		Table: "purchases",
+		Rules: []Rule{
+			{From: "accepted", To: "canceled", Roles: anyone},
+			{From: "accepted", To: "approved"},
+		},
+		Locks: []string{
+			"SELECT 1 FROM handover_purchases WHERE purchase_id = @id",
+			"SELECT 1 FROM inventory_recaps INNER JOIN outlet_purchases ON outlet_purchases.outlet_id = inventory_recaps.outlet_id INNER JOIN purchases ON purchases.id = outlet_purchases.purchase_id WHERE purchases.id = @id AND inventory_recaps.date >= purchases.date",
+		},
+	},
+	TypeExpense: {
+		Name:  "Pengeluaran",
+		Table: "expenses",
+		Rules: []Rule{
+			{From: "draft", To: "canceled", Roles: anyone},
+			{From: "draft", To: "accepted"},
+			{From: "accepted", To: "canceled", Roles: anyone},
+			{From: "accepted", To: "approved"},
+		},
+		Locks: []string{
+			"SELECT 1 FROM handover_expenses WHERE expense_id = @id",
+		},
+	},
+	TypeWage: {
+		Name:  "Gaji",
+		Table: "wages",
+		Rules: []Rule{
+			{From: "draft", To: "canceled", Roles: anyone},
+			{From: "draft", To: "accepted"},
+			{From: "accepted", To: "canceled", Roles: anyone},
+			{From: "accepted", To: "approved"},
+		},
+		Locks: []string{
+			"SELECT 1 FROM handover_expenses INNER JOIN wages ON wages.expense_id = handover_expenses.expense_id WHERE wages.id = @id",
+		},
+	},
+	TypeReturn: {
+		Name:  "Retur",
+		Table: "sale_returns",
+		Rules: []Rule{
+			{From: "accepted", To: "canceled", Roles: anyone},
+			{From: "accepted", To: "approved"},
+		},
+		Locks: []string{
+			"SELECT 1 FROM handover_sale_returns WHERE sale_return_id = @id",
+		},
+	},
+	TypeOrder: {
+		Name:  "Pesanan pembelian",
+		Table: "purchase_orders",
+		Rules: []Rule{
+			{From: "draft", To: "ordered", Roles: anyone},
+			{From: "draft", To: "canceled", Roles: anyone},
+			{From: "ordered", To: "canceled", Roles: anyone},
+			{From: "ordered", To: "partial"},
+			{From: "ordered", To: "received"},
+			{From: "partial", To: "received"},
+			{From: "ordered", To: "closed", Roles: anyone},
+			{From: "partial", To: "closed", Roles: anyone},
+			{From: "received", To: "closed", Roles: anyone},
+		},
+	},
+	TypeAdvance: {
+		Name:  "Kasbon",
+		Table: "employee_advances",
+		Rules: []Rule{
+			{From: "accepted", To: "canceled", Roles: anyone},
+			{From: "accepted", To: "approved"},
+		},
+		Locks: []string{
+			"SELECT 1 FROM handover_advances WHERE advance_id = @id",
+		},
+	},
+	TypePeriod: {
+		Name:  "Periode akuntansi",
+		Table: "accounting_periods",
+		Rules: []Rule{
+			{From: "open", To: "closed", Roles: owners},
+			{From: "closed", To: "open", Roles: superadmin},
+		},
+	},
+}
@@ -0,0 +1,10 @@
+package transition
+
+import "abude-backend/internal/common"
+
+func LoadRoutes(r *common.Router) {
+	historyService := NewService(r.DB)
+	historyHandler := NewController(r.Controller, historyService)
+
+	r.Router.Get("/history/:type/:id", r.Auth(1), historyHandler.All)
+}
@@ -0,0 +1,191 @@
+package transition
+
+import (
+	"abude-backend/internal/common"
+	"abude-backend/pkg/exception"
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// Change is a requested status change of a document. A nil Creds means the
+// change is made by the system.
+type Change struct {
+	Type   string
+	ID     uint
+	From   string
+	To     string
+	Creds  *common.Creds
+	Reason string
+}
+
+// Apply checks a status change against the machine of its document type and
+// records it in the history. It must be called in the same transaction as the
+// status update.
+func Apply(tx *gorm.DB, change Change) error {
+	machine, ok := machines[change.Type]
+	if !ok {
+		return exception.BadRequest("Tipe dokumen tidak valid")
+	}
+
+	if change.From == change.To {
+		return exception.BadRequest("Status tidak berubah")
+	}
+
+	rule := machine.find(change.From, change.To)
+	if rule == nil {
+		return exception.BadRequest(fmt.Sprintf("%s dengan status %s tidak dapat diubah menjadi %s", machine.Name, change.From, change.To))
+	}
+
+	if change.Creds != nil && !rule.allows(change.Creds.Role) {
+		return exception.Forbidden()
+	}
+
+	locked, err := machine.locked(tx, change.ID)
+	if err != nil {
+		return err
+	}
+
+	if locked {
+		return exception.BadRequest(fmt.Sprintf("%s sudah masuk serah terima atau rekapitulasi sehingga statusnya tidak dapat diubah", machine.Name))
+	}
+
+	history := History{
+		Type:       change.Type,
+		DocumentID: change.ID,
+		From:       change.From,
+		To:         change.To,
+		Reason:     change.Reason,
+	}
+
+	if change.Creds != nil {
+		history.UserID = &change.Creds.ID
+	}
+
+	if err := tx.Create(&history).Error; err != nil {
+		return exception.DB(err, "Riwayat Status")
+	}
+
+	return nil
+}
+
+// Record stores the history of documents moved in bulk by the system, such as
+// a handover approving every document it sweeps in.
+func Record(tx *gorm.DB, docType string, ids []uint, from string, to string, reason string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	histories := make([]History, len(ids))
+	for i, id := range ids {
+		histories[i] = History{
+			Type:       docType,
+			DocumentID: id,
+			From:       from,
+			To:         to,
+			Reason:     reason,
+		}
+	}
+
+	if err := tx.Create(&histories).Error; err != nil {
+		return exception.DB(err, "Riwayat Status")
+	}
+
+	return nil
+}
+
+// Editable checks that a document is not part of a handover or a stock recap,
+// after which its contents are final along with its status.
+func Editable(tx *gorm.DB, docType string, id uint) error {
+	machine, ok := machines[docType]
+	if !ok {
+		return exception.BadRequest("Tipe dokumen tidak valid")
+	}
+
+	locked, err := machine.locked(tx, id)
+	if err != nil {
+		return err
+	}
+
+	if locked {
+		return exception.BadRequest(fmt.Sprintf("%s sudah masuk serah terima atau rekapitulasi sehingga tidak dapat diubah", machine.Name))
+	}
+
+	return nil
+}
+
+func (m Machine) locked(tx *gorm.DB, id uint) (bool, error) {
+	for _, lock := range m.Locks {
+		var found int
+		if err := tx.Raw(lock+" LIMIT 1", map[string]interface{}{"id": id}).Scan(&found).Error; err != nil {
+			return false, exception.DB(err)
+		}
+
+		if found > 0 {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (m Machine) find(from string, to string) *Rule {
+	for i := range m.Rules {
+		if m.Rules[i].From == from && m.Rules[i].To == to {
+			return &m.Rules[i]
+		}
+	}
+
+	return nil
+}
+
+func (r Rule) allows(role string) bool {
+	for _, allowed := range r.Roles {
+		if allowed == role {
+			return true
+		}
+	}
+
+	return false
+}
+
+type HistoryService struct {
+	db *gorm.DB
+}
+
+func NewService(db *gorm.DB) *HistoryService {
+	return &HistoryService{db}
+}
+
+// FindAll returns the status history of a document, oldest first.
+func (s *HistoryService) FindAll(docType string, id int) ([]History, error) {
+	machine, ok := machines[docType]
+	if !ok {
+		return nil, exception.NotFound("Tipe dokumen")
+	}
+
+	var count int64
+	if err := s.db.Table(machine.Table).Where("id = ?", id).Count(&count).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	if count == 0 {
+		return nil, exception.NotFound(machine.Name)
+	}
+
+	var histories []History
+	if err := s.db.Preload("User").Where(&History{Type: docType, DocumentID: uint(id)}).Order("id").Find(&histories).Error; err != nil {
+		return nil, exception.DB(err)
+	}
+
+	return histories, nil
+}
+
+func (s *HistoryService) Using(tx *gorm.DB) *HistoryService {
+	return &HistoryService{tx}
+}
+
+func (s *HistoryService) WithContext(ctx context.Context) *HistoryService {
+	return &HistoryService{s.db.WithContext(ctx)}
+}
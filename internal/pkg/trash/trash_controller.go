@@ -0,0 +1,87 @@
+package trash
+
+import (
+	"abude-backend/internal/common"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type TrashController struct {
+	*common.BaseController
+	trash *TrashService
+}
+
+func NewController(ctrl *common.BaseController, trash *TrashService) *TrashController {
+	return &TrashController{ctrl, trash}
+}
+
+// @Summary Get Deleted Records
+// @Tags Trash
+// @Accept json
+// @Produce json
+// @Param type path string true "Record Type" Enums(company, outlet, employee, category, product, supplier, customer, sale, purchase, expense, wage)
+// @Param query query TrashQuery false "query"
+// @Success 200 {object} common.PaginatedResponse{result=[]object}
+// @Security JWT
+// @Router /api/trash/{type} [get]
+func (ctrl *TrashController) All(ctx *fiber.Ctx) error {
+	var query TrashQuery
+	if err := ctrl.Validation.Query(&query, ctx); err != nil {
+		return err
+	}
+
+	result, err := ctrl.trash.WithContext(ctx.Context()).FindAll(ctx.Params("type"), query)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(result)
+}
+
+// @Summary Restore Deleted Record
+// @Tags Trash
+// @Accept json
+// @Produce json
+// @Param type path string true "Record Type" Enums(company, outlet, employee, category, product, supplier, customer, sale, purchase, expense, wage)
+// @Param id path string true "Record ID"
+// @Success 200 {object} common.BasicResponse{}
+// @Security JWT
+// @Router /api/trash/{type}/{id}/restore [patch]
+func (ctrl *TrashController) Restore(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := ctrl.trash.WithContext(ctx.Context()).Restore(ctx.Params("type"), id); err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.BasicResponse{
+		Message: "Data berhasil dipulihkan",
+	})
+}
+
+// @Summary Permanently Delete Record
+// @Tags Trash
+// @Accept json
+// @Produce json
+// @Param type path string true "Record Type" Enums(company, outlet, employee, category, product, supplier, customer, sale, purchase, expense, wage)
+// @Param id path string true "Record ID"
+// @Success 200 {object} common.BasicResponse{}
+// @Security JWT
+// @Router /api/trash/{type}/{id} [delete]
+func (ctrl *TrashController) Purge(ctx *fiber.Ctx) error {
+	id, err := ctrl.Validation.ParamsInt(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := ctrl.trash.WithContext(ctx.Context()).Purge(ctx.Params("type"), id); err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.BasicResponse{
+		Message: "Data berhasil dihapus permanen",
+	})
+}
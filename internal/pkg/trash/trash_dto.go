@@ -0,0 +1,7 @@
+package trash
+
+import "abude-backend/pkg/pagination"
+
+type TrashQuery struct {
+	pagination.Pagination
+}
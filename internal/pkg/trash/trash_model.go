@@ -0,0 +1,119 @@
+package trash
+
+import "abude-backend/internal/pkg/attachment"
+
+// Entity is a soft deleted table that can be listed, restored and purged.
+// References are queries that return a row when the record with the @id is
+// still used by an approved transaction, which blocks purging it.
+//
+// Dated is a query returning the company and date of a transaction, whose
+// accounting period must be open to restore or purge it. Transactions that
+// are Posted to the journal can only be restored once canceled, as deleting
+// them reversed their entries.
+type Entity struct {
+	Name       string
+	Table      string
+	Attachment string
+	References []string
+	Dated      string
+	Posted     bool
+}
+
+var entities = map[string]Entity{
+	"company": {
+		Name:  "Perusahaan",
+		Table: "companies",
+		References: []string{
+			"SELECT 1 FROM sales INNER JOIN outlet_sales ON outlet_sales.sale_id = sales.id INNER JOIN outlets ON outlets.id = outlet_sales.outlet_id WHERE outlets.company_id = @id AND sales.status = 'approved'",
+			"SELECT 1 FROM purchases INNER JOIN outlet_purchases ON outlet_purchases.purchase_id = purchases.id INNER JOIN outlets ON outlets.id = outlet_purchases.outlet_id WHERE outlets.company_id = @id AND purchases.status = 'approved'",
+			"SELECT 1 FROM expenses WHERE company_id = @id AND status = 'approved'",
+		},
+	},
+	"outlet": {
+		Name:  "Outlet",
+		Table: "outlets",
+		References: []string{
+			"SELECT 1 FROM sales INNER JOIN outlet_sales ON outlet_sales.sale_id = sales.id WHERE outlet_sales.outlet_id = @id AND sales.status = 'approved'",
+			"SELECT 1 FROM purchases INNER JOIN outlet_purchases ON outlet_purchases.purchase_id = purchases.id WHERE outlet_purchases.outlet_id = @id AND purchases.status = 'approved'",
+			"SELECT 1 FROM expenses WHERE outlet_id = @id AND status = 'approved'",
+			"SELECT 1 FROM handovers WHERE outlet_id = @id",
+		},
+	},
+	"employee": {
+		Name:  "Karyawan",
+		Table: "employees",
+		References: []string{
+			"SELECT 1 FROM wages WHERE employee_id = @id AND status = 'approved'",
+			"SELECT 1 FROM employee_advances WHERE employee_id = @id AND status = 'approved'",
+		},
+	},
+	"category": {
+		Name:  "Kategori",
+		Table: "categories",
+		References: []string{
+			"SELECT 1 FROM sale_items INNER JOIN sales ON sales.id = sale_items.sale_id INNER JOIN products ON products.id = sale_items.product_id WHERE products.category_id = @id AND sales.status = 'approved'",
+			"SELECT 1 FROM purchase_items INNER JOIN purchases ON purchases.id = purchase_items.purchase_id INNER JOIN products ON products.id = purchase_items.product_id WHERE products.category_id = @id AND purchases.status = 'approved'",
+		},
+	},
+	"product": {
+		Name:  "Produk",
+		Table: "products",
+		References: []string{
+			"SELECT 1 FROM sale_items INNER JOIN sales ON sales.id = sale_items.sale_id WHERE sale_items.product_id = @id AND sales.status = 'approved'",
+			"SELECT 1 FROM purchase_items INNER JOIN purchases ON purchases.id = purchase_items.purchase_id WHERE purchase_items.product_id = @id AND purchases.status = 'approved'",
+		},
+	},
+	"supplier": {
+		Name:  "Supplier",
+		Table: "suppliers",
+		References: []string{
+			"SELECT 1 FROM purchases WHERE supplier_id = @id AND status = 'approved'",
+		},
+	},
+	"customer": {
+		Name:  "Pelanggan",
+		Table: "customers",
+		References: []string{
+			"SELECT 1 FROM sales WHERE customer_id = @id AND status = 'approved'",
+		},
+	},
+	"sale": {
+		Name:  "Penjualan",
+		Table: "sales",
+		References: []string{
+			"SELECT 1 FROM handover_sales WHERE sale_id = @id",
+		},
+		Dated:  "SELECT outlets.company_id, sales.date FROM sales INNER JOIN outlet_sales ON outlet_sales.sale_id = sales.id INNER JOIN outlets ON outlets.id = outlet_sales.outlet_id WHERE sales.id = @id",
+		Posted: true,
+	},
+	"purchase": {
+		Name:       "Pembelian",
+		Table:      "purchases",
+		Attachment: attachment.OwnerPurchase,
+		References: []string{
+			"SELECT 1 FROM handover_purchases WHERE purchase_id = @id",
+		},
+		Dated:  "SELECT outlets.company_id, purchases.date FROM purchases INNER JOIN outlet_purchases ON outlet_purchases.purchase_id = purchases.id INNER JOIN outlets ON outlets.id = outlet_purchases.outlet_id WHERE purchases.id = @id",
+		Posted: true,
+	},
+	"expense": {
+		Name:       "Pengeluaran",
+		Table:      "expenses",
+		Attachment: attachment.OwnerExpense,
+		References: []string{
+			"SELECT 1 FROM handover_expenses WHERE expense_id = @id",
+		},
+		Dated:  "SELECT company_id, date FROM expenses WHERE id = @id",
+		Posted: true,
+	},
+	"wage": {
+		Name:       "Gaji",
+		Table:      "wages",
+		Attachment: attachment.OwnerWage,
+		References: []string{
+			"SELECT 1 FROM handover_expenses INNER JOIN wages ON wages.expense_id = handover_expenses.expense_id WHERE wages.id = @id",
+		},
+		Dated:  "SELECT company_id, date FROM wages WHERE id = @id",
+		Posted: true,
+	},
+}
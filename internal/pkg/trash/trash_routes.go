@@ -0,0 +1,15 @@
+package trash
+
+import (
+	"abude-backend/internal/common"
+	"abude-backend/internal/pkg/attachment"
+)
+
+func LoadRoutes(r *common.Router) {
+	trashService := NewService(r.DB, attachment.NewService(r.DB, r.Config))
+	trashHandler := NewController(r.Controller, trashService)
+
+	r.Router.Get("/trash/:type", r.Auth(4), trashHandler.All)
+	r.Router.Patch("/trash/:type/:id/restore", r.Auth(4), trashHandler.Restore)
+	r.Router.Delete("/trash/:type/:id", r.Auth(4), trashHandler.Purge)
+}
@@ -0,0 +1,175 @@
+package trash
+
+import (
+	"abude-backend/internal/pkg/accounts/period"
+	"abude-backend/internal/pkg/attachment"
+	"abude-backend/pkg/exception"
+	"abude-backend/pkg/file"
+	"abude-backend/pkg/pagination"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TrashService struct {
+	db          *gorm.DB
+	attachments *attachment.AttachmentService
+}
+
+func NewService(db *gorm.DB, attachments *attachment.AttachmentService) *TrashService {
+	return &TrashService{db, attachments}
+}
+
+// FindAll lists the soft deleted rows of an entity, latest deleted first.
+func (s *TrashService) FindAll(kind string, query TrashQuery) (*pagination.Result[map[string]interface{}], error) {
+	entity, ok := entities[kind]
+	if !ok {
+		return nil, exception.NotFound("Jenis data")
+	}
+
+	result := pagination.New[map[string]interface{}](query.Pagination)
+
+	db := s.db.Table(entity.Table).Where("deleted_at IS NOT NULL").Order("deleted_at DESC")
+
+	return result.Paginate(db), nil
+}
+
+// Restore brings a soft deleted row back. Its update time moves along so that
+// offline clients download it again.
+func (s *TrashService) Restore(kind string, id int) error {
+	entity, ok := entities[kind]
+	if !ok {
+		return exception.NotFound("Jenis data")
+	}
+
+	return exception.DB(s.db.Transaction(func(tx *gorm.DB) error {
+		var row struct {
+			Status string
+		}
+
+		db := tx.Table(entity.Table).Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ? AND deleted_at IS NOT NULL", id)
+		if entity.Posted {
+			db = db.Select("status")
+		} else {
+			db = db.Select("id")
+		}
+
+		result := db.Limit(1).Scan(&row)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return exception.NotFound(entity.Name)
+		}
+
+		// Deleting reversed the journal entries and points of the document,
+		// only a canceled one comes back without them
+		if entity.Posted && row.Status != "canceled" {
+			return exception.BadRequest(fmt.Sprintf("%s yang tercatat di jurnal tidak dapat dipulihkan, hanya yang dibatalkan", entity.Name))
+		}
+
+		if err := checkPeriod(tx, entity, id); err != nil {
+			return err
+		}
+
+		return tx.Table(entity.Table).Where("id = ?", id).Updates(map[string]interface{}{
+			"deleted_at": nil,
+			"updated_at": time.Now(),
+		}).Error
+	}))
+}
+
+// checkPeriod fails when the transaction falls in a closed accounting period.
+func checkPeriod(tx *gorm.DB, entity Entity, id int) error {
+	if entity.Dated == "" {
+		return nil
+	}
+
+	var dated struct {
+		CompanyID uint
+		Date      time.Time
+	}
+
+	result := tx.Raw(entity.Dated, map[string]interface{}{"id": id}).Scan(&dated)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return nil
+	}
+
+	return period.Check(tx, dated.CompanyID, dated.Date)
+}
+
+// Purge permanently deletes a soft deleted row along with its attachments.
+// Rows still used by approved transactions are kept.
+func (s *TrashService) Purge(kind string, id int) error {
+	entity, ok := entities[kind]
+	if !ok {
+		return exception.NotFound("Jenis data")
+	}
+
+	var count int64
+	if err := s.db.Table(entity.Table).Where("id = ? AND deleted_at IS NOT NULL", id).Count(&count).Error; err != nil {
+		return exception.DB(err)
+	}
+
+	if count == 0 {
+		return exception.NotFound(entity.Name)
+	}
+
+	if err := checkPeriod(s.db, entity, id); err != nil {
+		return exception.DB(err)
+	}
+
+	for _, reference := range entity.References {
+		var found int
+		if err := s.db.Raw(reference+" LIMIT 1", map[string]interface{}{"id": id}).Scan(&found).Error; err != nil {
+			return exception.DB(err)
+		}
+
+		if found > 0 {
+			return exception.BadRequest(fmt.Sprintf("%s masih digunakan oleh transaksi yang sudah disetujui", entity.Name))
+		}
+	}
+
+	var files []file.File
+	if entity.Attachment != "" {
+		attached, err := s.attachments.FindAll(entity.Attachment, id)
+		if err != nil {
+			return err
+		}
+
+		files = attached
+	}
+
+	if err := s.db.Exec(fmt.Sprintf("DELETE FROM %s WHERE id = ?", entity.Table), id).Error; err != nil {
+		if translator, ok := s.db.Dialector.(gorm.ErrorTranslator); ok {
+			err = translator.Translate(err)
+		}
+
+		if errors.Is(err, gorm.ErrForeignKeyViolated) {
+			return exception.BadRequest(fmt.Sprintf("%s masih digunakan oleh data lain", entity.Name))
+		}
+
+		return exception.DB(err)
+	}
+
+	s.attachments.Remove(files)
+
+	return nil
+}
+
+func (s *TrashService) Using(tx *gorm.DB) *TrashService {
+	return &TrashService{tx, s.attachments}
+}
+
+func (s *TrashService) WithContext(ctx context.Context) *TrashService {
+	return &TrashService{s.db.WithContext(ctx), s.attachments}
+}
@@ -26,7 +26,7 @@ func (t *Turnover) AfterFind(tx *gorm.DB) error {
 	tx.Table("sales").
 		Select("SUM(total)").
 		Joins("INNER JOIN outlet_sales ON sales.id=outlet_sales.sale_id").
-		Where("outlet_sales.outlet_id = ? AND DATE(sales.date) = DATE(?) AND sales.status != 'canceled'", t.OutletID, t.Date).Row().Scan(&income)
+		Where("outlet_sales.outlet_id = ? AND DATE(sales.date) = DATE(?) AND sales.status != 'canceled' AND sales.deleted_at IS NULL", t.OutletID, t.Date).Row().Scan(&income)
 
 	t.Income = income
 
@@ -34,7 +34,7 @@ func (t *Turnover) AfterFind(tx *gorm.DB) error {
 	tx.Table("purchases").
 		Select("SUM(total)").
 		Joins("INNER JOIN outlet_purchases ON purchases.id=outlet_purchases.purchase_id").
-		Where("outlet_purchases.outlet_id = ? AND DATE(purchases.date) = DATE(?) AND purchases.status != 'canceled'", t.OutletID, t.Date).Row().Scan(&expense)
+		Where("outlet_purchases.outlet_id = ? AND DATE(purchases.date) = DATE(?) AND purchases.status != 'canceled' AND purchases.deleted_at IS NULL", t.OutletID, t.Date).Row().Scan(&expense)
 
 	t.Expense = expense
 
@@ -40,5 +40,7 @@ func main() {
 		migrations.AutoMigrate(app.Database.DB)
 	}
 
+	app.StartJobs()
+
 	log.Fatal(app.Server.Serve())
 }
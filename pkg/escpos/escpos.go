@@ -0,0 +1,83 @@
+package escpos
+
+import (
+	"bytes"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+const (
+	esc = 0x1b
+	gs  = 0x1d
+)
+
+const (
+	AlignLeft   = 0
+	AlignCenter = 1
+	AlignRight  = 2
+)
+
+// Printer buffers ESC/POS commands for a thermal receipt printer.
+type Printer struct {
+	buf bytes.Buffer
+}
+
+// New returns a printer buffer that starts with the initialize command.
+func New() *Printer {
+	p := &Printer{}
+	p.buf.Write([]byte{esc, '@'})
+
+	return p
+}
+
+func (p *Printer) Align(align byte) *Printer {
+	p.buf.Write([]byte{esc, 'a', align})
+
+	return p
+}
+
+func (p *Printer) Bold(on bool) *Printer {
+	var n byte
+	if on {
+		n = 1
+	}
+
+	p.buf.Write([]byte{esc, 'E', n})
+
+	return p
+}
+
+// Line writes the text followed by a line feed. The text is encoded in code
+// page 437, the default of printers after initializing, and characters
+// outside of it print as a question mark.
+func (p *Printer) Line(text string) *Printer {
+	for _, r := range text {
+		b, ok := charmap.CodePage437.EncodeRune(r)
+		if !ok {
+			b = '?'
+		}
+
+		p.buf.WriteByte(b)
+	}
+	p.buf.WriteByte('\n')
+
+	return p
+}
+
+// Feed advances the paper by n lines.
+func (p *Printer) Feed(n byte) *Printer {
+	p.buf.Write([]byte{esc, 'd', n})
+
+	return p
+}
+
+// Cut feeds the paper to the cutter and does a partial cut.
+func (p *Printer) Cut() *Printer {
+	p.buf.Write([]byte{gs, 'V', 66, 0})
+
+	return p
+}
+
+func (p *Printer) Bytes() []byte {
+	return p.buf.Bytes()
+}
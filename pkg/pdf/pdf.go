@@ -0,0 +1,105 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// Points per millimeter.
+const mm = 72 / 25.4
+
+// Document is a minimal single page PDF writer for monospaced text. The
+// output only depends on its input, so generated files can be compared
+// byte for byte. Text is written in the WinAnsi encoding of the font and
+// characters outside of it print as a question mark.
+type Document struct {
+	Width    float64 // Page width in millimeters
+	Margin   float64 // Margin in millimeters
+	FontSize float64
+	Lines    []string
+}
+
+func New(width float64, fontSize float64) *Document {
+	return &Document{
+		Width:    width,
+		Margin:   2,
+		FontSize: fontSize,
+	}
+}
+
+func (d *Document) AddLine(line string) {
+	d.Lines = append(d.Lines, line)
+}
+
+// Bytes renders the document using the built-in Courier font.
+func (d *Document) Bytes() []byte {
+	leading := d.FontSize * 1.2
+	width := d.Width * mm
+	height := float64(len(d.Lines))*leading + 2*d.Margin*mm
+
+	var content bytes.Buffer
+	fmt.Fprintf(&content, "BT\n/F1 %s Tf\n%s TL\n", number(d.FontSize), number(leading))
+	fmt.Fprintf(&content, "%s %s Td\n", number(d.Margin*mm), number(height-d.Margin*mm-d.FontSize))
+	for _, line := range d.Lines {
+		fmt.Fprintf(&content, "(%s) Tj T*\n", escape(line))
+	}
+	content.WriteString("ET\n")
+
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /F1 4 0 R >> >> /Contents 5 0 R >>", number(width), number(height)),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>",
+		fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()),
+	}
+
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.4\n")
+
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+
+	return out.Bytes()
+}
+
+// escape encodes the text as a literal string in WinAnsi, the Windows-1252
+// code page. Bytes above ASCII are written as octal escapes.
+func escape(text string) string {
+	var out strings.Builder
+	for _, r := range text {
+		b, ok := charmap.Windows1252.EncodeRune(r)
+		if !ok {
+			b = '?'
+		}
+
+		switch {
+		case b == '\\' || b == '(' || b == ')':
+			out.WriteByte('\\')
+			out.WriteByte(b)
+		case b < 0x20 || b >= 0x7f:
+			fmt.Fprintf(&out, "\\%03o", b)
+		default:
+			out.WriteByte(b)
+		}
+	}
+
+	return out.String()
+}
+
+func number(value float64) string {
+	return strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.2f", value), "0"), ".")
+}
@@ -0,0 +1,48 @@
+package pdf
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestEscape(t *testing.T) {
+	cases := []struct {
+		text string
+		want string
+	}{
+		{"Teh Tarik", "Teh Tarik"},
+		{`(a\b)`, `\(a\\b\)`},
+		{"Crème Brûlée", `Cr\350me Br\373l\351e`},
+		{"Rp 5€", `Rp 5\200`},
+		{"茶", "?"},
+	}
+
+	for _, c := range cases {
+		if got := escape(c.text); got != c.want {
+			t.Errorf("escape(%q) = %q, want %q", c.text, got, c.want)
+		}
+	}
+}
+
+func TestBytesIsStable(t *testing.T) {
+	render := func() []byte {
+		document := New(58, 8)
+		document.AddLine("Crème Brûlée")
+		document.AddLine("Total 35.000")
+
+		return document.Bytes()
+	}
+
+	first := render()
+	if !bytes.Equal(first, render()) {
+		t.Fatal("rendering the same document twice gave different output")
+	}
+
+	if !bytes.HasPrefix(first, []byte("%PDF-1.4\n")) || !bytes.HasSuffix(first, []byte("%%EOF\n")) {
+		t.Fatal("output is not a complete PDF file")
+	}
+
+	if !bytes.Contains(first, []byte(`(Cr\350me Br\373l\351e) Tj`)) {
+		t.Error("text is not written in WinAnsi")
+	}
+}
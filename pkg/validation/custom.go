@@ -3,13 +3,17 @@ package validation
 import (
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/go-playground/validator/v10"
 	"gorm.io/gorm"
 )
 
 // Validates if a field value exists in a specified database table and column.
+// Soft deleted rows are treated as missing.
 func exist(db *gorm.DB) func(validator.FieldLevel) bool {
+	var softDeletes sync.Map
+
 	return func(fl validator.FieldLevel) bool {
 		params := strings.Split(fl.Param(), ".")
 		if len(params) == 0 {
@@ -21,10 +25,19 @@ func exist(db *gorm.DB) func(validator.FieldLevel) bool {
 			column = params[1]
 		}
 
+		softDelete, ok := softDeletes.Load(table)
+		if !ok {
+			softDelete = db.Migrator().HasColumn(table, "deleted_at")
+			softDeletes.Store(table, softDelete)
+		}
+
+		query := db.Table(table).Where(fmt.Sprintf("`%s` = ?", column), fl.Field().Interface())
+		if softDelete.(bool) {
+			query = query.Where("deleted_at IS NULL")
+		}
+
 		var count int64
-		db.Table(table).
-			Where(fmt.Sprintf("`%s` = ?", column), fl.Field().Interface()).
-			Count(&count)
+		query.Count(&count)
 
 		return count > 0
 	}
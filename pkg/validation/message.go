@@ -1,14 +1,15 @@
 package validation
 
 var messages = map[string]string{
-	"default":     "{{.Attribute}} tidak valid",
-	"required":    "{{.Attribute}} wajib diisi",
-	"required_if": "{{.Attribute}} wajib diisi",
-	"min":         "{{.Attribute}} harus lebih dari {{.Param}}",
-	"max":         "{{.Attribute}} harus kurang dari {{.Param}}",
-	"oneof":       "{{.Attribute}} harus salah satu dari {{.Param}}",
-	"exist":       "{{.Attribute}} tidak ditemukan",
-	"not_exist":   "{{.Attribute}} telah digunakan",
+	"default":          "{{.Attribute}} tidak valid",
+	"required":         "{{.Attribute}} wajib diisi",
+	"required_if":      "{{.Attribute}} wajib diisi",
+	"required_without": "{{.Attribute}} wajib diisi",
+	"min":              "{{.Attribute}} harus lebih dari {{.Param}}",
+	"max":              "{{.Attribute}} harus kurang dari {{.Param}}",
+	"oneof":            "{{.Attribute}} harus salah satu dari {{.Param}}",
+	"exist":            "{{.Attribute}} tidak ditemukan",
+	"not_exist":        "{{.Attribute}} telah digunakan",
 }
 
 var attributes = map[string]string{
@@ -54,4 +55,6 @@ var attributes = map[string]string{
 	"Topic":        "Topik",
 	"Gender":       "Jenis Kelamin",
 	"Amount":       "Jumlah",
+	"Customer":     "Pelanggan",
+	"Client":       "Pelanggan",
 }
@@ -0,0 +1,252 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cell styles, see styles.
+const (
+	styleDefault = iota
+	styleBold
+	styleNumber
+	styleBoldNumber
+)
+
+// Workbook is a minimal XLSX writer for tabular reports. Cells hold text or
+// numbers only, numbers are shown with two decimals. The output only depends
+// on its input, so generated files can be compared byte for byte.
+type Workbook struct {
+	sheets []*Sheet
+}
+
+type Sheet struct {
+	Name string
+	rows [][]cell
+}
+
+type cell struct {
+	value interface{}
+	bold  bool
+}
+
+func New() *Workbook {
+	return &Workbook{}
+}
+
+// AddSheet appends a worksheet. Names are cut to the 31 characters Excel
+// allows.
+func (w *Workbook) AddSheet(name string) *Sheet {
+	if len([]rune(name)) > 31 {
+		name = string([]rune(name)[:31])
+	}
+
+	sheet := &Sheet{Name: name}
+	w.sheets = append(w.sheets, sheet)
+
+	return sheet
+}
+
+// AddRow appends a row of strings and numbers. A nil value leaves the cell
+// empty.
+func (s *Sheet) AddRow(values ...interface{}) {
+	s.add(false, values)
+}
+
+// AddHeader appends a row in bold.
+func (s *Sheet) AddHeader(values ...interface{}) {
+	s.add(true, values)
+}
+
+func (s *Sheet) add(bold bool, values []interface{}) {
+	row := make([]cell, len(values))
+	for i, v := range values {
+		row[i] = cell{v, bold}
+	}
+
+	s.rows = append(s.rows, row)
+}
+
+// Bytes renders the workbook as an XLSX file.
+func (w *Workbook) Bytes() ([]byte, error) {
+	var out bytes.Buffer
+	archive := zip.NewWriter(&out)
+
+	files := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", w.contentTypes()},
+		{"_rels/.rels", rels},
+		{"xl/workbook.xml", w.workbook()},
+		{"xl/_rels/workbook.xml.rels", w.workbookRels()},
+		{"xl/styles.xml", styles},
+	}
+
+	for i, sheet := range w.sheets {
+		files = append(files, struct {
+			name    string
+			content string
+		}{fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), sheet.xml()})
+	}
+
+	for _, file := range files {
+		writer, err := archive.CreateHeader(&zip.FileHeader{
+			Name:     file.name,
+			Method:   zip.Deflate,
+			Modified: time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := writer.Write([]byte(file.content)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+
+	return out.Bytes(), nil
+}
+
+func (w *Workbook) contentTypes() string {
+	var b strings.Builder
+	b.WriteString(header)
+	b.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	b.WriteString(`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`)
+	b.WriteString(`<Default Extension="xml" ContentType="application/xml"/>`)
+	b.WriteString(`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+	b.WriteString(`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	for i := range w.sheets {
+		fmt.Fprintf(&b, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i+1)
+	}
+	b.WriteString(`</Types>`)
+
+	return b.String()
+}
+
+func (w *Workbook) workbook() string {
+	var b strings.Builder
+	b.WriteString(header)
+	b.WriteString(`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	for i, sheet := range w.sheets {
+		fmt.Fprintf(&b, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, escape(sheet.Name), i+1, i+1)
+	}
+	b.WriteString(`</sheets></workbook>`)
+
+	return b.String()
+}
+
+func (w *Workbook) workbookRels() string {
+	var b strings.Builder
+	b.WriteString(header)
+	b.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	for i := range w.sheets {
+		fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, i+1, i+1)
+	}
+	fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, len(w.sheets)+1)
+	b.WriteString(`</Relationships>`)
+
+	return b.String()
+}
+
+func (s *Sheet) xml() string {
+	var b strings.Builder
+	b.WriteString(header)
+	b.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	for i, row := range s.rows {
+		fmt.Fprintf(&b, `<row r="%d">`, i+1)
+		for j, c := range row {
+			ref := column(j) + strconv.Itoa(i+1)
+
+			style := styleDefault
+			if c.bold {
+				style = styleBold
+			}
+
+			switch v := c.value.(type) {
+			case nil:
+				continue
+			case string:
+				fmt.Fprintf(&b, `<c r="%s" s="%d" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, style, escape(v))
+			default:
+				if number, ok := numeric(v); ok {
+					fmt.Fprintf(&b, `<c r="%s" s="%d"><v>%s</v></c>`, ref, style+styleNumber, number)
+					continue
+				}
+
+				fmt.Fprintf(&b, `<c r="%s" s="%d" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, style, escape(fmt.Sprint(v)))
+			}
+		}
+		b.WriteString(`</row>`)
+	}
+	b.WriteString(`</sheetData></worksheet>`)
+
+	return b.String()
+}
+
+// column returns the letters of a zero based column index.
+func column(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+
+	return name
+}
+
+func numeric(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32), true
+	case int:
+		return strconv.Itoa(v), true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case uint:
+		return strconv.FormatUint(uint64(v), 10), true
+	case uint64:
+		return strconv.FormatUint(v, 10), true
+	}
+
+	return "", false
+}
+
+func escape(value string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(value))
+
+	return b.String()
+}
+
+const header = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n"
+
+const rels = header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+// styles holds the default, bold, number and bold number cell formats in
+// that order. Numbers use the built-in "#,##0.00" format.
+const styles = header + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="4">` +
+	`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+	`<xf numFmtId="4" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="4" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1" applyNumberFormat="1"/>` +
+	`</cellXfs>` +
+	`</styleSheet>`